@@ -0,0 +1,18 @@
+-- CreateTable
+CREATE TABLE "device_code" (
+    "id" TEXT NOT NULL,
+    "device_code" TEXT NOT NULL,
+    "user_code" TEXT NOT NULL,
+    "user_id" TEXT,
+    "client_id" TEXT,
+    "scope" TEXT,
+    "status" TEXT NOT NULL,
+    "expires_at" TIMESTAMP(3) NOT NULL,
+    "last_polled_at" TIMESTAMP(3),
+    "polling_interval" INTEGER,
+
+    CONSTRAINT "device_code_pkey" PRIMARY KEY ("id")
+);
+
+-- AddForeignKey
+ALTER TABLE "device_code" ADD CONSTRAINT "device_code_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "user"("id") ON DELETE CASCADE ON UPDATE CASCADE;
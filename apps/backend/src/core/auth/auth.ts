@@ -1,5 +1,5 @@
 import { betterAuth } from 'better-auth';
-import { bearer } from 'better-auth/plugins';
+import { bearer, deviceAuthorization } from 'better-auth/plugins';
 import { createAuthMiddleware } from 'better-auth/api';
 import { prismaAdapter } from 'better-auth/adapters/prisma';
 import { expo } from '@better-auth/expo';
@@ -26,6 +26,17 @@ const getTrustedOrigins = () => {
   return origins;
 };
 
+/**
+ * Client ID the TUI sends for `cadence login --device`.
+ */
+const DEVICE_CLIENT_ID = 'cadence-tui';
+
+/**
+ * Page of the web app where a signed-in user approves a device code.
+ */
+const getDeviceVerificationUri = () =>
+  process.env.DEVICE_VERIFICATION_URL ?? 'http://localhost:3001/device';
+
 /**
  * Plugin to support CLI/TUI OAuth flows via localhost callbacks.
  * Appends the session cookie as a query parameter to localhost redirect URLs,
@@ -72,5 +83,16 @@ export const auth = betterAuth({
   account: {
     skipStateCookieCheck: true,
   },
-  plugins: [expo(), bearer(), cliAuth()],
+  plugins: [
+    expo(),
+    bearer(),
+    cliAuth(),
+    // Device authorization grant for headless TUI logins (RFC 8628)
+    deviceAuthorization({
+      verificationUri: getDeviceVerificationUri(),
+      expiresIn: '15m',
+      interval: '5s',
+      validateClient: (clientId) => clientId === DEVICE_CLIENT_ID,
+    }),
+  ],
 });
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"cadence/internal/daemon"
+	"cadence/pkg/output"
+)
+
+var (
+	actionsOutput string
+	actionsSet    []string
+)
+
+var actionsCmd = &cobra.Command{
+	Use:   "actions",
+	Short: "Show and try out automation rules",
+	Long: `Show and try out the automation rules in the actions section of the
+config. The daemon runs them when tasks are created, updated, moved or
+deleted, when a task's status or priority changes, when a timer starts and
+on cron schedules.`,
+}
+
+var actionsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the configured rules",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runActionsCommand(func(ctx context.Context, client *daemon.Client) (interface{}, error) {
+			rules, err := client.ListActionRules(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return actionRuleList(rules), nil
+		})
+	},
+}
+
+var actionsDryRunCmd = &cobra.Command{
+	Use:   "dry-run <event|rule> [task]",
+	Short: "Show what the rules would do, without doing it",
+	Long: `Show what the rules would do, without doing it.
+
+With an event, such as task_moved or status_changed, the rules for that
+event are evaluated on a task. --set applies changes to a copy of the task
+first, as the update or move that caused the event would:
+
+  cadence actions dry-run task_moved CAD-12 --set column=Done
+  cadence actions dry-run task_updated CAD-12 --set status=done --set priority=high
+
+With a rule name the rule is evaluated as if it fired now. A scheduled rule
+with conditions lists every task it would run on:
+
+  cadence actions dry-run "Flag overdue tasks"`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var payload daemon.DryRunActionsPayload
+		switch args[0] {
+		case daemon.ActionEventTaskCreated, daemon.ActionEventTaskUpdated, daemon.ActionEventTaskMoved,
+			daemon.ActionEventTaskDeleted, daemon.ActionEventStatusChanged, daemon.ActionEventPriorityChanged,
+			daemon.ActionEventTimerStarted:
+			payload.Event = args[0]
+		default:
+			payload.Rule = args[0]
+		}
+		if len(args) == 2 {
+			payload.Task = args[1]
+		}
+
+		for _, kv := range actionsSet {
+			key, value, ok := strings.Cut(kv, "=")
+			if !ok || key == "" {
+				return fmt.Errorf("invalid --set %q, expected field=value", kv)
+			}
+			if payload.Set == nil {
+				payload.Set = make(map[string]string)
+			}
+			payload.Set[key] = value
+		}
+
+		return runActionsCommand(func(ctx context.Context, client *daemon.Client) (interface{}, error) {
+			plans, err := client.DryRunActions(ctx, payload)
+			if err != nil {
+				return nil, err
+			}
+			return actionPlans(plans), nil
+		})
+	},
+}
+
+func init() {
+	actionsDryRunCmd.Flags().StringArrayVar(&actionsSet, "set", nil, "Change a task field before evaluating (field=value)")
+
+	actionsCmd.PersistentFlags().StringVarP(&actionsOutput, "output", "o", "text", "Output format (text, json)")
+	actionsCmd.AddCommand(actionsListCmd, actionsDryRunCmd)
+}
+
+type actionRuleList []daemon.ActionRuleInfo
+
+func (l actionRuleList) Table() output.Table {
+	t := output.Table{Headers: []string{"Rule", "Trigger", "Conditions", "Actions", "Next run"}}
+	for _, r := range l {
+		next := ""
+		switch {
+		case r.Error != "":
+			next = "invalid: " + r.Error
+		case r.Disabled:
+			next = "disabled"
+		case r.NextRun != nil:
+			next = r.NextRun.Local().Format("Mon Jan 2 15:04")
+		}
+		t.Rows = append(t.Rows, []string{
+			r.Name, r.Trigger, strings.Join(r.Conditions, ", "), strings.Join(r.Actions, ", "), next,
+		})
+	}
+	return t
+}
+
+type actionPlans []daemon.ActionPlan
+
+// String lists each rule with the actions it would take, or why it would
+// not run.
+func (p actionPlans) String() string {
+	if len(p) == 0 {
+		return "No rules would run."
+	}
+
+	var b strings.Builder
+	for i, plan := range p {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		mark := "✓"
+		if !plan.Matched || plan.Error != "" {
+			mark = "✗"
+		}
+		fmt.Fprintf(&b, "%s %s (%s)", mark, plan.Rule, plan.Trigger)
+		if plan.Task != "" {
+			fmt.Fprintf(&b, " on %s", plan.Task)
+		}
+		if plan.Reason != "" {
+			fmt.Fprintf(&b, ": %s", plan.Reason)
+		}
+		for _, action := range plan.Actions {
+			fmt.Fprintf(&b, "\n    → %s", action)
+		}
+		if plan.Error != "" {
+			fmt.Fprintf(&b, "\n    error: %s", plan.Error)
+		}
+	}
+	return b.String()
+}
+
+func runActionsCommand(send func(context.Context, *daemon.Client) (interface{}, error)) error {
+	format, err := output.ParseFormat(actionsOutput)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := daemon.NewClient(cfg)
+	if err := client.Connect(); err != nil {
+		return err
+	}
+
+	result, err := send(context.Background(), client)
+	if err != nil {
+		return err
+	}
+
+	formatter := output.NewFormatter(format, os.Stdout)
+	if format == output.FormatJSON {
+		return formatter.Print(result)
+	}
+	switch v := result.(type) {
+	case actionPlans:
+		return formatter.Print(v.String())
+	case actionRuleList:
+		if len(v) == 0 {
+			return formatter.Print("No rules configured.")
+		}
+	}
+	return formatter.Print(result)
+}
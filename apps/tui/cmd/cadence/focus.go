@@ -0,0 +1,179 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cadence/internal/daemon"
+	"cadence/pkg/output"
+)
+
+var (
+	focusProjectID  string
+	focusWork       int
+	focusShortBreak int
+	focusLongBreak  int
+	focusLongEvery  int
+	focusCycles     int
+	focusOutput     string
+)
+
+var focusCmd = &cobra.Command{
+	Use:   "focus",
+	Short: "Run pomodoro-style focus sessions",
+	Long: `Run pomodoro-style focus sessions on a task.
+
+A focus session is a timer that alternates between work phases and breaks.
+The timer pauses during breaks, which are recorded on the time log, and the
+whole session is logged as one entry when it ends. The daemon notifies the
+TUI at every phase change and the TUI shows a countdown in its status bar.
+
+Phase lengths default to time_tracking.focus in the config (25 minutes of
+work, 5 minute breaks and a 15 minute break every 4 cycles).`,
+}
+
+var focusStartCmd = &cobra.Command{
+	Use:   "start [task]",
+	Short: "Start a focus session",
+	Long: `Start a focus session on a task ID or slug such as CAD-12. Without a
+task the session follows what auto-tracking is attributing time to; with
+--project-id it is logged against the project.
+
+  cadence focus start CAD-12
+  cadence focus start CAD-12 --work 50 --short-break 10 --cycles 3`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		payload := daemon.StartFocusPayload{
+			ProjectID:         focusProjectID,
+			Description:       timerDescription,
+			WorkMinutes:       focusWork,
+			ShortBreakMinutes: focusShortBreak,
+			LongBreakMinutes:  focusLongBreak,
+			LongBreakEvery:    focusLongEvery,
+			Cycles:            focusCycles,
+		}
+		if len(args) == 1 {
+			payload.TaskID = args[0]
+		}
+		return runFocusCommand(func(ctx context.Context, client *daemon.Client) (*daemon.FocusStatus, error) {
+			return client.StartFocus(ctx, payload)
+		})
+	},
+}
+
+var focusStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "End the focus session and log its time",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFocusCommand(func(ctx context.Context, client *daemon.Client) (*daemon.FocusStatus, error) {
+			return client.StopFocus(ctx)
+		})
+	},
+}
+
+var focusSkipCmd = &cobra.Command{
+	Use:   "skip",
+	Short: "End the current phase early",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFocusCommand(func(ctx context.Context, client *daemon.Client) (*daemon.FocusStatus, error) {
+			return client.SkipFocusPhase(ctx)
+		})
+	},
+}
+
+var focusStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the running focus session",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runFocusCommand(func(ctx context.Context, client *daemon.Client) (*daemon.FocusStatus, error) {
+			return client.GetFocusStatus(ctx)
+		})
+	},
+}
+
+func init() {
+	focusStartCmd.Flags().StringVar(&focusProjectID, "project-id", "", "Project to focus on when no task is given")
+	focusStartCmd.Flags().StringVarP(&timerDescription, "description", "m", "", "Description of the work")
+	focusStartCmd.Flags().IntVar(&focusWork, "work", 0, "Minutes per work phase")
+	focusStartCmd.Flags().IntVar(&focusShortBreak, "short-break", 0, "Minutes per short break")
+	focusStartCmd.Flags().IntVar(&focusLongBreak, "long-break", 0, "Minutes per long break")
+	focusStartCmd.Flags().IntVar(&focusLongEvery, "long-break-every", 0, "Work phases before a long break")
+	focusStartCmd.Flags().IntVar(&focusCycles, "cycles", 0, "End after this many work phases (0: until stopped)")
+
+	focusCmd.PersistentFlags().StringVarP(&focusOutput, "output", "o", "text", "Output format (text, json)")
+	focusCmd.AddCommand(focusStartCmd, focusStopCmd, focusSkipCmd, focusStatusCmd)
+}
+
+// focusStatusLine describes a focus session in one line, such as
+// "Work 12:34 left, cycle 2/4 (until 15:30)".
+func focusStatusLine(s *daemon.FocusStatus, now time.Time) string {
+	cycle := fmt.Sprintf("cycle %d", s.Cycle)
+	if s.Cycles > 0 {
+		cycle = fmt.Sprintf("cycle %d/%d", s.Cycle, s.Cycles)
+	}
+
+	if s.Finished {
+		return fmt.Sprintf("Focus session finished after %d cycles, %s worked.",
+			s.Cycle, (time.Duration(s.Worked) * time.Second).Round(time.Second))
+	}
+
+	remaining := s.PhaseEnd.Sub(now)
+	if remaining < 0 {
+		remaining = 0
+	}
+	return fmt.Sprintf("%s %s left, %s (until %s)", focusPhaseName(s.Phase),
+		formatCountdown(remaining), cycle, s.PhaseEnd.Local().Format("15:04"))
+}
+
+func focusPhaseName(phase string) string {
+	switch phase {
+	case daemon.FocusPhaseShortBreak:
+		return "Short break"
+	case daemon.FocusPhaseLongBreak:
+		return "Long break"
+	}
+	return "Work"
+}
+
+func formatCountdown(d time.Duration) string {
+	secs := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%02d:%02d", secs/60, secs%60)
+}
+
+func runFocusCommand(send func(context.Context, *daemon.Client) (*daemon.FocusStatus, error)) error {
+	format, err := output.ParseFormat(focusOutput)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := daemon.NewClient(cfg)
+	if err := client.Connect(); err != nil {
+		return err
+	}
+
+	status, err := send(context.Background(), client)
+	if err != nil {
+		return err
+	}
+
+	formatter := output.NewFormatter(format, os.Stdout)
+	if format == output.FormatJSON {
+		return formatter.Print(status)
+	}
+	if status == nil {
+		return formatter.Print("No focus session running.")
+	}
+	return formatter.Print(focusStatusLine(status, time.Now()))
+}
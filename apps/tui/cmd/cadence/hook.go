@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cadence/internal/daemon"
+)
+
+var (
+	hookShell string
+	hookPID   int
+	hookExit  bool
+)
+
+var hookCmd = &cobra.Command{
+	Use:   "hook",
+	Short: "Shell integration for session tracking without a multiplexer",
+	Long: `Shell integration for session tracking without a multiplexer.
+
+With session_tracking.tracker_type set to shell, the daemon follows the
+shell that showed a prompt last, in any terminal, instead of reading tmux
+or zellij. Add the prompt hook to your shell's startup file:
+
+  eval "$(cadence hook init bash)"    # ~/.bashrc
+  eval "$(cadence hook init zsh)"     # ~/.zshrc
+  cadence hook init fish | source     # ~/.config/fish/config.fish
+
+Over SSH, forward the daemon socket to the same path on the remote host and
+add the hook there too.`,
+}
+
+var hookInitCmd = &cobra.Command{
+	Use:       "init <bash|zsh|fish>",
+	Short:     "Print the prompt hook for a shell",
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: []string{"bash", "zsh", "fish"},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		exe, err := os.Executable()
+		if err != nil {
+			exe = "cadence"
+		}
+
+		script, ok := hookScripts[args[0]]
+		if !ok {
+			return fmt.Errorf("unsupported shell %q (bash, zsh or fish)", args[0])
+		}
+		fmt.Print(strings.ReplaceAll(script, "CADENCE", shellQuote(exe)))
+		return nil
+	},
+}
+
+var hookReportCmd = &cobra.Command{
+	Use:    "report",
+	Short:  "Report the shell's directory to the daemon (run by the prompt hook)",
+	Args:   cobra.NoArgs,
+	Hidden: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := loadConfig()
+		if err != nil {
+			return err
+		}
+
+		// A prompt never starts the daemon, which also keeps a shell on an
+		// SSH host without a forwarded socket from starting one there.
+		client := daemon.NewClient(cfg)
+		if !client.IsHealthy() {
+			return nil
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		return client.ReportShell(ctx, shellReport())
+	},
+}
+
+func init() {
+	hookReportCmd.Flags().StringVar(&hookShell, "shell", "", "Shell reporting (bash, zsh, fish)")
+	hookReportCmd.Flags().IntVar(&hookPID, "pid", 0, "Process ID of the shell")
+	hookReportCmd.Flags().BoolVar(&hookExit, "exit", false, "Report that the shell exits")
+
+	hookCmd.AddCommand(hookInitCmd, hookReportCmd)
+}
+
+// shellReport describes the shell running the hook from its environment.
+func shellReport() daemon.ReportShellPayload {
+	pid := hookPID
+	if pid == 0 {
+		pid = os.Getppid()
+	}
+
+	cwd := os.Getenv("PWD")
+	if cwd == "" {
+		cwd, _ = os.Getwd()
+	}
+
+	host, _ := os.Hostname()
+	tty, _ := os.Readlink("/proc/" + strconv.Itoa(pid) + "/fd/0")
+	terminal, window := terminalIdentity()
+
+	return daemon.ReportShellPayload{
+		Shell:    hookShell,
+		PID:      pid,
+		Host:     host,
+		Cwd:      cwd,
+		TTY:      tty,
+		Terminal: terminal,
+		Window:   window,
+		SSH:      os.Getenv("SSH_CONNECTION") != "",
+		Exit:     hookExit,
+	}
+}
+
+// terminalIdentity names the terminal emulator and, where it says, the
+// window or pane the shell runs in.
+func terminalIdentity() (string, string) {
+	terminal := os.Getenv("TERM_PROGRAM")
+	switch {
+	case os.Getenv("TERMINAL_EMULATOR") != "":
+		terminal = os.Getenv("TERMINAL_EMULATOR")
+	case os.Getenv("KITTY_WINDOW_ID") != "":
+		terminal = "kitty"
+	case os.Getenv("ALACRITTY_WINDOW_ID") != "":
+		terminal = "alacritty"
+	case terminal == "":
+		terminal = os.Getenv("TERM")
+	}
+
+	for _, key := range []string{"WEZTERM_PANE", "KITTY_WINDOW_ID", "ALACRITTY_WINDOW_ID", "WINDOWID"} {
+		if window := os.Getenv(key); window != "" {
+			return terminal, window
+		}
+	}
+	return terminal, ""
+}
+
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// hookScripts are the prompt hooks `cadence hook init` prints, with
+// CADENCE standing for the path of this binary. Reports run in the
+// background so a slow daemon never holds up the prompt.
+var hookScripts = map[string]string{
+	"bash": `_cadence_hook() {
+  (CADENCE hook report --shell bash --pid $$ "$@" >/dev/null 2>&1 &)
+}
+_cadence_prompt() {
+  local ret=$?
+  _cadence_hook
+  return $ret
+}
+if [[ ";${PROMPT_COMMAND:-};" != *";_cadence_prompt;"* ]]; then
+  PROMPT_COMMAND="_cadence_prompt${PROMPT_COMMAND:+;$PROMPT_COMMAND}"
+fi
+if [[ -z "$(trap -p EXIT)" ]]; then
+  trap '_cadence_hook --exit' EXIT
+fi
+`,
+	"zsh": `_cadence_precmd() {
+  CADENCE hook report --shell zsh --pid $$ >/dev/null 2>&1 &!
+}
+_cadence_zshexit() {
+  CADENCE hook report --shell zsh --pid $$ --exit >/dev/null 2>&1
+}
+autoload -Uz add-zsh-hook
+add-zsh-hook precmd _cadence_precmd
+add-zsh-hook zshexit _cadence_zshexit
+`,
+	"fish": `function __cadence_prompt --on-event fish_prompt
+    CADENCE hook report --shell fish --pid $fish_pid >/dev/null 2>&1 &
+    disown 2>/dev/null
+end
+function __cadence_exit --on-event fish_exit
+    CADENCE hook report --shell fish --pid $fish_pid --exit >/dev/null 2>&1
+end
+`,
+}
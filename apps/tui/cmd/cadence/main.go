@@ -0,0 +1,613 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/term"
+	"github.com/spf13/cobra"
+
+	"cadence/internal/buildinfo"
+	"cadence/internal/daemon"
+	"cadence/internal/infrastructure/auth"
+	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/fakebackend"
+	"cadence/tui/app"
+	"cadence/tui/kanban"
+	"cadence/tui/style"
+)
+
+var initialTab int
+
+var statusTrace bool
+
+var profileName string
+
+var rootCmd = &cobra.Command{
+	Use:   "cadence",
+	Short: "Cadence - unified project management TUI",
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		// Exported so subcommands and a daemon started on demand see the
+		// same profile.
+		if profileName != "" {
+			os.Setenv(config.ProfileEnvVar, profileName)
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTUI(initialTab)
+	},
+}
+
+var kanbanCmd = &cobra.Command{
+	Use:   "kanban",
+	Short: "Open the kanban view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTUI(app.TabKanban)
+	},
+}
+
+var agendaCmd = &cobra.Command{
+	Use:   "agenda",
+	Short: "Open the agenda view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTUI(app.TabAgenda)
+	},
+}
+
+var notesCmd = &cobra.Command{
+	Use:   "notes",
+	Short: "Open the notes view",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTUI(app.TabNotes)
+	},
+}
+
+var statusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show daemon status and active timers",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runStatus(statusTrace)
+	},
+}
+
+var (
+	loginDevice    bool
+	loginNoBrowser bool
+	loginAccount   string
+	logoutAccount  string
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Authenticate with Google OAuth",
+	Long: `Authenticate with Google OAuth.
+
+By default a browser is opened and the login completes on a localhost
+callback. Over SSH or on headless machines use --device to approve a code
+from another device, or --no-browser to print the sign-in URL and paste the
+resulting callback URL or token back in.
+
+With --account the login is stored under that name and becomes the active
+account, so several Google accounts can stay signed in side by side.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLogin(loginDevice, loginNoBrowser, loginAccount)
+	},
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove stored authentication token",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runLogout(logoutAccount)
+	},
+}
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage signed-in accounts",
+}
+
+var authListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List signed-in accounts",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuthList()
+	},
+}
+
+var authTokenCmd = &cobra.Command{
+	Use:   "token",
+	Short: "Manage the API token used instead of a login",
+}
+
+var authTokenSetCmd = &cobra.Command{
+	Use:   "set",
+	Short: "Store an API token read from stdin",
+	Long: `Store an API token read from stdin.
+
+The token replaces the login session for the TUI and the daemon, so CI jobs
+and scripts never need the browser login:
+
+  echo "$CADENCE_TOKEN" | cadence auth token set
+
+CADENCE_API_TOKEN and backend.api_token in config.yml take precedence over
+a stored token.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuthTokenSet()
+	},
+}
+
+var authTokenClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the stored API token",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuthTokenClear()
+	},
+}
+
+var authUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Switch the active account",
+	Long: `Switch the active account.
+
+A running daemon picks up the new account right away: it stops running
+timers under the old account, clears its response cache and reloads
+connected TUIs.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAuthUse(args[0])
+	},
+}
+
+func init() {
+	if fakebackend.EnabledFromEnv() && buildinfo.BackendURL == "" {
+		buildinfo.BackendURL = fakebackend.BaseURL
+	}
+
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Config profile to use (also "+config.ProfileEnvVar+")")
+
+	rootCmd.AddCommand(kanbanCmd)
+	rootCmd.AddCommand(agendaCmd)
+	rootCmd.AddCommand(notesCmd)
+	statusCmd.Flags().BoolVar(&statusTrace, "trace", false, "Show recent slow or failed requests")
+	rootCmd.AddCommand(statusCmd)
+	loginCmd.Flags().BoolVar(&loginDevice, "device", false, "Sign in by approving a device code in any browser")
+	loginCmd.Flags().BoolVar(&loginNoBrowser, "no-browser", false, "Print the sign-in URL instead of opening a browser")
+	loginCmd.MarkFlagsMutuallyExclusive("device", "no-browser")
+	loginCmd.Flags().StringVar(&loginAccount, "account", "", "Account name to sign in to (default: the active account)")
+	rootCmd.AddCommand(loginCmd)
+	logoutCmd.Flags().StringVar(&logoutAccount, "account", "", "Account to sign out of (default: the active account)")
+	rootCmd.AddCommand(logoutCmd)
+	authCmd.AddCommand(authListCmd)
+	authCmd.AddCommand(authUseCmd)
+	authTokenCmd.AddCommand(authTokenSetCmd)
+	authTokenCmd.AddCommand(authTokenClearCmd)
+	authCmd.AddCommand(authTokenCmd)
+	rootCmd.AddCommand(authCmd)
+	rootCmd.AddCommand(timeCmd)
+	rootCmd.AddCommand(focusCmd)
+	rootCmd.AddCommand(remindCmd)
+	rootCmd.AddCommand(actionsCmd)
+	rootCmd.AddCommand(hookCmd)
+}
+
+func loadConfig() (*config.Config, error) {
+	loader, err := config.NewLoader()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config loader: %w", err)
+	}
+	loader.SetProfile(profileName)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+	return cfg, nil
+}
+
+func ensureAuth(cfg *config.Config) error {
+	if fakebackend.EnabledFromEnv() {
+		return nil
+	}
+
+	apiToken, err := auth.APIToken(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to load API token: %w", err)
+	}
+	if apiToken != "" {
+		return nil
+	}
+
+	accounts, err := auth.NewAccounts(cfg.Auth)
+	if err != nil {
+		return err
+	}
+
+	account, err := accounts.Active()
+	if err != nil {
+		return err
+	}
+
+	tokenStore, err := accounts.Store(account)
+	if err != nil {
+		return fmt.Errorf("failed to create token store: %w", err)
+	}
+
+	if tokenStore.Exists() {
+		return nil
+	}
+
+	fmt.Println("No authentication token found. Starting login...")
+	if err := login(cfg, tokenStore, false, !auth.HasLocalBrowser()); err != nil {
+		return fmt.Errorf("authentication failed: %w", err)
+	}
+	if err := accounts.Add(account); err != nil {
+		return err
+	}
+
+	fmt.Println("Login successful!")
+
+	reloadDaemonToken(cfg, accounts)
+	return nil
+}
+
+// reloadDaemonToken points a running daemon at the active account's token
+// and records the account's email for `cadence auth list`.
+func reloadDaemonToken(cfg *config.Config, accounts *auth.Accounts) {
+	client := daemon.NewClient(cfg)
+	if !client.IsHealthy() {
+		return
+	}
+
+	ctx := context.Background()
+	if err := client.ReloadToken(ctx); err != nil {
+		fmt.Printf("Warning: daemon could not reload the token: %v\n", err)
+		return
+	}
+
+	whoami, err := client.WhoAmI(ctx)
+	if err == nil && whoami.Authenticated && whoami.User != nil {
+		_ = accounts.SetEmail(whoami.Account, whoami.User.Email)
+	}
+}
+
+func login(cfg *config.Config, tokenStore auth.TokenStore, device, noBrowser bool) error {
+	switch {
+	case device:
+		return auth.NewDeviceFlow(cfg.Backend.URL, tokenStore).Execute(context.Background())
+	case noBrowser:
+		return auth.NewOAuthFlow(cfg.Backend.URL, tokenStore).ExecuteManual(os.Stdin)
+	default:
+		return auth.NewOAuthFlow(cfg.Backend.URL, tokenStore).Execute()
+	}
+}
+
+func runTUI(tab int) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if err := ensureAuth(cfg); err != nil {
+		return err
+	}
+
+	style.InitStyles(cfg)
+	kanban.InitKeybindings(cfg)
+
+	daemonClient := daemon.NewClient(cfg)
+
+	model := app.NewAppModel(cfg, daemonClient, tab)
+	p := tea.NewProgram(model, tea.WithAltScreen())
+
+	if _, err := p.Run(); err != nil {
+		return fmt.Errorf("TUI error: %w", err)
+	}
+
+	return nil
+}
+
+func runLogin(device, noBrowser bool, account string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	accounts, err := auth.NewAccounts(cfg.Auth)
+	if err != nil {
+		return err
+	}
+
+	if account == "" {
+		if account, err = accounts.Active(); err != nil {
+			return err
+		}
+	}
+
+	tokenStore, err := accounts.Store(account)
+	if err != nil {
+		return fmt.Errorf("failed to create token store: %w", err)
+	}
+
+	if err := login(cfg, tokenStore, device, noBrowser); err != nil {
+		return fmt.Errorf("login failed: %w", err)
+	}
+	if err := accounts.Add(account); err != nil {
+		return err
+	}
+
+	fmt.Printf("Login successful! Active account: %s\n", account)
+
+	reloadDaemonToken(cfg, accounts)
+	return nil
+}
+
+func runLogout(account string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	accounts, err := auth.NewAccounts(cfg.Auth)
+	if err != nil {
+		return err
+	}
+
+	active, err := accounts.Active()
+	if err != nil {
+		return err
+	}
+	if account == "" {
+		account = active
+	}
+
+	if err := accounts.Remove(account); err != nil {
+		return fmt.Errorf("logout failed: %w", err)
+	}
+
+	fmt.Printf("Logged out of %s.\n", account)
+
+	// Logging out of the active account hands over to the next one.
+	if account == active {
+		if next, err := accounts.ActiveStore(); err == nil && next.Exists() {
+			reloadDaemonToken(cfg, accounts)
+		}
+	}
+	return nil
+}
+
+func runAuthList() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	accounts, err := auth.NewAccounts(cfg.Auth)
+	if err != nil {
+		return err
+	}
+
+	list, active, err := accounts.List()
+	if err != nil {
+		return err
+	}
+
+	if len(list) == 0 {
+		fmt.Println("No accounts. Run `cadence login --account <name>` to add one.")
+		return nil
+	}
+
+	for _, a := range list {
+		marker := " "
+		if a.Name == active {
+			marker = "*"
+		}
+		email := a.Email
+		if email == "" {
+			email = "-"
+		}
+		fmt.Printf("%s %-16s %s\n", marker, a.Name, email)
+	}
+	return nil
+}
+
+func runAuthTokenSet() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	token, err := readAPIToken()
+	if err != nil {
+		return err
+	}
+
+	store, err := auth.NewAPITokenStore(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to create token store: %w", err)
+	}
+	if err := store.Save(token); err != nil {
+		return fmt.Errorf("failed to save API token: %w", err)
+	}
+
+	fmt.Println("API token saved.")
+	if os.Getenv(auth.APITokenEnvVar) != "" || cfg.Backend.APIToken != "" {
+		fmt.Printf("Note: %s or backend.api_token is set and takes precedence.\n", auth.APITokenEnvVar)
+	}
+
+	accounts, err := auth.NewAccounts(cfg.Auth)
+	if err != nil {
+		return err
+	}
+	reloadDaemonToken(cfg, accounts)
+	return nil
+}
+
+// readAPIToken prompts without echo on a terminal and otherwise reads all
+// of stdin, so the token can be piped in from a secret store.
+func readAPIToken() (string, error) {
+	var raw []byte
+	var err error
+	if term.IsTerminal(os.Stdin.Fd()) {
+		fmt.Fprint(os.Stderr, "API token: ")
+		raw, err = term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(os.Stderr)
+	} else {
+		raw, err = io.ReadAll(os.Stdin)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to read API token: %w", err)
+	}
+
+	token := strings.TrimSpace(string(raw))
+	if token == "" {
+		return "", fmt.Errorf("no API token given on stdin")
+	}
+	return token, nil
+}
+
+func runAuthTokenClear() error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	store, err := auth.NewAPITokenStore(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("failed to create token store: %w", err)
+	}
+	if err := store.Clear(); err != nil {
+		return fmt.Errorf("failed to remove API token: %w", err)
+	}
+
+	fmt.Println("API token removed.")
+
+	accounts, err := auth.NewAccounts(cfg.Auth)
+	if err != nil {
+		return err
+	}
+	if active, err := accounts.ActiveStore(); err == nil && active.Exists() {
+		reloadDaemonToken(cfg, accounts)
+	}
+	return nil
+}
+
+func runAuthUse(name string) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	accounts, err := auth.NewAccounts(cfg.Auth)
+	if err != nil {
+		return err
+	}
+
+	if err := accounts.Use(name); err != nil {
+		return err
+	}
+
+	fmt.Printf("Switched to account %s.\n", name)
+	reloadDaemonToken(cfg, accounts)
+	return nil
+}
+
+func runStatus(showTrace bool) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	if cfg.Profile != "" {
+		fmt.Printf("Profile: %s\n", cfg.Profile)
+	}
+	fmt.Printf("Backend: %s\n", cfg.Backend.URL)
+
+	client := daemon.NewClient(cfg)
+
+	if !client.IsHealthy() {
+		fmt.Println("Daemon: not running")
+		return nil
+	}
+
+	fmt.Println("Daemon: running")
+	printAccount(client)
+
+	if showTrace {
+		return printTraces(client)
+	}
+	return nil
+}
+
+func printAccount(client *daemon.Client) {
+	whoami, err := client.WhoAmI(context.Background())
+	if err != nil {
+		fmt.Printf("Account: unknown (%v)\n", err)
+		return
+	}
+
+	if !whoami.Authenticated {
+		reason := whoami.Reason
+		if reason == "" {
+			reason = "not signed in"
+		}
+		fmt.Printf("Account: %s (run `cadence login`)\n", reason)
+		return
+	}
+
+	account := whoami.User.Email
+	if whoami.User.Name != "" {
+		account = fmt.Sprintf("%s <%s>", whoami.User.Name, whoami.User.Email)
+	}
+	if whoami.Account != "" {
+		account += " [" + whoami.Account + "]"
+	}
+	fmt.Printf("Account: %s\n", account)
+	if whoami.ExpiresAt != nil {
+		fmt.Printf("Session expires: %s\n", whoami.ExpiresAt.Local().Format("2006-01-02 15:04"))
+	}
+}
+
+func printTraces(client *daemon.Client) error {
+	traces, err := client.GetTraces(context.Background())
+	if err != nil {
+		return fmt.Errorf("failed to get traces: %w", err)
+	}
+
+	fmt.Printf("\nSlow requests (over %dms) and failures:\n", traces.SlowThresholdMs)
+	if len(traces.Traces) == 0 {
+		fmt.Println("  none")
+		return nil
+	}
+
+	for _, t := range traces.Traces {
+		fmt.Printf("  %s  %s  %-20s %s\n",
+			t.StartedAt.Local().Format("15:04:05"), t.RequestID, t.Type, t.Duration.Round(time.Millisecond))
+		for _, span := range t.Spans {
+			line := fmt.Sprintf("      %-40s %s", span.Name, span.Duration.Round(time.Microsecond))
+			if span.Error != "" {
+				line += "  error: " + span.Error
+			}
+			fmt.Println(line)
+		}
+		if t.Error != "" {
+			fmt.Printf("      error: %s\n", t.Error)
+		}
+	}
+	return nil
+}
+
+func main() {
+	if err := rootCmd.Execute(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+}
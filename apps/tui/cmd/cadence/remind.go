@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cadence/internal/daemon"
+	"cadence/pkg/output"
+	"cadence/pkg/timeparse"
+)
+
+var remindOutput string
+
+var remindCmd = &cobra.Command{
+	Use:   "remind",
+	Short: "List, snooze and dismiss reminders",
+	Long: `List, snooze and dismiss the reminders the daemon rings.
+
+The daemon reminds of tasks before their due date, of agenda items before
+they start and of the backend's alarm plans for routines. Reminders show
+through the notifiers in the reminders section of the config: desktop
+notifications over D-Bus or notify-send, tmux messages and the terminal
+bell.`,
+}
+
+var remindListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List upcoming and recent reminders",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRemindCommand(func(ctx context.Context, client *daemon.Client) (interface{}, error) {
+			reminders, err := client.ListReminders(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return reminderList(reminders), nil
+		})
+	},
+}
+
+var remindSnoozeCmd = &cobra.Command{
+	Use:   "snooze <id> [duration]",
+	Short: "Ring a reminder again later",
+	Long: `Ring a reminder again after a duration such as 10m or 1h, by default
+reminders.snooze_minutes. The ID may be shortened to any unique prefix.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var d time.Duration
+		if len(args) == 2 {
+			var err error
+			if d, err = timeparse.ParseDuration(args[1]); err != nil {
+				return err
+			}
+		}
+		return runRemindCommand(func(ctx context.Context, client *daemon.Client) (interface{}, error) {
+			reminder, err := client.SnoozeReminder(ctx, args[0], d)
+			if err != nil {
+				return nil, err
+			}
+			return fmt.Sprintf("Snoozed %q until %s.", reminder.Title, reminder.FireAt.Local().Format("15:04")), nil
+		})
+	},
+}
+
+var remindDismissCmd = &cobra.Command{
+	Use:   "dismiss <id>",
+	Short: "Silence a reminder",
+	Long:  `Silence a reminder, including further repeats of an alarm. The ID may be shortened to any unique prefix.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRemindCommand(func(ctx context.Context, client *daemon.Client) (interface{}, error) {
+			reminder, err := client.DismissReminder(ctx, args[0])
+			if err != nil {
+				return nil, err
+			}
+			return fmt.Sprintf("Dismissed %q.", reminder.Title), nil
+		})
+	},
+}
+
+var remindTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Send a test notification",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runRemindCommand(func(ctx context.Context, client *daemon.Client) (interface{}, error) {
+			if err := client.TestNotification(ctx); err != nil {
+				return nil, err
+			}
+			return "Test notification sent.", nil
+		})
+	},
+}
+
+func init() {
+	remindCmd.PersistentFlags().StringVarP(&remindOutput, "output", "o", "text", "Output format (text, json, csv, markdown)")
+	remindCmd.AddCommand(remindListCmd, remindSnoozeCmd, remindDismissCmd, remindTestCmd)
+}
+
+type reminderList []daemon.Reminder
+
+func (l reminderList) Table() output.Table {
+	t := output.Table{Headers: []string{"ID", "When", "Reminder", "State"}}
+	for _, r := range l {
+		state := "scheduled"
+		switch {
+		case r.Snoozed:
+			state = "snoozed"
+		case r.Fired:
+			state = "rung"
+		}
+		title := r.Title
+		if r.Body != "" {
+			title += " (" + r.Body + ")"
+		}
+		t.Rows = append(t.Rows, []string{r.ID, r.FireAt.Local().Format("Mon 15:04"), title, state})
+	}
+	return t
+}
+
+func runRemindCommand(send func(context.Context, *daemon.Client) (interface{}, error)) error {
+	format, err := output.ParseFormat(remindOutput)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := daemon.NewClient(cfg)
+	if err := client.Connect(); err != nil {
+		return err
+	}
+
+	result, err := send(context.Background(), client)
+	if err != nil {
+		return err
+	}
+
+	formatter := output.NewFormatter(format, os.Stdout)
+	if list, ok := result.(reminderList); ok && len(list) == 0 && format == output.FormatText {
+		return formatter.Print("No reminders.")
+	}
+	return formatter.Print(result)
+}
@@ -0,0 +1,333 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cadence/internal/daemon"
+	"cadence/pkg/output"
+	"cadence/pkg/timeparse"
+)
+
+var (
+	timerID          string
+	timerProjectID   string
+	timerTaskID      string
+	timerDescription string
+	timerOutput      string
+)
+
+var timeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Control time tracking timers",
+	Long: `Control time tracking timers in the daemon.
+
+Commands that act on a running timer pick it with --timer, --project-id or
+--task-id. Without them the only running manual timer is used.`,
+}
+
+var timeStartCmd = &cobra.Command{
+	Use:   "start",
+	Short: "Start a timer for a project or task",
+	Long: `Start a timer for a project or task. With only --task-id the
+project is looked up from the task.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if timerProjectID == "" && timerTaskID == "" {
+			return fmt.Errorf("--project-id or --task-id is required")
+		}
+		return runTimerCommand(func(ctx context.Context, client *daemon.Client) (*daemon.Response, error) {
+			return client.StartTimer(ctx, timerProjectID, timerTaskID, timerDescription)
+		})
+	},
+}
+
+var timeStopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop a timer and log its time",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTimerCommand(func(ctx context.Context, client *daemon.Client) (*daemon.Response, error) {
+			return client.StopTimerByRef(ctx, timerRef())
+		})
+	},
+}
+
+var timePauseCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "Pause a timer without ending its log",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTimerCommand(func(ctx context.Context, client *daemon.Client) (*daemon.Response, error) {
+			return client.PauseTimer(ctx, timerRef())
+		})
+	},
+}
+
+var timeResumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Resume a paused timer",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTimerCommand(func(ctx context.Context, client *daemon.Client) (*daemon.Response, error) {
+			return client.ResumeTimer(ctx, timerRef())
+		})
+	},
+}
+
+var timeEditNewTask string
+
+var timeEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Change the description or task of a running timer",
+	Long: `Change the description or task of a running timer.
+
+  cadence time edit -m "code review"
+  cadence time edit --set-task <task-id>
+  cadence time edit --set-task ""        # detach from the task`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var description, taskID *string
+		if cmd.Flags().Changed("description") {
+			description = &timerDescription
+		}
+		if cmd.Flags().Changed("set-task") {
+			taskID = &timeEditNewTask
+		}
+		if description == nil && taskID == nil {
+			return fmt.Errorf("nothing to change: pass --description or --set-task")
+		}
+		return runTimerCommand(func(ctx context.Context, client *daemon.Client) (*daemon.Response, error) {
+			return client.UpdateTimer(ctx, timerRef(), description, taskID)
+		})
+	},
+}
+
+var timeDiscardCmd = &cobra.Command{
+	Use:   "discard",
+	Short: "Drop a running timer without logging it",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTimerCommand(func(ctx context.Context, client *daemon.Client) (*daemon.Response, error) {
+			return client.DiscardTimer(ctx, timerRef())
+		})
+	},
+}
+
+var timeActiveCmd = &cobra.Command{
+	Use:   "active",
+	Short: "List running timers",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTimerCommand(func(ctx context.Context, client *daemon.Client) (*daemon.Response, error) {
+			return client.GetActiveTimers(ctx)
+		})
+	},
+}
+
+var (
+	timeLogAt           string
+	timeLogAllowOverlap bool
+)
+
+var timeLogCmd = &cobra.Command{
+	Use:   "log [task] <duration>",
+	Short: "Log time after the fact",
+	Long: `Log time after the fact. The task is a task ID or slug such as CAD-12;
+with --project-id it can be left out to log against the project.
+
+  cadence time log CAD-12 1h30m --at "yesterday 14:00" -m "pairing"
+  cadence time log CAD-12 "45 min"             # ended just now
+  cadence time log --project-id <id> 2h --at "monday 9am"
+
+Durations accept 1h30m, 1.5h, 90m, 90 min, 1:30 or a number of minutes.
+--at is when the work started: now-relative ("2h ago"), a day and time
+("yesterday 14:00", "last friday 9:30", "2pm") or a date ("2026-02-15 14:00").
+Without --at the entry ends now. Entries overlapping a running timer or a
+log on the same task are rejected unless --allow-overlap is given.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		taskRef := ""
+		if len(args) == 2 {
+			taskRef, args = args[0], args[1:]
+		} else if timerProjectID == "" {
+			return fmt.Errorf("give a task or --project-id")
+		}
+		return runTimeLog(taskRef, args[0])
+	},
+}
+
+func init() {
+	for _, cmd := range []*cobra.Command{timeStopCmd, timePauseCmd, timeResumeCmd, timeEditCmd, timeDiscardCmd} {
+		cmd.Flags().StringVar(&timerID, "timer", "", "ID of the timer")
+		cmd.Flags().StringVar(&timerProjectID, "project-id", "", "Project the timer was started for")
+		cmd.Flags().StringVar(&timerTaskID, "task-id", "", "Task the timer was started for")
+	}
+	timeStartCmd.Flags().StringVar(&timerProjectID, "project-id", "", "Project to track")
+	timeStartCmd.Flags().StringVar(&timerTaskID, "task-id", "", "Task to track")
+	for _, cmd := range []*cobra.Command{timeStartCmd, timeEditCmd} {
+		cmd.Flags().StringVarP(&timerDescription, "description", "m", "", "Description of the work")
+	}
+	timeEditCmd.Flags().StringVar(&timeEditNewTask, "set-task", "", "Move the timer to this task")
+	timeLogCmd.Flags().StringVar(&timerProjectID, "project-id", "", "Project to log against")
+	timeLogCmd.Flags().StringVar(&timeLogAt, "at", "", "When the work started (default: duration before now)")
+	timeLogCmd.Flags().StringVarP(&timerDescription, "description", "m", "", "Description of the work")
+	timeLogCmd.Flags().BoolVar(&timeLogAllowOverlap, "allow-overlap", false, "Log even if the entry overlaps existing time")
+
+	timeCmd.PersistentFlags().StringVarP(&timerOutput, "output", "o", "text", "Output format (text, json; report also csv, markdown)")
+	timeCmd.AddCommand(timeStartCmd, timeStopCmd, timePauseCmd, timeResumeCmd, timeEditCmd, timeDiscardCmd, timeActiveCmd, timeLogCmd)
+}
+
+// timerInfo mirrors the timer fields the daemon returns.
+type timerInfo struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id"`
+	TaskID      string    `json:"task_id,omitempty"`
+	Source      string    `json:"source,omitempty"`
+	Description string    `json:"description,omitempty"`
+	StartTime   time.Time `json:"start_time"`
+	Duration    float64   `json:"duration"`
+	Paused      bool      `json:"paused"`
+	Segments    int       `json:"segments,omitempty"`
+}
+
+func (t timerInfo) String() string {
+	target := "project " + t.ProjectID
+	if t.TaskID != "" {
+		target = "task " + t.TaskID
+	}
+
+	state := "running"
+	if t.Paused {
+		state = "paused"
+	}
+
+	line := fmt.Sprintf("%s  %-8s %s  %s  since %s", t.ID, state,
+		(time.Duration(t.Duration) * time.Second).Round(time.Second), target,
+		t.StartTime.Local().Format("15:04"))
+	if t.Segments > 1 {
+		line += fmt.Sprintf("  (%d segments)", t.Segments)
+	}
+	if t.Description != "" {
+		line += "  " + t.Description
+	}
+	return line
+}
+
+func timerRef() daemon.TimerPayload {
+	return daemon.TimerPayload{TimerID: timerID, ProjectID: timerProjectID, TaskID: timerTaskID}
+}
+
+func runTimerCommand(send func(context.Context, *daemon.Client) (*daemon.Response, error)) error {
+	format, err := output.ParseFormat(timerOutput)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := daemon.NewClient(cfg)
+	if err := client.Connect(); err != nil {
+		return err
+	}
+
+	resp, err := send(context.Background(), client)
+	if err != nil {
+		return err
+	}
+
+	formatter := output.NewFormatter(format, os.Stdout)
+	if format == output.FormatJSON {
+		return formatter.Print(resp.Data)
+	}
+
+	data, err := json.Marshal(resp.Data)
+	if err != nil {
+		return err
+	}
+
+	var timers []timerInfo
+	if err := json.Unmarshal(data, &timers); err != nil {
+		var timer timerInfo
+		if err := json.Unmarshal(data, &timer); err != nil {
+			return fmt.Errorf("unexpected daemon response: %w", err)
+		}
+		timers = []timerInfo{timer}
+	}
+
+	if len(timers) == 0 {
+		return formatter.Print("No running timers.")
+	}
+	for _, t := range timers {
+		if err := formatter.Print(t); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runTimeLog(taskRef, durationArg string) error {
+	format, err := output.ParseFormat(timerOutput)
+	if err != nil {
+		return err
+	}
+
+	duration, err := timeparse.ParseDuration(durationArg)
+	if err != nil {
+		return err
+	}
+	if duration < time.Minute {
+		return fmt.Errorf("duration %s is under a minute; the backend records whole minutes", duration)
+	}
+
+	now := time.Now()
+	start := now.Add(-duration)
+	if timeLogAt != "" {
+		if start, err = timeparse.ParseTime(timeLogAt, now); err != nil {
+			return err
+		}
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := daemon.NewClient(cfg)
+	if err := client.Connect(); err != nil {
+		return err
+	}
+
+	log, err := client.LogTime(context.Background(), daemon.LogTimePayload{
+		ProjectID:       timerProjectID,
+		TaskID:          taskRef,
+		Start:           start,
+		DurationSeconds: int64(duration / time.Second),
+		Description:     timerDescription,
+		AllowOverlap:    timeLogAllowOverlap,
+	})
+	if err != nil {
+		return err
+	}
+
+	formatter := output.NewFormatter(format, os.Stdout)
+	if format == output.FormatJSON {
+		return formatter.Print(log)
+	}
+
+	target := "project " + timerProjectID
+	if taskRef != "" {
+		target = taskRef
+	}
+	end := start.Add(duration)
+	return formatter.Print(fmt.Sprintf("Logged %s on %s, %s to %s.",
+		duration, target, start.Format("Mon 2006-01-02 15:04"), end.Format("15:04")))
+}
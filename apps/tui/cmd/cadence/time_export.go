@@ -0,0 +1,611 @@
+package main
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/daemon"
+	"cadence/internal/infrastructure/config"
+)
+
+const (
+	exportICal     = "ical"
+	exportToggl    = "toggl"
+	exportClockify = "clockify"
+	exportJSON     = "json"
+
+	// timesheetVersion is bumped when the JSON export changes incompatibly.
+	timesheetVersion = 1
+
+	icalLineLimit = 75
+)
+
+var (
+	exportFormat   string
+	exportFile     string
+	exportMergeGap int
+	exportRoundTo  int
+	exportRounding string
+	exportMinimum  int
+	exportBillable bool
+
+	importAllowOverlap bool
+)
+
+var timeExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export time logs as iCalendar, Toggl/Clockify CSV or JSON",
+	Long: `Export time logs for a date range.
+
+Consecutive logs of the same task and description are merged first, then each
+entry is rounded by the rules in time_tracking.export of config.yml, which
+the flags below override.
+
+Formats:
+  ical      iCalendar file with one VEVENT per entry
+  toggl     CSV in the column layout of Toggl Track's import
+  clockify  CSV in the column layout of Clockify's import
+  json      timesheet that ` + "`cadence time import`" + ` reads back
+
+  cadence time export --range last-month --format toggl --file march.csv
+  cadence time export --from monday --format ical --round 15 --rounding up`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTimeExport(cmd)
+	},
+}
+
+var timeImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a JSON timesheet written by time export",
+	Long: `Import a JSON timesheet written by ` + "`cadence time export --format json`" + `.
+
+Every entry is logged as manual time. Entries that overlap time already
+logged on their task are skipped, so importing the same file twice is safe;
+--allow-overlap logs them anyway.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTimeImport(args[0])
+	},
+}
+
+func init() {
+	timeExportCmd.Flags().StringVar(&exportFormat, "format", exportJSON, "Export format: ical, toggl, clockify, json")
+	timeExportCmd.Flags().StringVarP(&exportFile, "file", "f", "", "Write to this file instead of stdout")
+	timeExportCmd.Flags().StringVar(&reportRange, "range", "week", "Date range: today, yesterday, week, last-week, month, last-month")
+	timeExportCmd.Flags().StringVar(&reportFrom, "from", "", "Start of the range (overrides --range)")
+	timeExportCmd.Flags().StringVar(&reportTo, "to", "", "End of the range (default: now)")
+	timeExportCmd.Flags().StringVar(&reportProject, "project-id", "", "Only export this project")
+	timeExportCmd.Flags().IntVar(&exportMergeGap, "merge-gap", 0, "Merge logs at most this many minutes apart (-1 disables merging)")
+	timeExportCmd.Flags().IntVar(&exportRoundTo, "round", 0, "Round entries to a multiple of this many minutes")
+	timeExportCmd.Flags().StringVar(&exportRounding, "rounding", "", "Rounding direction: nearest, up, down")
+	timeExportCmd.Flags().IntVar(&exportMinimum, "minimum", 0, "Raise shorter entries to this many minutes")
+	timeExportCmd.Flags().BoolVar(&exportBillable, "billable", false, "Mark entries as billable (Toggl, Clockify)")
+	timeCmd.AddCommand(timeExportCmd)
+
+	timeImportCmd.Flags().BoolVar(&importAllowOverlap, "allow-overlap", false, "Import entries that overlap logged time")
+	timeCmd.AddCommand(timeImportCmd)
+}
+
+// timesheet is the JSON export, and the input of time import.
+type timesheet struct {
+	Version    int              `json:"version"`
+	ExportedAt time.Time        `json:"exported_at"`
+	From       time.Time        `json:"from"`
+	To         time.Time        `json:"to"`
+	TimeLogs   []dto.TimeLogDto `json:"time_logs"`
+}
+
+// exportEntry is a time log with its span parsed, merged and rounded.
+// worked excludes pauses and breaks, and gaps between merged logs; lastEnd
+// is when the last merged log ended, which only decides what merges.
+type exportEntry struct {
+	log     dto.TimeLogDto
+	start   time.Time
+	worked  time.Duration
+	lastEnd time.Time
+}
+
+func (e *exportEntry) duration() time.Duration {
+	return e.worked
+}
+
+// end is when the entry ends billed as one block from its start.
+func (e *exportEntry) end() time.Time {
+	return e.start.Add(e.worked)
+}
+
+// timeLog returns the entry as a TimeLogDto with its end time and duration
+// updated.
+func (e *exportEntry) timeLog() dto.TimeLogDto {
+	log := e.log
+	end := e.end().UTC().Format(time.RFC3339)
+	minutes := int(e.duration() / time.Minute)
+	log.StartTime = e.start.UTC().Format(time.RFC3339)
+	log.EndTime = &end
+	log.Duration = &minutes
+	return log
+}
+
+func runTimeExport(cmd *cobra.Command) error {
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	rules := exportRules(cmd, cfg.TimeTracking.Export)
+	if err := validateExportRules(rules); err != nil {
+		return err
+	}
+
+	switch exportFormat {
+	case exportICal, exportToggl, exportClockify, exportJSON:
+	default:
+		return fmt.Errorf("invalid format %q: must be ical, toggl, clockify or json", exportFormat)
+	}
+
+	now := time.Now()
+	from, to, err := reportPeriod(now)
+	if err != nil {
+		return err
+	}
+
+	client := daemon.NewClient(cfg)
+	if err := client.Connect(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	records, err := fetchTimeLogs(ctx, client, from, to)
+	if err != nil {
+		return err
+	}
+
+	var logs []dto.TimeLogDto
+	for _, record := range records {
+		if reportProject != "" && deref(record.ProjectID) != reportProject {
+			continue
+		}
+		logs = append(logs, timeLogFromRecord(record))
+	}
+
+	entries := mergeTimeLogs(logs, rules.MergeGap)
+	for i := range entries {
+		roundEntry(&entries[i], rules)
+	}
+
+	var w io.Writer = os.Stdout
+	if exportFile != "" {
+		f, err := os.Create(exportFile)
+		if err != nil {
+			return fmt.Errorf("failed to create export file: %w", err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	switch exportFormat {
+	case exportJSON:
+		sheet := timesheet{Version: timesheetVersion, ExportedAt: now, From: from, To: to, TimeLogs: []dto.TimeLogDto{}}
+		for i := range entries {
+			sheet.TimeLogs = append(sheet.TimeLogs, entries[i].timeLog())
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(sheet)
+	default:
+		var names *nameLookup
+		if names, err = newNameLookup(ctx, client); err != nil {
+			return err
+		}
+		switch exportFormat {
+		case exportICal:
+			err = writeICal(w, entries, names, now)
+		case exportToggl:
+			err = writeTogglCSV(w, entries, names, accountEmail(ctx, client), rules.Billable)
+		case exportClockify:
+			err = writeClockifyCSV(w, entries, names, accountEmail(ctx, client), rules.Billable)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	if exportFile != "" {
+		fmt.Printf("Exported %d entries to %s.\n", len(entries), exportFile)
+	}
+	return nil
+}
+
+// exportRules starts from the config and applies the flags that were set.
+func exportRules(cmd *cobra.Command, rules config.TimeExportConfig) config.TimeExportConfig {
+	flags := cmd.Flags()
+	if flags.Changed("merge-gap") {
+		rules.MergeGap = exportMergeGap
+	}
+	if flags.Changed("round") {
+		rules.RoundTo = exportRoundTo
+	}
+	if flags.Changed("rounding") {
+		rules.Rounding = exportRounding
+	}
+	if flags.Changed("minimum") {
+		rules.Minimum = exportMinimum
+	}
+	if flags.Changed("billable") {
+		rules.Billable = exportBillable
+	}
+	if rules.Rounding == "" {
+		rules.Rounding = "nearest"
+	}
+	return rules
+}
+
+func validateExportRules(rules config.TimeExportConfig) error {
+	switch rules.Rounding {
+	case "nearest", "up", "down":
+	default:
+		return fmt.Errorf("invalid rounding %q: must be nearest, up or down", rules.Rounding)
+	}
+	if rules.RoundTo < 0 || rules.Minimum < 0 {
+		return fmt.Errorf("rounding and minimum must not be negative")
+	}
+	return nil
+}
+
+// timeLogFromRecord maps a daily record to a TimeLogDto, taking the end
+// time and description from the metadata the daemon stores them in.
+func timeLogFromRecord(record dto.TimeLogRecordDto) dto.TimeLogDto {
+	minutes := record.DurationMinutes
+	log := dto.TimeLogDto{
+		ID:        record.ID,
+		TaskID:    record.TaskID,
+		ProjectID: record.ProjectID,
+		StartTime: record.Date,
+		Duration:  &minutes,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+	}
+	if end, ok := record.Metadata["end_time"].(string); ok && end != "" {
+		log.EndTime = &end
+	}
+	if desc, ok := record.Metadata["description"].(string); ok && desc != "" {
+		log.Description = &desc
+	}
+	return log
+}
+
+// logSpan returns when a log started and ended and how long was worked in
+// between. The end time includes pauses and breaks, so the worked time is
+// the logged duration, falling back to the span without one.
+func logSpan(log dto.TimeLogDto) (time.Time, time.Time, time.Duration, error) {
+	start, err := time.Parse(time.RFC3339, log.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("time log %s has an invalid start time: %w", log.ID, err)
+	}
+	var end time.Time
+	if log.EndTime != nil {
+		if t, err := time.Parse(time.RFC3339, *log.EndTime); err == nil && !t.Before(start) {
+			end = t
+		}
+	}
+
+	switch {
+	case log.Duration != nil:
+		worked := time.Duration(*log.Duration) * time.Minute
+		if end.IsZero() || end.Before(start.Add(worked)) {
+			end = start.Add(worked)
+		}
+		return start, end, worked, nil
+	case !end.IsZero():
+		return start, end, end.Sub(start), nil
+	default:
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("time log %s has no duration", log.ID)
+	}
+}
+
+// mergeTimeLogs joins logs of the same project, task and description that
+// follow each other within gapMinutes. A negative gap disables merging.
+func mergeTimeLogs(logs []dto.TimeLogDto, gapMinutes int) []exportEntry {
+	entries := make([]exportEntry, 0, len(logs))
+	for _, log := range logs {
+		start, end, worked, err := logSpan(log)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping: %v\n", err)
+			continue
+		}
+		entries = append(entries, exportEntry{log: log, start: start, worked: worked, lastEnd: end})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].start.Before(entries[j].start) })
+
+	if gapMinutes < 0 {
+		return entries
+	}
+
+	gap := time.Duration(gapMinutes) * time.Minute
+	last := make(map[string]int)
+	merged := entries[:0]
+	for _, e := range entries {
+		key := deref(e.log.ProjectID) + "\x00" + deref(e.log.TaskID) + "\x00" + deref(e.log.Description)
+		if i, ok := last[key]; ok && i == len(merged)-1 && e.start.Sub(merged[i].lastEnd) <= gap {
+			// Only the time worked adds up, not the gap between the logs
+			merged[i].worked += e.worked
+			if e.lastEnd.After(merged[i].lastEnd) {
+				merged[i].lastEnd = e.lastEnd
+			}
+			continue
+		}
+		merged = append(merged, e)
+		last[key] = len(merged) - 1
+	}
+	return merged
+}
+
+func roundEntry(e *exportEntry, rules config.TimeExportConfig) {
+	minutes := e.duration().Minutes()
+
+	if rules.RoundTo > 0 {
+		step := float64(rules.RoundTo)
+		switch rules.Rounding {
+		case "up":
+			minutes = math.Ceil(minutes/step) * step
+		case "down":
+			minutes = math.Floor(minutes/step) * step
+		default:
+			minutes = math.Round(minutes/step) * step
+		}
+	}
+	if minutes < float64(rules.Minimum) {
+		minutes = float64(rules.Minimum)
+	}
+
+	e.worked = time.Duration(minutes * float64(time.Minute))
+}
+
+// entryTitle names an entry after its task, or its project without one.
+func entryTitle(e *exportEntry, names *nameLookup) string {
+	if task := names.task(deref(e.log.TaskID)); task != "" {
+		return task
+	}
+	if project := names.project(deref(e.log.ProjectID)); project != "" {
+		return project
+	}
+	return deref(e.log.ProjectID)
+}
+
+func accountEmail(ctx context.Context, client *daemon.Client) string {
+	whoami, err := client.WhoAmI(ctx)
+	if err != nil || whoami.User == nil {
+		return ""
+	}
+	return whoami.User.Email
+}
+
+func writeICal(w io.Writer, entries []exportEntry, names *nameLookup, now time.Time) error {
+	const stamp = "20060102T150405Z"
+
+	lines := []string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Cadence//Time Export//EN",
+		"CALSCALE:GREGORIAN",
+	}
+	for i := range entries {
+		e := &entries[i]
+		project := names.project(deref(e.log.ProjectID))
+
+		description := deref(e.log.Description)
+		if project != "" {
+			if description != "" {
+				description += "\n"
+			}
+			description += "Project: " + project
+		}
+
+		lines = append(lines,
+			"BEGIN:VEVENT",
+			"UID:"+e.log.ID+"@cadence",
+			"DTSTAMP:"+now.UTC().Format(stamp),
+			"DTSTART:"+e.start.UTC().Format(stamp),
+			"DTEND:"+e.end().UTC().Format(stamp),
+			"SUMMARY:"+icalText(entryTitle(e, names)),
+		)
+		if description != "" {
+			lines = append(lines, "DESCRIPTION:"+icalText(description))
+		}
+		if project != "" {
+			lines = append(lines, "CATEGORIES:"+icalText(project))
+		}
+		lines = append(lines, "END:VEVENT")
+	}
+	lines = append(lines, "END:VCALENDAR")
+
+	for _, line := range lines {
+		if _, err := io.WriteString(w, foldICalLine(line)+"\r\n"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// icalText escapes a TEXT value per RFC 5545.
+func icalText(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// foldICalLine splits lines longer than 75 octets, continuing them with a
+// leading space, without cutting a UTF-8 sequence.
+func foldICalLine(line string) string {
+	if len(line) <= icalLineLimit {
+		return line
+	}
+
+	var b strings.Builder
+	width := 0
+	for _, r := range line {
+		size := len(string(r))
+		if width+size > icalLineLimit {
+			b.WriteString("\r\n ")
+			width = 1
+		}
+		b.WriteRune(r)
+		width += size
+	}
+	return b.String()
+}
+
+func writeTogglCSV(w io.Writer, entries []exportEntry, names *nameLookup, email string, billable bool) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		"Email", "Project", "Client", "Task", "Description", "Billable",
+		"Start date", "Start time", "Duration", "Tags",
+	}); err != nil {
+		return err
+	}
+
+	for i := range entries {
+		e := &entries[i]
+		start := e.start.Local()
+		if err := cw.Write([]string{
+			email,
+			names.project(deref(e.log.ProjectID)),
+			"",
+			names.task(deref(e.log.TaskID)),
+			entryDescription(e, names),
+			yesNo(billable),
+			start.Format("2006-01-02"),
+			start.Format("15:04:05"),
+			clockDuration(e.duration()),
+			"",
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+func writeClockifyCSV(w io.Writer, entries []exportEntry, names *nameLookup, email string, billable bool) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{
+		"Project", "Client", "Description", "Task", "Email", "Tags", "Billable",
+		"Start Date", "Start Time", "End Date", "End Time", "Duration (h)", "Duration (decimal)",
+	}); err != nil {
+		return err
+	}
+
+	for i := range entries {
+		e := &entries[i]
+		start, end := e.start.Local(), e.end().Local()
+		if err := cw.Write([]string{
+			names.project(deref(e.log.ProjectID)),
+			"",
+			entryDescription(e, names),
+			names.task(deref(e.log.TaskID)),
+			email,
+			"",
+			yesNo(billable),
+			start.Format("01/02/2006"),
+			start.Format("15:04:05"),
+			end.Format("01/02/2006"),
+			end.Format("15:04:05"),
+			clockDuration(e.duration()),
+			fmt.Sprintf("%.2f", e.duration().Hours()),
+		}); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// entryDescription is the log's description, or its title when it has
+// none, since both tools show the description first.
+func entryDescription(e *exportEntry, names *nameLookup) string {
+	if desc := deref(e.log.Description); desc != "" {
+		return desc
+	}
+	return entryTitle(e, names)
+}
+
+func clockDuration(d time.Duration) string {
+	secs := int(d.Round(time.Second) / time.Second)
+	return fmt.Sprintf("%02d:%02d:%02d", secs/3600, secs/60%60, secs%60)
+}
+
+func yesNo(b bool) string {
+	if b {
+		return "Yes"
+	}
+	return "No"
+}
+
+func runTimeImport(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read timesheet: %w", err)
+	}
+
+	var sheet timesheet
+	if err := json.Unmarshal(data, &sheet); err != nil {
+		return fmt.Errorf("failed to parse timesheet: %w", err)
+	}
+	if sheet.Version != timesheetVersion {
+		return fmt.Errorf("unsupported timesheet version %d (expected %d)", sheet.Version, timesheetVersion)
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := daemon.NewClient(cfg)
+	if err := client.Connect(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	imported, skipped := 0, 0
+	for _, log := range sheet.TimeLogs {
+		start, _, worked, err := logSpan(log)
+		if err == nil && deref(log.ProjectID) == "" && deref(log.TaskID) == "" {
+			err = fmt.Errorf("time log %s has no project or task", log.ID)
+		}
+		if err == nil {
+			_, err = client.LogTime(ctx, daemon.LogTimePayload{
+				ProjectID:       deref(log.ProjectID),
+				TaskID:          deref(log.TaskID),
+				Start:           start,
+				DurationSeconds: int64(worked / time.Second),
+				Description:     deref(log.Description),
+				AllowOverlap:    importAllowOverlap,
+			})
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipped %s (%s): %v\n", log.ID, start.Local().Format("2006-01-02 15:04"), err)
+			skipped++
+			continue
+		}
+		imported++
+	}
+
+	fmt.Printf("Imported %d of %d entries", imported, len(sheet.TimeLogs))
+	if skipped > 0 {
+		fmt.Printf(", skipped %d", skipped)
+	}
+	fmt.Println(".")
+	return nil
+}
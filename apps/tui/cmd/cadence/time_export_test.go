@@ -0,0 +1,167 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/config"
+)
+
+// testLog builds a time log on 2026-10-19 from "15:04" clock times. An
+// empty end leaves it open and a negative duration leaves it unset.
+func testLog(id, description, start, end string, duration int) dto.TimeLogDto {
+	stamp := func(clock string) string {
+		return "2026-10-19T" + clock + ":00Z"
+	}
+	log := dto.TimeLogDto{ID: id, StartTime: stamp(start), Description: &description}
+	if end != "" {
+		e := stamp(end)
+		log.EndTime = &e
+	}
+	if duration >= 0 {
+		log.Duration = &duration
+	}
+	return log
+}
+
+func TestMergeTimeLogs(t *testing.T) {
+	type want struct {
+		start  string
+		worked time.Duration
+	}
+
+	tests := []struct {
+		name string
+		logs []dto.TimeLogDto
+		gap  int
+		want []want
+	}{
+		{
+			name: "within the gap merge without billing it",
+			logs: []dto.TimeLogDto{
+				testLog("1", "api", "09:00", "10:00", 60),
+				testLog("2", "api", "10:05", "10:35", 30),
+			},
+			gap:  10,
+			want: []want{{"09:00", 90 * time.Minute}},
+		},
+		{
+			name: "beyond the gap stay apart",
+			logs: []dto.TimeLogDto{
+				testLog("1", "api", "09:00", "10:00", 60),
+				testLog("2", "api", "10:05", "10:35", 30),
+			},
+			gap:  1,
+			want: []want{{"09:00", 60 * time.Minute}, {"10:05", 30 * time.Minute}},
+		},
+		{
+			name: "a negative gap never merges",
+			logs: []dto.TimeLogDto{
+				testLog("1", "api", "09:00", "10:00", 60),
+				testLog("2", "api", "10:00", "10:30", 30),
+			},
+			gap:  -1,
+			want: []want{{"09:00", 60 * time.Minute}, {"10:00", 30 * time.Minute}},
+		},
+		{
+			name: "different descriptions stay apart",
+			logs: []dto.TimeLogDto{
+				testLog("1", "api", "09:00", "10:00", 60),
+				testLog("2", "docs", "10:00", "10:30", 30),
+			},
+			gap:  5,
+			want: []want{{"09:00", 60 * time.Minute}, {"10:00", 30 * time.Minute}},
+		},
+		{
+			name: "only the last entry takes a merge",
+			logs: []dto.TimeLogDto{
+				testLog("1", "api", "09:00", "10:00", 60),
+				testLog("2", "docs", "10:00", "10:30", 30),
+				testLog("3", "api", "10:30", "11:00", 30),
+			},
+			gap:  5,
+			want: []want{{"09:00", 60 * time.Minute}, {"10:00", 30 * time.Minute}, {"10:30", 30 * time.Minute}},
+		},
+		{
+			name: "pauses are not billed and the gap counts from the end time",
+			logs: []dto.TimeLogDto{
+				testLog("1", "api", "09:00", "11:00", 45),
+				testLog("2", "api", "11:02", "11:32", 30),
+			},
+			gap:  5,
+			want: []want{{"09:00", 75 * time.Minute}},
+		},
+		{
+			name: "logs are sorted by start",
+			logs: []dto.TimeLogDto{
+				testLog("2", "docs", "13:00", "13:20", 20),
+				testLog("1", "api", "09:00", "", 15),
+			},
+			gap:  0,
+			want: []want{{"09:00", 15 * time.Minute}, {"13:00", 20 * time.Minute}},
+		},
+		{
+			name: "without a duration the span is worked",
+			logs: []dto.TimeLogDto{
+				testLog("1", "api", "09:00", "09:40", -1),
+			},
+			gap:  0,
+			want: []want{{"09:00", 40 * time.Minute}},
+		},
+		{
+			name: "logs without a duration or end are skipped",
+			logs: []dto.TimeLogDto{
+				testLog("1", "api", "09:00", "", -1),
+				testLog("2", "api", "10:00", "10:10", 10),
+			},
+			gap:  0,
+			want: []want{{"10:00", 10 * time.Minute}},
+		},
+	}
+
+	for _, tt := range tests {
+		got := mergeTimeLogs(tt.logs, tt.gap)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %d entries, want %d", tt.name, len(got), len(tt.want))
+			continue
+		}
+		for i, w := range tt.want {
+			start, _ := time.Parse(time.RFC3339, "2026-10-19T"+w.start+":00Z")
+			if !got[i].start.Equal(start) || got[i].duration() != w.worked {
+				t.Errorf("%s: entry %d = %s for %v, want %s for %v", tt.name, i,
+					got[i].start.Format("15:04"), got[i].duration(), w.start, w.worked)
+			}
+			if end := got[i].end(); !end.Equal(start.Add(w.worked)) {
+				t.Errorf("%s: entry %d ends %s, want the start plus %v", tt.name, i, end.Format("15:04"), w.worked)
+			}
+		}
+	}
+}
+
+func TestRoundEntry(t *testing.T) {
+	tests := []struct {
+		worked time.Duration
+		rules  config.TimeExportConfig
+		want   time.Duration
+	}{
+		{52 * time.Minute, config.TimeExportConfig{}, 52 * time.Minute},
+		{52 * time.Minute, config.TimeExportConfig{RoundTo: 15}, 45 * time.Minute},
+		{53 * time.Minute, config.TimeExportConfig{RoundTo: 15}, 60 * time.Minute},
+		{52 * time.Minute, config.TimeExportConfig{RoundTo: 15, Rounding: "nearest"}, 45 * time.Minute},
+		{46 * time.Minute, config.TimeExportConfig{RoundTo: 15, Rounding: "up"}, 60 * time.Minute},
+		{59 * time.Minute, config.TimeExportConfig{RoundTo: 15, Rounding: "down"}, 45 * time.Minute},
+		{7 * time.Minute, config.TimeExportConfig{RoundTo: 15}, 0},
+		{7 * time.Minute, config.TimeExportConfig{RoundTo: 15, Minimum: 15}, 15 * time.Minute},
+		{52 * time.Minute, config.TimeExportConfig{Minimum: 30}, 52 * time.Minute},
+		{90 * time.Second, config.TimeExportConfig{RoundTo: 1}, 2 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		e := exportEntry{start: time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC), worked: tt.worked}
+		roundEntry(&e, tt.rules)
+		if e.duration() != tt.want {
+			t.Errorf("roundEntry(%v, %+v) = %v, want %v", tt.worked, tt.rules, e.duration(), tt.want)
+		}
+	}
+}
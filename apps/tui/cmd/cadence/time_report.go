@@ -0,0 +1,483 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/daemon"
+	"cadence/pkg/output"
+	"cadence/pkg/timeparse"
+)
+
+const (
+	groupProject = "project"
+	groupTask    = "task"
+	groupDay     = "day"
+	groupSource  = "source"
+
+	// maxReportDays bounds ranges read day by day from the backend.
+	maxReportDays = 366
+	// reportFetchWorkers is how many days are read from the backend at once.
+	reportFetchWorkers = 8
+)
+
+var (
+	reportRange   string
+	reportFrom    string
+	reportTo      string
+	reportGroups  []string
+	reportProject string
+)
+
+var timeReportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Summarize logged time",
+	Long: `Summarize logged time over a date range, grouped by project, task,
+day or source.
+
+  cadence time report                         # this week, per project
+  cadence time report --range last-week --group project,day -o markdown
+  cadence time report --from 2026-02-01 --to 2026-02-28 --group task -o csv
+
+--range is one of today, yesterday, week, last-week, month or last-month;
+weeks start on Monday. --from and --to take the same dates as
+` + "`cadence time log --at`" + `; a --to without a time of day includes that day.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTimeReport()
+	},
+}
+
+func init() {
+	timeReportCmd.Flags().StringVar(&reportRange, "range", "week", "Date range: today, yesterday, week, last-week, month, last-month")
+	timeReportCmd.Flags().StringVar(&reportFrom, "from", "", "Start of the range (overrides --range)")
+	timeReportCmd.Flags().StringVar(&reportTo, "to", "", "End of the range (default: now)")
+	timeReportCmd.Flags().StringSliceVar(&reportGroups, "group", []string{groupProject}, "Group by project, task, day and/or source")
+	timeReportCmd.Flags().StringVar(&reportProject, "project-id", "", "Only report this project")
+	timeCmd.AddCommand(timeReportCmd)
+}
+
+// timeReport is the JSON form of a report; the other formats render it as
+// a table.
+type timeReport struct {
+	From         time.Time   `json:"from"`
+	To           time.Time   `json:"to"`
+	GroupBy      []string    `json:"group_by"`
+	Rows         []reportRow `json:"rows"`
+	TotalMinutes int         `json:"total_minutes"`
+}
+
+type reportRow struct {
+	ProjectID string `json:"project_id,omitempty"`
+	Project   string `json:"project,omitempty"`
+	TaskID    string `json:"task_id,omitempty"`
+	Task      string `json:"task,omitempty"`
+	Day       string `json:"day,omitempty"`
+	Source    string `json:"source,omitempty"`
+	Minutes   int    `json:"minutes"`
+}
+
+func (r *timeReport) Table() output.Table {
+	var t output.Table
+	for _, g := range r.GroupBy {
+		t.Headers = append(t.Headers, strings.ToUpper(g[:1])+g[1:])
+	}
+	t.Headers = append(t.Headers, "Duration", "Hours")
+
+	for _, row := range r.Rows {
+		var cells []string
+		for _, g := range r.GroupBy {
+			cells = append(cells, row.label(g))
+		}
+		cells = append(cells, formatMinutes(row.Minutes), formatHours(row.Minutes))
+		t.Rows = append(t.Rows, cells)
+	}
+	return t
+}
+
+func (r reportRow) label(group string) string {
+	switch group {
+	case groupProject:
+		if r.Project != "" {
+			return r.Project
+		}
+		return r.ProjectID
+	case groupTask:
+		if r.Task != "" {
+			return r.Task
+		}
+		if r.TaskID == "" {
+			return "(no task)"
+		}
+		return r.TaskID
+	case groupDay:
+		return r.Day
+	case groupSource:
+		return r.Source
+	}
+	return ""
+}
+
+func runTimeReport() error {
+	format, err := output.ParseFormat(timerOutput)
+	if err != nil {
+		return err
+	}
+
+	groups, err := parseReportGroups(reportGroups)
+	if err != nil {
+		return err
+	}
+
+	from, to, err := reportPeriod(time.Now())
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig()
+	if err != nil {
+		return err
+	}
+
+	client := daemon.NewClient(cfg)
+	if err := client.Connect(); err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	report := &timeReport{From: from, To: to, GroupBy: groups}
+
+	// Per-project totals come straight from the summary endpoint; any other
+	// grouping needs the individual logs.
+	if len(groups) == 1 && groups[0] == groupProject {
+		summary, err := client.GetTimeSummary(ctx, reportProject, from, to.Add(-time.Nanosecond))
+		if err != nil {
+			return err
+		}
+		for _, s := range summary {
+			report.Rows = append(report.Rows, reportRow{ProjectID: s.ProjectID, Minutes: s.TotalMinutes})
+		}
+	} else {
+		logs, err := fetchTimeLogs(ctx, client, from, to)
+		if err != nil {
+			return err
+		}
+		report.Rows = groupTimeLogs(logs, groups, reportProject)
+	}
+
+	if err := nameReportRows(ctx, client, report.Rows); err != nil {
+		return err
+	}
+	sortReportRows(report.Rows, groups)
+	for _, row := range report.Rows {
+		report.TotalMinutes += row.Minutes
+	}
+
+	formatter := output.NewFormatter(format, os.Stdout)
+	if format == output.FormatJSON || format == output.FormatCSV {
+		return formatter.Print(report)
+	}
+
+	period := fmt.Sprintf("%s to %s", from.Format("Mon 2006-01-02"), to.Add(-time.Nanosecond).Format("Mon 2006-01-02"))
+	if len(report.Rows) == 0 {
+		return formatter.Print("No time logged from " + period + ".")
+	}
+
+	title := "Time logged " + period
+	total := "Total: " + formatMinutes(report.TotalMinutes)
+	if format == output.FormatMarkdown {
+		title = "### " + title + "\n"
+		total = "\n**" + total + "**"
+	} else {
+		title += "\n"
+		total = "\n" + total
+	}
+	for _, v := range []interface{}{title, report, total} {
+		if err := formatter.Print(v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseReportGroups(values []string) ([]string, error) {
+	var groups []string
+	seen := make(map[string]bool)
+	for _, v := range values {
+		g := strings.ToLower(strings.TrimSpace(v))
+		switch g {
+		case groupProject, groupTask, groupDay, groupSource:
+		default:
+			return nil, fmt.Errorf("invalid group %q: must be project, task, day or source", v)
+		}
+		if !seen[g] {
+			seen[g] = true
+			groups = append(groups, g)
+		}
+	}
+	if len(groups) == 0 {
+		groups = []string{groupProject}
+	}
+	return groups, nil
+}
+
+// reportPeriod returns the half-open range [from, to) selected by --range,
+// --from and --to.
+func reportPeriod(now time.Time) (time.Time, time.Time, error) {
+	today := timeparse.StartOfDay(now)
+	var from, to time.Time
+
+	switch reportRange {
+	case "today":
+		from, to = today, today.AddDate(0, 0, 1)
+	case "yesterday":
+		from, to = today.AddDate(0, 0, -1), today
+	case "week", "last-week":
+		from = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+		if reportRange == "last-week" {
+			from = from.AddDate(0, 0, -7)
+		}
+		to = from.AddDate(0, 0, 7)
+	case "month", "last-month":
+		from = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, today.Location())
+		if reportRange == "last-month" {
+			from = from.AddDate(0, -1, 0)
+		}
+		to = from.AddDate(0, 1, 0)
+	default:
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid range %q: must be today, yesterday, week, last-week, month or last-month", reportRange)
+	}
+
+	var err error
+	if reportFrom != "" {
+		if from, err = timeparse.ParseTime(reportFrom, now); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		to = now
+	}
+	if reportTo != "" {
+		if to, err = timeparse.ParseTime(reportTo, now); err != nil {
+			return time.Time{}, time.Time{}, err
+		}
+		if to.Equal(timeparse.StartOfDay(to)) {
+			to = to.AddDate(0, 0, 1)
+		}
+	}
+
+	if !to.After(from) {
+		return time.Time{}, time.Time{}, fmt.Errorf("the range ends before it starts")
+	}
+	if span := to.Sub(from); span > maxReportDays*24*time.Hour {
+		days := int((span + 24*time.Hour - 1) / (24 * time.Hour))
+		return time.Time{}, time.Time{}, fmt.Errorf("the range covers %d days; logs are read day by day, so ranges are limited to %d days: split it into shorter ones",
+			days, maxReportDays)
+	}
+	return from, to, nil
+}
+
+// fetchTimeLogs returns the logs that started in [from, to). The backend
+// only lists logs per day, in its own time zone, so one extra day is read on
+// each side and the logs are filtered here. Days are read
+// reportFetchWorkers at a time.
+func fetchTimeLogs(ctx context.Context, client *daemon.Client, from, to time.Time) ([]dto.TimeLogRecordDto, error) {
+	var days []string
+	for day := timeparse.StartOfDay(from).AddDate(0, 0, -1); day.Before(to.AddDate(0, 0, 1)); day = day.AddDate(0, 0, 1) {
+		days = append(days, day.Format("2006-01-02"))
+	}
+
+	daily := make([][]dto.TimeLogRecordDto, len(days))
+	errs := make([]error, len(days))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(reportFetchWorkers, len(days)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				daily[i], errs[i] = client.GetDailyTimeLogs(ctx, days[i])
+			}
+		}()
+	}
+	for i := range days {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	var logs []dto.TimeLogRecordDto
+	starts := make(map[string]time.Time)
+	for i, day := range days {
+		if errs[i] != nil {
+			return nil, fmt.Errorf("failed to get time logs for %s: %w", day, errs[i])
+		}
+		for _, log := range daily[i] {
+			if _, ok := starts[log.ID]; ok {
+				continue
+			}
+			start, err := time.Parse(time.RFC3339, log.Date)
+			if err != nil || start.Before(from) || !start.Before(to) {
+				continue
+			}
+			starts[log.ID] = start
+			logs = append(logs, log)
+		}
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool { return starts[logs[i].ID].Before(starts[logs[j].ID]) })
+	return logs, nil
+}
+
+func groupTimeLogs(logs []dto.TimeLogRecordDto, groups []string, projectID string) []reportRow {
+	rows := make(map[string]*reportRow)
+	var order []string
+
+	for _, log := range logs {
+		if projectID != "" && deref(log.ProjectID) != projectID {
+			continue
+		}
+
+		var row reportRow
+		for _, g := range groups {
+			switch g {
+			case groupProject:
+				row.ProjectID = deref(log.ProjectID)
+			case groupTask:
+				row.TaskID = deref(log.TaskID)
+			case groupDay:
+				if start, err := time.Parse(time.RFC3339, log.Date); err == nil {
+					row.Day = start.Local().Format("2006-01-02")
+				}
+			case groupSource:
+				row.Source = log.Source
+			}
+		}
+
+		key := strings.Join([]string{row.ProjectID, row.TaskID, row.Day, row.Source}, "\x00")
+		if _, ok := rows[key]; !ok {
+			rows[key] = &row
+			order = append(order, key)
+		}
+		rows[key].Minutes += log.DurationMinutes
+	}
+
+	result := make([]reportRow, 0, len(order))
+	for _, key := range order {
+		result = append(result, *rows[key])
+	}
+	return result
+}
+
+// nameReportRows fills in project names and task titles.
+func nameReportRows(ctx context.Context, client *daemon.Client, rows []reportRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	names, err := newNameLookup(ctx, client)
+	if err != nil {
+		return err
+	}
+	for i := range rows {
+		rows[i].Project = names.project(rows[i].ProjectID)
+		rows[i].Task = names.task(rows[i].TaskID)
+	}
+	return nil
+}
+
+// nameLookup resolves project and task IDs to display names. Names are for
+// display only, so tasks that cannot be fetched get an empty name.
+type nameLookup struct {
+	ctx      context.Context
+	client   *daemon.Client
+	projects map[string]string
+	tasks    map[string]string
+}
+
+func newNameLookup(ctx context.Context, client *daemon.Client) (*nameLookup, error) {
+	projects, err := client.ListProjectsTyped(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	n := &nameLookup{
+		ctx:      ctx,
+		client:   client,
+		projects: make(map[string]string),
+		tasks:    make(map[string]string),
+	}
+	for _, p := range projects.Items {
+		n.projects[p.ID] = p.Name
+	}
+	return n, nil
+}
+
+func (n *nameLookup) project(id string) string {
+	return n.projects[id]
+}
+
+// task returns "CAD-12 Fix login" for a task with a slug, else its title.
+func (n *nameLookup) task(id string) string {
+	if id == "" {
+		return ""
+	}
+	if name, ok := n.tasks[id]; ok {
+		return name
+	}
+
+	var name string
+	if task, err := n.client.GetTask(n.ctx, id); err == nil {
+		name = task.Title
+		if task.Slug != "" {
+			name = taskSlugPrefix(task.Slug) + " " + task.Title
+		}
+	}
+	n.tasks[id] = name
+	return name
+}
+
+// taskSlugPrefix shortens "CAD-12-fix-login" to "CAD-12".
+func taskSlugPrefix(slug string) string {
+	parts := strings.SplitN(slug, "-", 3)
+	if len(parts) >= 2 {
+		if _, err := strconv.Atoi(parts[1]); err == nil {
+			return parts[0] + "-" + parts[1]
+		}
+	}
+	return slug
+}
+
+func sortReportRows(rows []reportRow, groups []string) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		for _, g := range groups {
+			a, b := rows[i].label(g), rows[j].label(g)
+			if a != b {
+				return a < b
+			}
+		}
+		return rows[i].Minutes > rows[j].Minutes
+	})
+}
+
+func formatMinutes(minutes int) string {
+	return fmt.Sprintf("%dh %02dm", minutes/60, minutes%60)
+}
+
+func formatHours(minutes int) string {
+	return strconv.FormatFloat(float64(minutes)/60, 'f', 2, 64)
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestReportPeriod(t *testing.T) {
+	now := time.Date(2026, 3, 4, 10, 30, 0, 0, time.Local)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2026, month, d, 0, 0, 0, 0, time.Local)
+	}
+
+	tests := []struct {
+		rangeName string
+		from, to  string
+		wantFrom  time.Time
+		wantTo    time.Time
+		wantErr   string
+	}{
+		{rangeName: "week", wantFrom: day(3, 2), wantTo: day(3, 9)},
+		{rangeName: "last-week", wantFrom: day(2, 23), wantTo: day(3, 2)},
+		{rangeName: "last-month", wantFrom: day(2, 1), wantTo: day(3, 1)},
+		{rangeName: "week", from: "2026-02-01", to: "2026-02-28", wantFrom: day(2, 1), wantTo: day(3, 1)},
+		{rangeName: "week", from: "2026-03-01", wantFrom: day(3, 1), wantTo: now},
+		{rangeName: "week", from: "2025-03-04", to: "2026-03-03", wantFrom: time.Date(2025, 3, 4, 0, 0, 0, 0, time.Local), wantTo: day(3, 4)},
+		{rangeName: "week", from: "2025-01-01", to: "2026-03-01", wantErr: "the range covers 425 days"},
+		{rangeName: "week", from: "2026-03-02", to: "2026-03-01 12:00", wantErr: "ends before it starts"},
+		{rangeName: "fortnight", wantErr: "invalid range"},
+	}
+
+	saved := []string{reportRange, reportFrom, reportTo}
+	t.Cleanup(func() { reportRange, reportFrom, reportTo = saved[0], saved[1], saved[2] })
+
+	for _, tt := range tests {
+		reportRange, reportFrom, reportTo = tt.rangeName, tt.from, tt.to
+		from, to, err := reportPeriod(now)
+		name := tt.rangeName + " " + tt.from + ".." + tt.to
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("%s: error = %v, want %q", name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", name, err)
+			continue
+		}
+		if !from.Equal(tt.wantFrom) || !to.Equal(tt.wantTo) {
+			t.Errorf("%s: period = %s to %s, want %s to %s", name, from, to, tt.wantFrom, tt.wantTo)
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"cadence/internal/buildinfo"
+	"cadence/internal/daemon"
+	"cadence/internal/domain/service"
+	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/external"
+	"cadence/internal/infrastructure/fakebackend"
+	"cadence/internal/infrastructure/httpclient"
+)
+
+func main() {
+	useFakeBackend := flag.Bool("fake-backend", fakebackend.EnabledFromEnv(),
+		"serve the backend API from in-memory state instead of the network (also "+fakebackend.EnvVar+")")
+	recordPath := flag.String("record", "", "record backend HTTP traffic to a cassette file")
+	replayPath := flag.String("replay", "", "answer backend requests from a cassette file instead of the network")
+	profile := flag.String("profile", "", "config profile to use (also "+config.ProfileEnvVar+")")
+	flag.Parse()
+
+	if (*useFakeBackend || *replayPath != "") && buildinfo.BackendURL == "" {
+		buildinfo.BackendURL = fakebackend.BaseURL
+	}
+
+	loader, err := config.NewLoader()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create config loader: %v\n", err)
+		os.Exit(1)
+	}
+	loader.SetProfile(*profile)
+
+	cfg, err := loader.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to load config: %v\n", err)
+		os.Exit(1)
+	}
+
+	if cfg.Profile != "" {
+		fmt.Printf("Using profile %s (%s)\n", cfg.Profile, cfg.Backend.URL)
+	}
+
+	server, err := daemon.NewServer(cfg)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create server: %v\n", err)
+		os.Exit(1)
+	}
+
+	transport, err := backendTransport(*useFakeBackend, *recordPath, *replayPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to set up backend transport: %v\n", err)
+		os.Exit(1)
+	}
+	if transport != nil {
+		server.SetBackendTransport(transport)
+	}
+
+	server.SetSessionTracker(sessionTracker(cfg.SessionTracking.TrackerType))
+	if detector := idleDetector(cfg.TimeTracking.IdleSources); detector != nil {
+		server.SetIdleDetector(detector)
+	}
+	if n := notifier(cfg.Reminders.Notifiers); n != nil {
+		server.SetNotifier(n)
+	}
+	server.SetVCSProvider(external.NewGitVCSProvider())
+
+	changeWatcher, err := external.NewFSNotifyWatcher()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to create change watcher: %v\n", err)
+	} else {
+		server.SetChangeWatcher(changeWatcher)
+	}
+
+	sigChan := make(chan os.Signal, 1)
+	signal.Notify(sigChan, syscall.SIGINT, syscall.SIGTERM)
+
+	stopping := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		<-sigChan
+		close(stopping)
+		fmt.Println("\nShutting down daemon...")
+		if err := server.Stop(); err != nil {
+			fmt.Fprintf(os.Stderr, "Error stopping server: %v\n", err)
+		}
+		// A recording is written once the last requests, such as the
+		// timers logged on shutdown, have been made.
+		if recorder, ok := transport.(io.Closer); ok {
+			if err := recorder.Close(); err != nil {
+				fmt.Fprintf(os.Stderr, "Error saving recording: %v\n", err)
+			}
+		}
+		close(stopped)
+	}()
+
+	err = server.Start()
+	select {
+	case <-stopping:
+		// Start fails once Stop closes the socket; wait for the rest of
+		// the shutdown instead.
+		<-stopped
+		os.Exit(0)
+	default:
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Daemon error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// sessionTracker returns the tracker for the configured session source,
+// falling back to tmux for unknown types.
+func sessionTracker(trackerType string) service.SessionTracker {
+	switch trackerType {
+	case "zellij":
+		return external.NewZellijSessionTracker()
+	case "shell":
+		return daemon.NewShellSessionTracker()
+	case "tmux":
+	default:
+		fmt.Fprintf(os.Stderr, "Warning: unknown session tracker %q, using tmux\n", trackerType)
+	}
+	return external.NewTmuxSessionTracker()
+}
+
+// idleDetector combines the configured activity sources. Unknown sources
+// are skipped; nil means idle detection is off.
+func idleDetector(sources []string) service.IdleDetector {
+	if len(sources) == 0 {
+		sources = []string{"tmux"}
+	}
+
+	var detectors []service.IdleDetector
+	for _, source := range sources {
+		var d service.IdleDetector
+		switch source {
+		case "tmux":
+			d = external.NewTmuxIdleDetector()
+		case "x11":
+			d = external.NewX11IdleDetector()
+		case "wayland":
+			d = external.NewWaylandIdleDetector()
+		default:
+			fmt.Fprintf(os.Stderr, "Warning: unknown idle source %q\n", source)
+			continue
+		}
+
+		// Kept even when unavailable now: tmux may start later, and a
+		// failing source is ignored while another one answers.
+		if !d.IsAvailable() {
+			fmt.Printf("Idle source %s not available yet\n", source)
+		}
+		detectors = append(detectors, d)
+	}
+
+	if len(detectors) == 0 {
+		return nil
+	}
+	return external.NewMultiIdleDetector(detectors...)
+}
+
+// notifier combines the configured notification channels. Every available
+// channel is used, so a desktop popup and a tmux message can both show.
+func notifier(names []string) service.Notifier {
+	var notifiers []service.Notifier
+	for _, name := range names {
+		var n service.Notifier
+		switch name {
+		case "dbus":
+			n = external.NewDBusNotifier()
+		case "notify-send":
+			n = external.NewNotifySendNotifier()
+		case "tmux":
+			n = external.NewTmuxNotifier()
+		case "bell":
+			n = external.NewBellNotifier()
+		default:
+			fmt.Fprintf(os.Stderr, "Warning: unknown notifier %q\n", name)
+			continue
+		}
+		notifiers = append(notifiers, n)
+	}
+
+	if len(notifiers) == 0 {
+		return nil
+	}
+	return external.NewMultiNotifier(notifiers...)
+}
+
+// backendTransport builds the HTTP transport for the backend client. It
+// returns nil when the default network transport should be used.
+func backendTransport(useFakeBackend bool, recordPath, replayPath string) (http.RoundTripper, error) {
+	var transport http.RoundTripper
+
+	switch {
+	case replayPath != "":
+		cassette, err := httpclient.LoadCassette(replayPath)
+		if err != nil {
+			return nil, err
+		}
+		transport = httpclient.NewReplayTransport(cassette)
+		fmt.Printf("Replaying backend traffic from %s\n", replayPath)
+	case useFakeBackend:
+		backend := fakebackend.New()
+		backend.Seed()
+		transport = backend.Transport()
+		fmt.Println("Using in-memory fake backend")
+	}
+
+	if recordPath != "" {
+		transport = httpclient.NewRecordingTransport(transport, recordPath)
+		fmt.Printf("Recording backend traffic to %s\n", recordPath)
+	}
+
+	return transport, nil
+}
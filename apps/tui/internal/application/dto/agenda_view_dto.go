@@ -0,0 +1,89 @@
+package dto
+
+const (
+	AgendaViewModeDay   = "day"
+	AgendaViewModeWeek  = "week"
+	AgendaViewModeMonth = "month"
+)
+
+// AgendaViewDto is the response of GET /agenda-views. The backend returns a
+// different shape per mode; fields that do not apply to the requested mode
+// are left at their zero value.
+type AgendaViewDto struct {
+	Mode            string                  `json:"mode"`
+	Timezone        string                  `json:"timezone"`
+	AnchorDate      string                  `json:"anchorDate"`
+	Label           string                  `json:"label"`
+	Navigation      AgendaViewNavigationDto `json:"navigation"`
+	UnfinishedItems []AgendaItemEnrichedDto `json:"unfinishedItems"`
+
+	// Day mode
+	DateKey      string                    `json:"dateKey,omitempty"`
+	IsToday      bool                      `json:"isToday,omitempty"`
+	WakeUpHour   *int                      `json:"wakeUpHour,omitempty"`
+	SleepHour    *int                      `json:"sleepHour,omitempty"`
+	AllDayItems  []AgendaItemEnrichedDto   `json:"allDayItems,omitempty"`
+	SpecialItems *AgendaDaySpecialItemsDto `json:"specialItems,omitempty"`
+	IsEmpty      bool                      `json:"isEmpty,omitempty"`
+
+	// Day and week mode. Week hour slots carry no items.
+	Hours []AgendaHourSlotDto `json:"hours,omitempty"`
+
+	// Week and month mode
+	Days []AgendaViewDayDto `json:"days,omitempty"`
+
+	// Week mode
+	RangeStart string `json:"rangeStart,omitempty"`
+	RangeEnd   string `json:"rangeEnd,omitempty"`
+
+	// Month mode
+	MonthStart    string   `json:"monthStart,omitempty"`
+	MonthEnd      string   `json:"monthEnd,omitempty"`
+	WeekdayLabels []string `json:"weekdayLabels,omitempty"`
+}
+
+type AgendaViewNavigationDto struct {
+	AnchorDate         string `json:"anchorDate"`
+	PreviousAnchorDate string `json:"previousAnchorDate"`
+	NextAnchorDate     string `json:"nextAnchorDate"`
+	TodayAnchorDate    string `json:"todayAnchorDate"`
+}
+
+type AgendaHourSlotDto struct {
+	Hour  int                     `json:"hour"`
+	Label string                  `json:"label"`
+	Items []AgendaItemEnrichedDto `json:"items,omitempty"`
+}
+
+// AgendaDaySpecialItemsDto holds the routine items the backend pulls out of
+// the hourly grid (sleep schedule and step goal).
+type AgendaDaySpecialItemsDto struct {
+	Wakeup *AgendaItemEnrichedDto `json:"wakeup"`
+	Sleep  *AgendaItemEnrichedDto `json:"sleep"`
+	Step   *AgendaItemEnrichedDto `json:"step"`
+}
+
+// AgendaViewDayDto is a single day column (week mode) or cell (month mode).
+type AgendaViewDayDto struct {
+	DateKey string `json:"dateKey"`
+	Label   string `json:"label"`
+	IsToday bool   `json:"isToday"`
+
+	// Week mode
+	ShortLabel  string                   `json:"shortLabel,omitempty"`
+	AllDayItems []AgendaItemEnrichedDto  `json:"allDayItems,omitempty"`
+	TimedItems  []AgendaWeekTimedItemDto `json:"timedItems,omitempty"`
+
+	// Month mode
+	IsCurrentMonth bool                    `json:"isCurrentMonth,omitempty"`
+	Items          []AgendaItemEnrichedDto `json:"items,omitempty"`
+	OverflowCount  int                     `json:"overflowCount,omitempty"`
+}
+
+type AgendaWeekTimedItemDto struct {
+	Item            AgendaItemEnrichedDto `json:"item"`
+	StartMinute     int                   `json:"startMinute"`
+	DurationMinutes int                   `json:"durationMinutes"`
+	OverlapIndex    int                   `json:"overlapIndex"`
+	OverlapCount    int                   `json:"overlapCount"`
+}
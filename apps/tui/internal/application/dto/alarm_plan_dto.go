@@ -0,0 +1,23 @@
+package dto
+
+const (
+	AlarmPlanStatusPending   = "PENDING"
+	AlarmPlanStatusActive    = "ACTIVE"
+	AlarmPlanStatusCancelled = "CANCELLED"
+	AlarmPlanStatusCompleted = "COMPLETED"
+)
+
+// AlarmPlanDto is an alarm the backend plans for a routine task, such as a
+// wake-up or bedtime alarm. RepeatIntervalMinutes is how often it rings
+// again until acknowledged.
+type AlarmPlanDto struct {
+	ID                    string                 `json:"id"`
+	RoutineTaskID         string                 `json:"routineTaskId"`
+	Status                string                 `json:"status"`
+	Type                  string                 `json:"type"`
+	TargetAt              string                 `json:"targetAt"`
+	RepeatIntervalMinutes int                    `json:"repeatIntervalMinutes"`
+	Metadata              map[string]interface{} `json:"metadata"`
+	CreatedAt             string                 `json:"createdAt"`
+	UpdatedAt             string                 `json:"updatedAt"`
+}
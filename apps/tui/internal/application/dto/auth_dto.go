@@ -0,0 +1,22 @@
+package dto
+
+// AuthSessionDto is the better-auth get-session response.
+type AuthSessionDto struct {
+	Session AuthSessionInfoDto `json:"session"`
+	User    AuthUserDto        `json:"user"`
+}
+
+type AuthSessionInfoDto struct {
+	ID        string `json:"id"`
+	UserID    string `json:"userId"`
+	ExpiresAt string `json:"expiresAt"`
+	CreatedAt string `json:"createdAt"`
+}
+
+type AuthUserDto struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	Email         string  `json:"email"`
+	EmailVerified bool    `json:"emailVerified"`
+	Image         *string `json:"image"`
+}
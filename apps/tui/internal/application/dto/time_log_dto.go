@@ -1,5 +1,7 @@
 package dto
 
+// TimeLogDto is a time log as the backend returns it. Duration is in
+// minutes.
 type TimeLogDto struct {
 	ID          string  `json:"id"`
 	TaskID      *string `json:"taskId"`
@@ -12,11 +14,33 @@ type TimeLogDto struct {
 	UpdatedAt   string  `json:"updatedAt"`
 }
 
+// TimeLogCreateRequest matches the backend's POST /time-logs body. Details
+// the backend has no column for, such as the description, go in Metadata.
 type TimeLogCreateRequest struct {
-	TaskID      *string `json:"taskId,omitempty"`
-	ProjectID   *string `json:"projectId,omitempty"`
-	StartTime   string  `json:"startTime"`
-	EndTime     *string `json:"endTime,omitempty"`
-	Duration    *int    `json:"duration,omitempty"`
-	Description *string `json:"description,omitempty"`
+	ProjectID       *string           `json:"project_id,omitempty"`
+	TaskID          *string           `json:"task_id,omitempty"`
+	Date            string            `json:"date"`
+	DurationMinutes int               `json:"duration_minutes"`
+	Source          string            `json:"source"`
+	Metadata        map[string]string `json:"metadata,omitempty"`
+}
+
+// TimeLogRecordDto is a time log as /time-logs/daily returns it: the
+// stored record, not mapped to TimeLogDto.
+type TimeLogRecordDto struct {
+	ID              string                 `json:"id"`
+	ProjectID       *string                `json:"projectId"`
+	TaskID          *string                `json:"taskId"`
+	Date            string                 `json:"date"`
+	DurationMinutes int                    `json:"durationMinutes"`
+	Source          string                 `json:"source"`
+	Metadata        map[string]interface{} `json:"metadata"`
+	CreatedAt       string                 `json:"createdAt"`
+	UpdatedAt       string                 `json:"updatedAt"`
+}
+
+// TimeSummaryDto is one project's total from /time-logs/summary.
+type TimeSummaryDto struct {
+	ProjectID    string `json:"projectId"`
+	TotalMinutes int    `json:"totalMinutes"`
 }
@@ -0,0 +1,333 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/config"
+	"cadence/pkg/timeparse"
+)
+
+var actionOperators = map[string]bool{
+	"equals": true, "not_equals": true, "contains": true, "matches": true,
+	"in": true, "not_in": true, "empty": true, "not_empty": true,
+	"before": true, "after": true, "gt": true, "lt": true,
+}
+
+// actionContext is what a rule runs on: the event, the task involved and,
+// for updates and moves, the task as it was before.
+type actionContext struct {
+	event    string
+	rule     string
+	task     *dto.TaskDto
+	previous *dto.TaskDto
+	depth    int
+	// boards caches the boards read during one event or schedule run.
+	boards map[string]*dto.BoardDetailDto
+}
+
+func validateActionCondition(cond config.ActionCondition) error {
+	if cond.Field == "" {
+		return fmt.Errorf("condition without a field")
+	}
+	op := conditionOperator(cond)
+	if !actionOperators[op] {
+		return fmt.Errorf("unknown condition operator %q", cond.Operator)
+	}
+	if op == "matches" {
+		if _, err := regexp.Compile(fmt.Sprint(cond.Value)); err != nil {
+			return fmt.Errorf("condition on %s: %w", cond.Field, err)
+		}
+	}
+	return nil
+}
+
+func conditionOperator(cond config.ActionCondition) string {
+	if cond.Operator == "" {
+		return "equals"
+	}
+	return strings.ToLower(cond.Operator)
+}
+
+// conditionValues turns a condition's value, a scalar or a list, into
+// strings.
+func conditionValues(cond config.ActionCondition) []string {
+	switch v := cond.Value.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			values = append(values, fmt.Sprint(item))
+		}
+		return values
+	}
+	return []string{fmt.Sprint(cond.Value)}
+}
+
+func describeCondition(cond config.ActionCondition) string {
+	op := conditionOperator(cond)
+	if op == "empty" || op == "not_empty" {
+		return cond.Field + " " + op
+	}
+	values := conditionValues(cond)
+	if len(values) == 1 {
+		return fmt.Sprintf("%s %s %s", cond.Field, op, values[0])
+	}
+	return fmt.Sprintf("%s %s [%s]", cond.Field, op, strings.Join(values, ", "))
+}
+
+func (e *ActionEngine) conditionHolds(ctx context.Context, cond config.ActionCondition, ac *actionContext) (bool, error) {
+	field, err := e.fieldValue(ctx, ac, cond.Field)
+	if err != nil {
+		return false, err
+	}
+	values := conditionValues(cond)
+	first := ""
+	if len(values) > 0 {
+		first = values[0]
+	}
+
+	switch conditionOperator(cond) {
+	case "equals":
+		return strings.EqualFold(field, first), nil
+	case "not_equals":
+		return !strings.EqualFold(field, first), nil
+	case "contains":
+		return strings.Contains(strings.ToLower(field), strings.ToLower(first)), nil
+	case "matches":
+		re, err := regexp.Compile(first)
+		if err != nil {
+			return false, err
+		}
+		return re.MatchString(field), nil
+	case "in", "not_in":
+		found := false
+		for _, v := range values {
+			if strings.EqualFold(field, v) {
+				found = true
+				break
+			}
+		}
+		return found == (conditionOperator(cond) == "in"), nil
+	case "empty":
+		return field == "", nil
+	case "not_empty":
+		return field != "", nil
+	case "before", "after":
+		if field == "" {
+			return false, nil
+		}
+		now := time.Now()
+		t, err := parseReminderTime(field)
+		if err != nil {
+			return false, fmt.Errorf("%s is not a date: %q", cond.Field, field)
+		}
+		ref, err := resolveActionTime(first, now)
+		if err != nil {
+			return false, err
+		}
+		if conditionOperator(cond) == "before" {
+			return t.Before(ref), nil
+		}
+		return t.After(ref), nil
+	case "gt", "lt":
+		a, errA := strconv.ParseFloat(field, 64)
+		b, errB := strconv.ParseFloat(first, 64)
+		if errA != nil || errB != nil {
+			return false, nil
+		}
+		if conditionOperator(cond) == "gt" {
+			return a > b, nil
+		}
+		return a < b, nil
+	}
+	return false, fmt.Errorf("unknown condition operator %q", cond.Operator)
+}
+
+// resolveActionTime reads the times conditions compare with: anything
+// timeparse reads, such as "now" or "tomorrow", and "in 2d".
+func resolveActionTime(s string, now time.Time) (time.Time, error) {
+	if rest, ok := strings.CutPrefix(strings.ToLower(strings.TrimSpace(s)), "in "); ok {
+		d, err := timeparse.ParseDuration(rest)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(d), nil
+	}
+	return timeparse.ParseTime(s, now)
+}
+
+// fieldValue reads a field for conditions: any TaskDto field by its JSON
+// name (or in snake_case), "previous." and a field for the task before the
+// change, and the derived fields column, board, column_tasks, wip_limit,
+// wip_limit_reached, overdue, has_due_date and event.
+func (e *ActionEngine) fieldValue(ctx context.Context, ac *actionContext, name string) (string, error) {
+	task := ac.task
+	if rest, ok := strings.CutPrefix(name, "previous."); ok {
+		task, name = ac.previous, rest
+	}
+
+	switch name {
+	case "event":
+		return ac.event, nil
+	case "has_due_date":
+		return strconv.FormatBool(task != nil && stringValue(task.DueDate) != ""), nil
+	case "overdue":
+		if task == nil || task.DueDate == nil || task.CompletedAt != nil || task.Status == dto.TaskStatusDone {
+			return "false", nil
+		}
+		due, err := parseReminderTime(*task.DueDate)
+		return strconv.FormatBool(err == nil && due.Before(time.Now())), nil
+	}
+	if task == nil {
+		return "", nil
+	}
+
+	switch name {
+	case "column", "board", "column_tasks", "wip_limit", "wip_limit_reached":
+		if name == "column" && task.Column != nil && task.Column.Name != "" {
+			return task.Column.Name, nil
+		}
+		board, err := e.board(ctx, ac, task.BoardID)
+		if err != nil {
+			return "", err
+		}
+		if name == "board" {
+			return board.Name, nil
+		}
+		for _, col := range board.Columns {
+			if col.ID != task.ColumnID {
+				continue
+			}
+			switch name {
+			case "column":
+				return col.Name, nil
+			case "column_tasks":
+				return strconv.Itoa(columnTaskCount(col)), nil
+			case "wip_limit":
+				if col.WipLimit == nil {
+					return "", nil
+				}
+				return strconv.Itoa(*col.WipLimit), nil
+			default:
+				return strconv.FormatBool(col.WipLimit != nil && *col.WipLimit > 0 && columnTaskCount(col) >= *col.WipLimit), nil
+			}
+		}
+		return "", nil
+	}
+
+	value, ok := taskFields(task)[camelField(name)]
+	if !ok || value == nil {
+		return "", nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+func columnTaskCount(col dto.BoardColumnDto) int {
+	if col.TaskCount > 0 {
+		return col.TaskCount
+	}
+	return len(col.Tasks)
+}
+
+// taskFields returns a task's fields by their JSON names.
+func taskFields(task *dto.TaskDto) map[string]interface{} {
+	fields := make(map[string]interface{})
+	if task == nil {
+		return fields
+	}
+	data, err := json.Marshal(task)
+	if err != nil {
+		return fields
+	}
+	_ = json.Unmarshal(data, &fields)
+	return fields
+}
+
+// camelField turns "due_date" into "dueDate", the form of the JSON names.
+func camelField(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// templateData is what the text of actions can refer to: the task's
+// fields such as {{.title}} and {{.status}}, {{.key}} (CAD-12),
+// {{.column}}, {{.event}}, {{.rule}} and {{.previous.status}}.
+func (ac *actionContext) templateData() map[string]interface{} {
+	data := taskFields(ac.task)
+	data["event"] = ac.event
+	data["rule"] = ac.rule
+	data["previous"] = taskFields(ac.previous)
+	data["key"] = ""
+	data["column"] = ""
+	if ac.task != nil {
+		data["key"] = taskKey(*ac.task)
+		if ac.task.Column != nil {
+			data["column"] = ac.task.Column.Name
+		}
+	}
+	return data
+}
+
+func (ac *actionContext) render(text string) (string, error) {
+	if !strings.Contains(text, "{{") {
+		return text, nil
+	}
+	tmpl, err := template.New(ac.rule).Option("missingkey=zero").Parse(text)
+	if err != nil {
+		return "", err
+	}
+	var out strings.Builder
+	if err := tmpl.Execute(&out, ac.templateData()); err != nil {
+		return "", err
+	}
+	return strings.ReplaceAll(out.String(), "<no value>", ""), nil
+}
+
+// scriptEnv describes the task to script actions.
+func (ac *actionContext) scriptEnv() []string {
+	env := []string{
+		"CADENCE_EVENT=" + ac.event,
+		"CADENCE_RULE=" + ac.rule,
+	}
+	if ac.task == nil {
+		return env
+	}
+	column := ""
+	if ac.task.Column != nil {
+		column = ac.task.Column.Name
+	}
+	return append(env,
+		"TASK_ID="+ac.task.ID,
+		"TASK_KEY="+taskKey(*ac.task),
+		"TASK_TITLE="+ac.task.Title,
+		"TASK_STATUS="+ac.task.Status,
+		"TASK_PRIORITY="+stringValue(ac.task.Priority),
+		"TASK_DUE_DATE="+stringValue(ac.task.DueDate),
+		"PROJECT_ID="+ac.task.ProjectID,
+		"BOARD_ID="+ac.task.BoardID,
+		"COLUMN_ID="+ac.task.ColumnID,
+		"COLUMN_NAME="+column,
+	)
+}
+
+// taskKey returns a task's key such as "CAD-12", or its ID.
+func taskKey(task dto.TaskDto) string {
+	if m := slugKeyPattern.FindStringSubmatch(strings.ToLower(task.Slug)); m != nil {
+		return strings.ToUpper(m[1]) + "-" + m[2]
+	}
+	return task.ID
+}
@@ -0,0 +1,135 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/config"
+)
+
+func TestValidateActionCondition(t *testing.T) {
+	tests := []struct {
+		cond    config.ActionCondition
+		wantErr bool
+	}{
+		{config.ActionCondition{Field: "status", Value: "DONE"}, false},
+		{config.ActionCondition{Field: "title", Operator: "MATCHES", Value: "^fix"}, false},
+		{config.ActionCondition{Field: "due_date", Operator: "empty"}, false},
+		{config.ActionCondition{Operator: "equals", Value: "x"}, true},
+		{config.ActionCondition{Field: "status", Operator: "like", Value: "x"}, true},
+		{config.ActionCondition{Field: "title", Operator: "matches", Value: "(unclosed"}, true},
+	}
+	for _, tt := range tests {
+		err := validateActionCondition(tt.cond)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("validateActionCondition(%+v) error = %v, want error %v", tt.cond, err, tt.wantErr)
+		}
+	}
+}
+
+func TestDescribeCondition(t *testing.T) {
+	tests := []struct {
+		cond config.ActionCondition
+		want string
+	}{
+		{config.ActionCondition{Field: "status", Value: "DONE"}, "status equals DONE"},
+		{config.ActionCondition{Field: "priority", Operator: "in", Value: []interface{}{"HIGH", "URGENT"}}, "priority in [HIGH, URGENT]"},
+		{config.ActionCondition{Field: "due_date", Operator: "not_empty"}, "due_date not_empty"},
+		{config.ActionCondition{Field: "estimated_minutes", Operator: "gt", Value: 60}, "estimated_minutes gt 60"},
+	}
+	for _, tt := range tests {
+		if got := describeCondition(tt.cond); got != tt.want {
+			t.Errorf("describeCondition(%+v) = %q, want %q", tt.cond, got, tt.want)
+		}
+	}
+}
+
+func TestConditionHolds(t *testing.T) {
+	str := func(s string) *string { return &s }
+	estimate := 90
+	ac := &actionContext{
+		event: "task_updated",
+		task: &dto.TaskDto{
+			Title:            "Fix login bug",
+			Status:           dto.TaskStatusInProgress,
+			Priority:         str("HIGH"),
+			Column:           &dto.ColumnDto{Name: "Doing"},
+			DueDate:          str(time.Now().Add(-24 * time.Hour).UTC().Format(time.RFC3339)),
+			EstimatedMinutes: &estimate,
+		},
+		previous: &dto.TaskDto{Title: "Fix login bug", Status: dto.TaskStatusTodo},
+	}
+
+	tests := []struct {
+		cond config.ActionCondition
+		want bool
+	}{
+		{config.ActionCondition{Field: "title", Value: "fix login bug"}, true},
+		{config.ActionCondition{Field: "status", Operator: "not_equals", Value: "DONE"}, true},
+		{config.ActionCondition{Field: "title", Operator: "contains", Value: "LOGIN"}, true},
+		{config.ActionCondition{Field: "title", Operator: "matches", Value: "^Fix .* bug$"}, true},
+		{config.ActionCondition{Field: "title", Operator: "matches", Value: "^login"}, false},
+		{config.ActionCondition{Field: "priority", Operator: "in", Value: []interface{}{"high", "urgent"}}, true},
+		{config.ActionCondition{Field: "priority", Operator: "not_in", Value: []interface{}{"high", "urgent"}}, false},
+		{config.ActionCondition{Field: "description", Operator: "empty"}, true},
+		{config.ActionCondition{Field: "completed_at", Operator: "empty"}, true},
+		{config.ActionCondition{Field: "dueDate", Operator: "not_empty"}, true},
+		{config.ActionCondition{Field: "estimated_minutes", Operator: "gt", Value: 60}, true},
+		{config.ActionCondition{Field: "estimated_minutes", Operator: "lt", Value: 60}, false},
+		{config.ActionCondition{Field: "title", Operator: "gt", Value: 1}, false},
+		{config.ActionCondition{Field: "due_date", Operator: "before", Value: "now"}, true},
+		{config.ActionCondition{Field: "due_date", Operator: "after", Value: "in 2d"}, false},
+		{config.ActionCondition{Field: "parent_id", Operator: "before", Value: "now"}, false},
+		{config.ActionCondition{Field: "previous.status", Value: "todo"}, true},
+		{config.ActionCondition{Field: "column", Value: "doing"}, true},
+		{config.ActionCondition{Field: "event", Value: "task_updated"}, true},
+		{config.ActionCondition{Field: "has_due_date", Value: "true"}, true},
+		{config.ActionCondition{Field: "overdue", Value: "true"}, true},
+		{config.ActionCondition{Field: "previous.overdue", Value: "false"}, true},
+	}
+
+	e := &ActionEngine{}
+	for _, tt := range tests {
+		got, err := e.conditionHolds(context.Background(), tt.cond, ac)
+		if err != nil {
+			t.Errorf("conditionHolds(%s) error: %v", describeCondition(tt.cond), err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("conditionHolds(%s) = %v, want %v", describeCondition(tt.cond), got, tt.want)
+		}
+	}
+
+	if _, err := e.conditionHolds(context.Background(), config.ActionCondition{Field: "title", Operator: "like"}, ac); err == nil {
+		t.Error("conditionHolds with an unknown operator succeeded, want an error")
+	}
+}
+
+func TestResolveActionTime(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 4, 0, 0, time.UTC)
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"now", now},
+		{"in 2d", now.Add(48 * time.Hour)},
+		{"In 90 min", now.Add(90 * time.Minute)},
+		{"tomorrow", time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+		{"2026-11-01", time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		got, err := resolveActionTime(tt.in, now)
+		if err != nil {
+			t.Errorf("resolveActionTime(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("resolveActionTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+	if _, err := resolveActionTime("in a while", now); err == nil {
+		t.Error("resolveActionTime(\"in a while\") succeeded, want an error")
+	}
+}
@@ -0,0 +1,838 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/domain/entity"
+	"cadence/internal/domain/service"
+	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/httpclient"
+	"cadence/pkg/cron"
+)
+
+const (
+	ActionEventTaskCreated     = "task_created"
+	ActionEventTaskUpdated     = "task_updated"
+	ActionEventTaskMoved       = "task_moved"
+	ActionEventTaskDeleted     = "task_deleted"
+	ActionEventStatusChanged   = "status_changed"
+	ActionEventPriorityChanged = "priority_changed"
+	ActionEventTimerStarted    = "timer_started"
+	ActionEventSchedule        = "schedule"
+
+	ActionUpdateTask = "update_task"
+	ActionMoveTask   = "move_task"
+	ActionStartTimer = "start_timer"
+	ActionStopTimer  = "stop_timer"
+	ActionCreateNote = "create_note"
+	ActionScript     = "script"
+	ActionNotify     = "notify"
+
+	// maxActionDepth stops rules from setting each other off forever: a
+	// change made by a rule fires events, but only this many levels deep.
+	maxActionDepth = 3
+
+	actionQueueSize = 64
+)
+
+var actionEvents = map[string]bool{
+	ActionEventTaskCreated: true, ActionEventTaskUpdated: true, ActionEventTaskMoved: true,
+	ActionEventTaskDeleted: true, ActionEventStatusChanged: true, ActionEventPriorityChanged: true,
+	ActionEventTimerStarted: true,
+}
+
+var actionTypes = map[string]bool{
+	ActionUpdateTask: true, ActionMoveTask: true, ActionStartTimer: true, ActionStopTimer: true,
+	ActionCreateNote: true, ActionScript: true, ActionNotify: true,
+}
+
+// ActionEvent is a change the rules may react to. Previous is the task
+// before an update or move; TaskID stands in for Task when only the ID is
+// at hand, as for a timer.
+type ActionEvent struct {
+	Type     string
+	Task     *dto.TaskDto
+	Previous *dto.TaskDto
+	TaskID   string
+	depth    int
+}
+
+// actionRule is a configured rule with its schedule parsed. Rules that do
+// not make sense keep the reason in err and never run.
+type actionRule struct {
+	config.ActionRule
+	schedule *cron.Schedule
+	err      error
+}
+
+// ActionEngine runs the automation rules in the config: on task events
+// reported by the server, when a timer starts and on cron schedules.
+// Rules run one at a time on the engine's own goroutine, so a slow script
+// does not hold up the request that set it off.
+type ActionEngine struct {
+	config        *config.Config
+	backendClient *httpclient.BackendClient
+	timeTracking  *TimeTrackingManager
+	notifier      service.Notifier
+	onTaskChanged func(notificationType string, task *dto.TaskDto)
+
+	rules  []*actionRule
+	events chan ActionEvent
+
+	mu       sync.Mutex
+	stopChan chan struct{}
+	stopped  bool
+}
+
+func NewActionEngine(
+	cfg *config.Config,
+	backendClient *httpclient.BackendClient,
+	timeTracking *TimeTrackingManager,
+	notifier service.Notifier,
+) *ActionEngine {
+	e := &ActionEngine{
+		config:        cfg,
+		backendClient: backendClient,
+		timeTracking:  timeTracking,
+		notifier:      notifier,
+		events:        make(chan ActionEvent, actionQueueSize),
+		stopChan:      make(chan struct{}),
+	}
+
+	for _, rule := range cfg.Actions.Rules {
+		compiled := compileActionRule(rule)
+		if compiled.err != nil {
+			fmt.Printf("[Actions] Ignoring rule %q: %v\n", rule.Name, compiled.err)
+		}
+		e.rules = append(e.rules, compiled)
+	}
+
+	return e
+}
+
+func compileActionRule(rule config.ActionRule) *actionRule {
+	compiled := &actionRule{ActionRule: rule}
+
+	switch {
+	case rule.Trigger.Event != "" && rule.Trigger.Schedule != "":
+		compiled.err = fmt.Errorf("trigger has both an event and a schedule")
+	case rule.Trigger.Schedule != "":
+		compiled.schedule, compiled.err = cron.Parse(rule.Trigger.Schedule)
+	case !actionEvents[rule.Trigger.Event]:
+		compiled.err = fmt.Errorf("unknown trigger event %q", rule.Trigger.Event)
+	}
+	if compiled.err != nil {
+		return compiled
+	}
+
+	for _, cond := range rule.Conditions {
+		if err := validateActionCondition(cond); err != nil {
+			compiled.err = err
+			return compiled
+		}
+	}
+
+	if len(rule.Actions) == 0 {
+		compiled.err = fmt.Errorf("no actions")
+	}
+	for _, step := range rule.Actions {
+		if !actionTypes[step.Type] {
+			compiled.err = fmt.Errorf("unknown action type %q", step.Type)
+			return compiled
+		}
+	}
+	return compiled
+}
+
+// SetTaskChangedHandler sets the function told about tasks the rules
+// change, so subscribers see them like any other change.
+func (e *ActionEngine) SetTaskChangedHandler(fn func(notificationType string, task *dto.TaskDto)) {
+	e.onTaskChanged = fn
+}
+
+// HasEventRules reports whether any rule listens for events, so callers
+// can skip reading a task's previous state when none does.
+func (e *ActionEngine) HasEventRules() bool {
+	for _, rule := range e.rules {
+		if rule.err == nil && !rule.Disabled && rule.Trigger.Event != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *ActionEngine) Start(ctx context.Context) {
+	go e.loop(ctx)
+}
+
+func (e *ActionEngine) Stop() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.stopped {
+		return
+	}
+	e.stopped = true
+	close(e.stopChan)
+}
+
+// Dispatch queues an event for the rules. Events are dropped, with a note
+// in the log, when the queue is full.
+func (e *ActionEngine) Dispatch(event ActionEvent) {
+	if event.depth > maxActionDepth {
+		fmt.Printf("[Actions] Not running rules for %s: rules changed the task %d times in a row\n", event.Type, event.depth)
+		return
+	}
+
+	select {
+	case e.events <- event:
+	default:
+		fmt.Printf("[Actions] Dropped %s event: queue full\n", event.Type)
+	}
+}
+
+func (e *ActionEngine) loop(ctx context.Context) {
+	minute := time.NewTimer(untilNextMinute(time.Now()))
+	defer minute.Stop()
+
+	for {
+		select {
+		case event := <-e.events:
+			e.handleEvent(ctx, event)
+		case now := <-minute.C:
+			e.runSchedules(ctx, now)
+			minute.Reset(untilNextMinute(time.Now()))
+		case <-e.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func untilNextMinute(now time.Time) time.Duration {
+	return now.Truncate(time.Minute).Add(time.Minute).Sub(now)
+}
+
+func (e *ActionEngine) handleEvent(ctx context.Context, event ActionEvent) {
+	if event.Task == nil && event.TaskID != "" {
+		task, err := e.backendClient.GetTask(ctx, event.TaskID)
+		if err != nil {
+			fmt.Printf("[Actions] Failed to read task %s for %s: %v\n", event.TaskID, event.Type, err)
+			return
+		}
+		event.Task = task
+	}
+
+	for _, plan := range e.evaluateEvent(ctx, event, false) {
+		e.logPlan(plan)
+	}
+}
+
+func (e *ActionEngine) runSchedules(ctx context.Context, now time.Time) {
+	for _, rule := range e.rules {
+		if rule.schedule == nil || rule.Disabled || !rule.schedule.Matches(now) {
+			continue
+		}
+		for _, plan := range e.evaluateSchedule(ctx, rule, false) {
+			e.logPlan(plan)
+		}
+	}
+}
+
+func (e *ActionEngine) logPlan(plan ActionPlan) {
+	if !plan.Matched {
+		return
+	}
+	target := ""
+	if plan.Task != "" {
+		target = " on " + plan.Task
+	}
+	for _, action := range plan.Actions {
+		fmt.Printf("[Actions] Rule %q%s: %s\n", plan.Rule, target, action)
+	}
+	if plan.Error != "" {
+		fmt.Printf("[Actions] Rule %q%s failed: %s\n", plan.Rule, target, plan.Error)
+	}
+}
+
+// evaluateEvent runs, or with dryRun only describes, the rules an event
+// sets off. An update or move that changes a task's status or priority
+// also counts as a status_changed or priority_changed event.
+func (e *ActionEngine) evaluateEvent(ctx context.Context, event ActionEvent, dryRun bool) []ActionPlan {
+	types := []string{event.Type}
+	if event.Previous != nil && event.Task != nil {
+		if !strings.EqualFold(event.Previous.Status, event.Task.Status) {
+			types = append(types, ActionEventStatusChanged)
+		}
+		if stringValue(event.Previous.Priority) != stringValue(event.Task.Priority) {
+			types = append(types, ActionEventPriorityChanged)
+		}
+	}
+
+	boards := make(map[string]*dto.BoardDetailDto)
+	var plans []ActionPlan
+	for _, eventType := range types {
+		for _, rule := range e.rules {
+			if rule.err != nil || rule.Disabled || rule.Trigger.Event != eventType {
+				continue
+			}
+			ac := &actionContext{
+				event:    eventType,
+				rule:     rule.Name,
+				task:     event.Task,
+				previous: event.Previous,
+				depth:    event.depth,
+				boards:   boards,
+			}
+			plans = append(plans, e.evaluateRule(ctx, rule, ac, dryRun))
+		}
+	}
+	return plans
+}
+
+// evaluateSchedule runs, or describes, a scheduled rule: once for every
+// task its conditions hold for, or once on its own when it has none.
+func (e *ActionEngine) evaluateSchedule(ctx context.Context, rule *actionRule, dryRun bool) []ActionPlan {
+	boards := make(map[string]*dto.BoardDetailDto)
+	if len(rule.Conditions) == 0 {
+		ac := &actionContext{event: ActionEventSchedule, rule: rule.Name, boards: boards}
+		return []ActionPlan{e.evaluateRule(ctx, rule, ac, dryRun)}
+	}
+
+	tasks, err := e.listAllTasks(ctx)
+	if err != nil {
+		return []ActionPlan{{Rule: rule.Name, Trigger: ruleTrigger(rule), Error: err.Error()}}
+	}
+
+	var plans []ActionPlan
+	for i := range tasks {
+		ac := &actionContext{event: ActionEventSchedule, rule: rule.Name, task: &tasks[i], boards: boards}
+		if plan := e.evaluateRule(ctx, rule, ac, dryRun); plan.Matched {
+			plans = append(plans, plan)
+		}
+	}
+	if len(plans) == 0 && dryRun {
+		plans = append(plans, ActionPlan{Rule: rule.Name, Trigger: ruleTrigger(rule), Reason: "no task matches the conditions"})
+	}
+	return plans
+}
+
+func (e *ActionEngine) evaluateRule(ctx context.Context, rule *actionRule, ac *actionContext, dryRun bool) ActionPlan {
+	plan := ActionPlan{Rule: rule.Name, Trigger: ruleTrigger(rule)}
+	if ac.task != nil {
+		plan.TaskID = ac.task.ID
+		plan.Task = taskLabel(*ac.task)
+	}
+
+	for _, cond := range rule.Conditions {
+		ok, err := e.conditionHolds(ctx, cond, ac)
+		if err != nil {
+			plan.Reason = err.Error()
+			return plan
+		}
+		if !ok {
+			plan.Reason = "condition not met: " + describeCondition(cond)
+			return plan
+		}
+	}
+
+	plan.Matched = true
+	for _, step := range rule.Actions {
+		description, err := e.runStep(ctx, step, ac, dryRun)
+		if err != nil {
+			plan.Error = fmt.Sprintf("%s: %v", step.Type, err)
+			return plan
+		}
+		plan.Actions = append(plan.Actions, description)
+	}
+	return plan
+}
+
+// runStep carries out one action, or only describes it when dryRun is set.
+func (e *ActionEngine) runStep(ctx context.Context, step config.ActionStep, ac *actionContext, dryRun bool) (string, error) {
+	switch step.Type {
+	case ActionUpdateTask:
+		return e.updateTask(ctx, step, ac, dryRun)
+	case ActionMoveTask:
+		return e.moveTask(ctx, step, ac, dryRun)
+	case ActionStartTimer:
+		return e.startTimer(ctx, step, ac, dryRun)
+	case ActionStopTimer:
+		return e.stopTimer(ctx, ac, dryRun)
+	case ActionCreateNote:
+		return e.createNote(ctx, step, ac, dryRun)
+	case ActionScript:
+		return e.runScript(ctx, step, ac, dryRun)
+	case ActionNotify:
+		return e.notify(step, ac, dryRun)
+	}
+	return "", fmt.Errorf("unknown action type %q", step.Type)
+}
+
+func (e *ActionEngine) updateTask(ctx context.Context, step config.ActionStep, ac *actionContext, dryRun bool) (string, error) {
+	if ac.task == nil {
+		return "", fmt.Errorf("no task to update")
+	}
+
+	var req dto.TaskUpdateRequest
+	keys := make([]string, 0, len(step.Fields))
+	for key := range step.Fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var changes []string
+	for _, key := range keys {
+		value, err := ac.render(step.Fields[key])
+		if err != nil {
+			return "", err
+		}
+		v := value
+		switch camelField(key) {
+		case "title":
+			req.Title = &v
+		case "description":
+			req.Description = &v
+		case "status":
+			v = strings.ToUpper(v)
+			req.Status = &v
+		case "priority":
+			v = strings.ToUpper(v)
+			req.Priority = &v
+		case "dueDate":
+			if due, err := resolveActionTime(v, time.Now()); err == nil {
+				v = due.Format(time.RFC3339)
+			}
+			req.DueDate = &v
+		case "completedAt":
+			// The backend's task update takes no completion time
+			return "", fmt.Errorf("cannot set field %q: the backend does not accept it; move the task to a done column instead", key)
+		default:
+			return "", fmt.Errorf("cannot set field %q", key)
+		}
+		changes = append(changes, fmt.Sprintf("%s=%s", key, v))
+	}
+
+	description := "update task: " + strings.Join(changes, ", ")
+	if dryRun {
+		return description, nil
+	}
+
+	updated, err := e.backendClient.UpdateTask(ctx, ac.task.ID, req)
+	if err != nil {
+		return "", err
+	}
+	e.taskChanged(NotificationTaskUpdated, ActionEventTaskUpdated, ac, updated)
+	return description, nil
+}
+
+func (e *ActionEngine) moveTask(ctx context.Context, step config.ActionStep, ac *actionContext, dryRun bool) (string, error) {
+	if ac.task == nil {
+		return "", fmt.Errorf("no task to move")
+	}
+
+	target, err := ac.render(step.Column)
+	if err != nil {
+		return "", err
+	}
+	board, err := e.board(ctx, ac, ac.task.BoardID)
+	if err != nil {
+		return "", err
+	}
+
+	var column *dto.BoardColumnDto
+	for i := range board.Columns {
+		if board.Columns[i].ID == target || strings.EqualFold(board.Columns[i].Name, target) {
+			column = &board.Columns[i]
+			break
+		}
+	}
+	if column == nil {
+		return "", fmt.Errorf("no column %q on board %s", target, board.Name)
+	}
+	if column.ID == ac.task.ColumnID {
+		return "move task: already in " + column.Name, nil
+	}
+
+	description := "move task to " + column.Name
+	if dryRun {
+		return description, nil
+	}
+
+	moved, err := e.backendClient.MoveTask(ctx, ac.task.ID, dto.TaskMoveRequest{TargetColumnID: column.ID})
+	if err != nil {
+		return "", err
+	}
+	delete(ac.boards, ac.task.BoardID)
+	e.taskChanged(NotificationTaskMoved, ActionEventTaskMoved, ac, moved)
+	return description, nil
+}
+
+func (e *ActionEngine) startTimer(ctx context.Context, step config.ActionStep, ac *actionContext, dryRun bool) (string, error) {
+	if ac.task == nil {
+		return "", fmt.Errorf("no task to time")
+	}
+	if e.timeTracking == nil {
+		return "", fmt.Errorf("time tracking not available")
+	}
+
+	description, err := ac.render(step.Description)
+	if err != nil {
+		return "", err
+	}
+	if dryRun {
+		return "start timer", nil
+	}
+
+	if _, err := e.timeTracking.StartTimer(ctx, ac.task.ProjectID, ac.task.ID, description); err != nil {
+		return "", err
+	}
+	e.Dispatch(ActionEvent{Type: ActionEventTimerStarted, Task: ac.task, depth: ac.depth + 1})
+	return "start timer", nil
+}
+
+func (e *ActionEngine) stopTimer(ctx context.Context, ac *actionContext, dryRun bool) (string, error) {
+	if ac.task == nil {
+		return "", fmt.Errorf("no task to stop timing")
+	}
+	if e.timeTracking == nil {
+		return "", fmt.Errorf("time tracking not available")
+	}
+	if dryRun {
+		return "stop timer", nil
+	}
+
+	log, err := e.timeTracking.StopTimer(ctx, TimerRef{ProjectID: ac.task.ProjectID, TaskID: ac.task.ID})
+	if errors.Is(err, entity.ErrTimeLogNotFound) {
+		return "stop timer: none running", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("stop timer (%s logged)", log.Duration().Round(time.Second)), nil
+}
+
+func (e *ActionEngine) createNote(ctx context.Context, step config.ActionStep, ac *actionContext, dryRun bool) (string, error) {
+	title, err := ac.render(step.Title)
+	if err != nil {
+		return "", err
+	}
+	content, err := ac.render(step.Body)
+	if err != nil {
+		return "", err
+	}
+	if title == "" {
+		return "", fmt.Errorf("note has no title")
+	}
+
+	noteType := strings.ToUpper(step.NoteType)
+	if noteType == "" {
+		noteType = dto.NoteTypeGeneral
+	}
+
+	description := fmt.Sprintf("create %s note %q", strings.ToLower(noteType), title)
+	if dryRun {
+		return description, nil
+	}
+
+	if _, err := e.backendClient.CreateNote(ctx, dto.NoteCreateRequest{Type: noteType, Title: title, Content: content}); err != nil {
+		return "", err
+	}
+	return description, nil
+}
+
+// runScript runs a shell command in the scripts directory with the task in
+// TASK_* variables. Its output goes to the daemon log. The command is not
+// a template: task fields rendered into it would be run by the shell.
+func (e *ActionEngine) runScript(ctx context.Context, step config.ActionStep, ac *actionContext, dryRun bool) (string, error) {
+	command := strings.TrimSpace(step.Command)
+	if command == "" {
+		return "", fmt.Errorf("no command")
+	}
+
+	description := "run " + command
+	if dryRun {
+		return description, nil
+	}
+
+	timeout := time.Duration(e.config.Actions.ScriptTimeout) * time.Second
+	if timeout <= 0 {
+		timeout = 30 * time.Second
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, "sh", "-c", command)
+	if dir := e.config.Actions.ScriptsDir; dir != "" {
+		if info, err := os.Stat(dir); err == nil && info.IsDir() {
+			cmd.Dir = dir
+		}
+	}
+	cmd.Env = append(os.Environ(), ac.scriptEnv()...)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err := cmd.Run()
+
+	for _, line := range strings.Split(strings.TrimRight(out.String(), "\n"), "\n") {
+		if line != "" {
+			fmt.Printf("[Actions] %s: %s\n", ac.rule, line)
+		}
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return "", fmt.Errorf("%q timed out after %s", command, timeout)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%q: %w", command, err)
+	}
+	return description, nil
+}
+
+func (e *ActionEngine) notify(step config.ActionStep, ac *actionContext, dryRun bool) (string, error) {
+	title, err := ac.render(step.Title)
+	if err != nil {
+		return "", err
+	}
+	body, err := ac.render(step.Body)
+	if err != nil {
+		return "", err
+	}
+	if title == "" {
+		title = ac.rule
+	}
+
+	urgency := service.UrgencyNormal
+	switch strings.ToLower(step.Urgency) {
+	case "low":
+		urgency = service.UrgencyLow
+	case "critical":
+		urgency = service.UrgencyCritical
+	}
+
+	description := fmt.Sprintf("notify %q", title)
+	if dryRun {
+		return description, nil
+	}
+	if e.notifier == nil {
+		return "", fmt.Errorf("no notifiers configured")
+	}
+	if err := e.notifier.Notify(title, body, urgency); err != nil {
+		return "", err
+	}
+	return description, nil
+}
+
+// taskChanged tells subscribers about a task a rule changed, fires the
+// events the change causes and lets later actions of the rule see it.
+func (e *ActionEngine) taskChanged(notificationType, eventType string, ac *actionContext, task *dto.TaskDto) {
+	if e.onTaskChanged != nil {
+		e.onTaskChanged(notificationType, task)
+	}
+	e.Dispatch(ActionEvent{Type: eventType, Task: task, Previous: ac.task, depth: ac.depth + 1})
+	ac.task = task
+}
+
+func (e *ActionEngine) board(ctx context.Context, ac *actionContext, boardID string) (*dto.BoardDetailDto, error) {
+	if board, ok := ac.boards[boardID]; ok {
+		return board, nil
+	}
+	board, err := e.backendClient.GetBoard(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	ac.boards[boardID] = board
+	return board, nil
+}
+
+func (e *ActionEngine) listAllTasks(ctx context.Context) ([]dto.TaskDto, error) {
+	var all []dto.TaskDto
+	for page := 1; page <= reminderMaxTaskPages; page++ {
+		tasks, err := e.backendClient.ListTasks(ctx, "", "", page, reminderTaskPageSize)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, tasks.Items...)
+		if len(tasks.Items) < reminderTaskPageSize || page*reminderTaskPageSize >= tasks.Total {
+			break
+		}
+	}
+	return all, nil
+}
+
+// Rules describes the configured rules, with when scheduled ones run next.
+func (e *ActionEngine) Rules() []ActionRuleInfo {
+	now := time.Now()
+	infos := make([]ActionRuleInfo, 0, len(e.rules))
+	for _, rule := range e.rules {
+		info := ActionRuleInfo{
+			Name:     rule.Name,
+			Trigger:  ruleTrigger(rule),
+			Disabled: rule.Disabled,
+		}
+		if rule.err != nil {
+			info.Error = rule.err.Error()
+		}
+		if rule.schedule != nil && !rule.Disabled {
+			if next := rule.schedule.Next(now); !next.IsZero() {
+				info.NextRun = &next
+			}
+		}
+		for _, cond := range rule.Conditions {
+			info.Conditions = append(info.Conditions, describeCondition(cond))
+		}
+		for _, step := range rule.Actions {
+			info.Actions = append(info.Actions, step.Type)
+		}
+		infos = append(infos, info)
+	}
+	return infos
+}
+
+// DryRun shows what the rules would do, without doing it. With a rule
+// name it evaluates that rule as if it fired now; with an event it
+// evaluates the rules for that event on a task, after applying Set to a
+// copy of the task as the change that caused the event.
+func (e *ActionEngine) DryRun(ctx context.Context, payload DryRunActionsPayload) ([]ActionPlan, error) {
+	var task, previous *dto.TaskDto
+	if payload.Task != "" {
+		var err error
+		if task, err = e.resolveTask(ctx, payload.Task); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(payload.Set) > 0 {
+		if task == nil {
+			return nil, fmt.Errorf("a task is needed to apply changes to")
+		}
+		changed, err := e.applyChanges(ctx, *task, payload.Set)
+		if err != nil {
+			return nil, err
+		}
+		previous, task = task, changed
+	}
+
+	if payload.Rule != "" {
+		rule := e.findRule(payload.Rule)
+		if rule == nil {
+			return nil, fmt.Errorf("no rule named %q", payload.Rule)
+		}
+		if rule.err != nil {
+			return nil, fmt.Errorf("rule %q is invalid: %v", rule.Name, rule.err)
+		}
+		if rule.schedule != nil && task == nil {
+			return e.evaluateSchedule(ctx, rule, true), nil
+		}
+		if task == nil {
+			return nil, fmt.Errorf("rule %q runs on %s events; give a task", rule.Name, rule.Trigger.Event)
+		}
+		ac := &actionContext{
+			event:    rule.Trigger.Event,
+			rule:     rule.Name,
+			task:     task,
+			previous: previous,
+			boards:   make(map[string]*dto.BoardDetailDto),
+		}
+		if ac.event == "" {
+			ac.event = ActionEventSchedule
+		}
+		return []ActionPlan{e.evaluateRule(ctx, rule, ac, true)}, nil
+	}
+
+	if !actionEvents[payload.Event] {
+		return nil, fmt.Errorf("unknown event %q", payload.Event)
+	}
+	if task == nil {
+		return nil, fmt.Errorf("event %s needs a task", payload.Event)
+	}
+	return e.evaluateEvent(ctx, ActionEvent{Type: payload.Event, Task: task, Previous: previous}, true), nil
+}
+
+func (e *ActionEngine) findRule(name string) *actionRule {
+	for _, rule := range e.rules {
+		if strings.EqualFold(rule.Name, name) {
+			return rule
+		}
+	}
+	return nil
+}
+
+// resolveTask reads a task by ID, or by a slug such as CAD-12 when time
+// tracking can resolve it.
+func (e *ActionEngine) resolveTask(ctx context.Context, ref string) (*dto.TaskDto, error) {
+	if e.timeTracking != nil {
+		taskID, _, err := e.timeTracking.ResolveTask(ctx, ref)
+		if err != nil {
+			return nil, err
+		}
+		ref = taskID
+	}
+	return e.backendClient.GetTask(ctx, ref)
+}
+
+// applyChanges returns a copy of a task with fields set as an update or
+// move would set them.
+func (e *ActionEngine) applyChanges(ctx context.Context, task dto.TaskDto, set map[string]string) (*dto.TaskDto, error) {
+	for key, value := range set {
+		v := value
+		switch camelField(key) {
+		case "title":
+			task.Title = v
+		case "description":
+			task.Description = &v
+		case "status":
+			task.Status = strings.ToUpper(v)
+		case "priority":
+			v = strings.ToUpper(v)
+			task.Priority = &v
+		case "dueDate":
+			task.DueDate = &v
+		case "column", "columnId":
+			board, err := e.backendClient.GetBoard(ctx, task.BoardID)
+			if err != nil {
+				return nil, err
+			}
+			found := false
+			for _, col := range board.Columns {
+				if col.ID == v || strings.EqualFold(col.Name, v) {
+					task.ColumnID = col.ID
+					task.Column = &dto.ColumnDto{ID: col.ID, Name: col.Name, BoardID: board.ID, WipLimit: col.WipLimit}
+					found = true
+					break
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("no column %q on board %s", v, board.Name)
+			}
+		default:
+			return nil, fmt.Errorf("cannot set field %q", key)
+		}
+	}
+	return &task, nil
+}
+
+func ruleTrigger(rule *actionRule) string {
+	if rule.Trigger.Schedule != "" {
+		return "schedule " + rule.Trigger.Schedule
+	}
+	return rule.Trigger.Event
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,36 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/config"
+)
+
+func TestRunScriptDoesNotTemplateTheCommand(t *testing.T) {
+	dir := t.TempDir()
+	e := NewActionEngine(&config.Config{Actions: config.ActionsConfig{ScriptsDir: dir}}, nil, nil, nil)
+	ac := &actionContext{
+		event: "task_moved",
+		rule:  "log moves",
+		task:  &dto.TaskDto{ID: "t1", Title: "$(touch injected)"},
+	}
+	step := config.ActionStep{Type: ActionScript, Command: `printf '%s|%s' "$TASK_TITLE" '{{.title}}' > out`}
+
+	if _, err := e.runScript(context.Background(), step, ac, false); err != nil {
+		t.Fatalf("runScript: %v", err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, "out"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "$(touch injected)|{{.title}}"; string(data) != want {
+		t.Errorf("script wrote %q, want %q", data, want)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "injected")); err == nil {
+		t.Error("the task title ran as a command")
+	}
+}
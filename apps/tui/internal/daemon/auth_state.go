@@ -0,0 +1,222 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/auth"
+	"cadence/internal/infrastructure/httpclient"
+)
+
+// authState tracks the signed-in account and whether connected clients have
+// been told that the session needs to be renewed.
+type authState struct {
+	mu           sync.Mutex
+	name         string
+	account      *dto.AuthSessionDto
+	expiresAt    time.Time
+	required     bool
+	reason       string
+	expiryTimer  *time.Timer
+	onExpiration func()
+}
+
+func (a *authState) snapshot() WhoAmIResponse {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.account == nil || a.required {
+		return WhoAmIResponse{Account: a.name, Authenticated: false, Reason: a.reason}
+	}
+
+	user := a.account.User
+	resp := WhoAmIResponse{Account: a.name, Authenticated: true, User: &user}
+	if !a.expiresAt.IsZero() {
+		expiresAt := a.expiresAt
+		resp.ExpiresAt = &expiresAt
+	}
+	return resp
+}
+
+// setAccount records a validated session and arms a timer for its expiry.
+func (a *authState) setAccount(account *dto.AuthSessionDto) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.account = account
+	a.required = false
+	a.reason = ""
+	a.expiresAt = time.Time{}
+	if a.expiryTimer != nil {
+		a.expiryTimer.Stop()
+		a.expiryTimer = nil
+	}
+
+	expiresAt, err := time.Parse(time.RFC3339, account.Session.ExpiresAt)
+	if err != nil {
+		return
+	}
+	a.expiresAt = expiresAt
+	a.expiryTimer = time.AfterFunc(time.Until(expiresAt), a.onExpiration)
+}
+
+func (a *authState) accountName() string {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.name
+}
+
+// reset forgets the validated session when switching to another account.
+func (a *authState) reset(name string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.name = name
+	a.account = nil
+	a.required = false
+	a.reason = ""
+	a.expiresAt = time.Time{}
+	if a.expiryTimer != nil {
+		a.expiryTimer.Stop()
+		a.expiryTimer = nil
+	}
+}
+
+// markRequired flags the session as unusable. It reports false if clients
+// were already told, so callers broadcast only once per expiry.
+func (a *authState) markRequired(reason string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.required {
+		return false
+	}
+	a.required = true
+	a.reason = reason
+	if a.expiryTimer != nil {
+		a.expiryTimer.Stop()
+		a.expiryTimer = nil
+	}
+	return true
+}
+
+// refreshSession asks the backend who the current token belongs to.
+func (s *Server) refreshSession(ctx context.Context) (WhoAmIResponse, error) {
+	account, err := s.backendClient.GetSession(ctx)
+	if err != nil {
+		var unauthorized *httpclient.UnauthorizedError
+		if errors.As(err, &unauthorized) {
+			s.requireAuth("not signed in or session expired")
+			return s.auth.snapshot(), nil
+		}
+		return WhoAmIResponse{}, err
+	}
+
+	s.auth.setAccount(account)
+	return s.auth.snapshot(), nil
+}
+
+// requireAuth tells every subscribed client that the user has to log in
+// again.
+func (s *Server) requireAuth(reason string) {
+	if !s.auth.markRequired(reason) {
+		return
+	}
+
+	fmt.Printf("[Auth] %s\n", reason)
+	s.broadcast(&Notification{
+		Type: NotificationAuthRequired,
+		Data: map[string]string{"reason": reason},
+	})
+}
+
+// credentials is the token the daemon authenticates with. scheme is set
+// only for API tokens.
+type credentials struct {
+	account string
+	token   string
+	scheme  string
+}
+
+// loadCredentials prefers a configured API token, so CI machines never
+// need a login, and otherwise reads the active account's session token.
+// A missing session token is not an error; token is left empty.
+func (s *Server) loadCredentials() (credentials, error) {
+	apiToken, err := auth.APIToken(s.config)
+	if err != nil {
+		return credentials{}, fmt.Errorf("failed to load API token: %w", err)
+	}
+	if apiToken != "" {
+		return credentials{
+			account: auth.APITokenAccount,
+			token:   apiToken,
+			scheme:  s.config.Backend.APITokenScheme,
+		}, nil
+	}
+
+	account, err := s.accounts.Active()
+	if err != nil {
+		return credentials{}, err
+	}
+
+	tokenStore, err := s.accounts.Store(account)
+	if err != nil {
+		return credentials{}, fmt.Errorf("failed to create token store: %w", err)
+	}
+
+	creds := credentials{account: account}
+	if tokenStore.Exists() {
+		if creds.token, err = tokenStore.Load(); err != nil {
+			return credentials{}, fmt.Errorf("failed to load token: %w", err)
+		}
+	}
+	return creds, nil
+}
+
+func (s *Server) applyCredentials(creds credentials) error {
+	if creds.account == auth.APITokenAccount {
+		return s.backendClient.SetAPIToken(creds.token, creds.scheme)
+	}
+	s.backendClient.SetAuthToken(creds.token)
+	return nil
+}
+
+// switchAccount moves the daemon to another account's token. Running timers
+// are flushed to the old account first and cached responses are dropped.
+// Subscribers are told to reload and then disconnected, since the boards
+// they were watching belong to the previous account.
+func (s *Server) switchAccount(ctx context.Context, creds credentials) error {
+	previous := s.auth.accountName()
+
+	if s.timeTrackingManager != nil {
+		s.timeTrackingManager.StopAll(ctx)
+	}
+
+	if err := s.applyCredentials(creds); err != nil {
+		return err
+	}
+	if err := s.backendClient.ClearCache(); err != nil {
+		fmt.Printf("[Auth] Failed to clear HTTP cache: %v\n", err)
+	}
+	s.auth.reset(creds.account)
+
+	fmt.Printf("[Auth] Switched account %s -> %s\n", previous, creds.account)
+	s.broadcast(&Notification{
+		Type: NotificationAccountSwitched,
+		Data: map[string]string{"account": creds.account},
+	})
+	s.dropSubscribers()
+	return nil
+}
+
+func (s *Server) handleWhoAmI(ctx context.Context) *Response {
+	whoami, err := s.refreshSession(ctx)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	return &Response{Success: true, Data: whoami}
+}
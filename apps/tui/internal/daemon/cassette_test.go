@@ -0,0 +1,74 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/domain/entity"
+	"cadence/internal/infrastructure/fakebackend"
+	"cadence/internal/infrastructure/httpclient"
+)
+
+// logTimeTwice logs half an hour on a task and then an overlapping quarter,
+// which must be refused.
+func logTimeTwice(t *testing.T, tm *TimeTrackingManager, client *httpclient.BackendClient) *dto.TimeLogDto {
+	t.Helper()
+	ctx := context.Background()
+	task := firstTask(t, client)
+	start := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	created, err := tm.LogTime(ctx, task.ProjectID, task.ID, start, 30*time.Minute, "Review", false)
+	if err != nil {
+		t.Fatalf("LogTime: %v", err)
+	}
+	if created.Duration == nil || *created.Duration != 30 {
+		t.Errorf("logged %+v, want 30 minutes", created)
+	}
+
+	_, err = tm.LogTime(ctx, task.ProjectID, task.ID, start.Add(15*time.Minute), 15*time.Minute, "Review", false)
+	if !errors.Is(err, entity.ErrTimeLogOverlap) {
+		t.Errorf("overlapping LogTime error = %v, want %v", err, entity.ErrTimeLogOverlap)
+	}
+	return created
+}
+
+func TestTimeLogsReplayFromCassette(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	backend := fakebackend.New()
+	backend.Seed()
+	recorder := httpclient.NewRecordingTransport(backend.Transport(), path)
+	tm, client := newFakeTimeTracking(recorder)
+	recorded := logTimeTwice(t, tm, client)
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("saving the cassette: %v", err)
+	}
+
+	cassette, err := httpclient.LoadCassette(path)
+	if err != nil {
+		t.Fatalf("LoadCassette: %v", err)
+	}
+	if len(cassette.Interactions) == 0 {
+		t.Fatal("nothing was recorded")
+	}
+
+	// The same calls are answered from the cassette alone
+	tm, client = newFakeTimeTracking(httpclient.NewReplayTransport(cassette))
+	replayed := logTimeTwice(t, tm, client)
+	if replayed.ID != recorded.ID {
+		t.Errorf("replayed log %s, recorded %s", replayed.ID, recorded.ID)
+	}
+
+	// Each interaction is served once, and a request that differs is refused
+	if _, err := client.ListProjects(context.Background(), 1, 50); err == nil {
+		t.Error("a second ListProjects was served from the cassette, want an error")
+	}
+	if _, err := tm.LogTime(context.Background(), *replayed.ProjectID, *replayed.TaskID,
+		time.Date(2026, 1, 6, 9, 0, 0, 0, time.UTC), time.Hour, "Other", true); err == nil {
+		t.Error("LogTime with no recorded interaction succeeded, want an error")
+	}
+}
@@ -14,6 +14,7 @@ import (
 
 	"cadence/internal/application/dto"
 	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/tracing"
 )
 
 type Client struct {
@@ -73,7 +74,12 @@ func (c *Client) startDaemon() error {
 		}
 	}
 
-	cmd := exec.Command(daemonPath)
+	var args []string
+	if c.config.Profile != "" {
+		args = append(args, "--profile", c.config.Profile)
+	}
+
+	cmd := exec.Command(daemonPath, args...)
 	cmd.Stdout = nil
 	cmd.Stderr = nil
 	cmd.Stdin = nil
@@ -93,7 +99,14 @@ func (c *Client) Close() error {
 	return c.Unsubscribe()
 }
 
-func (c *Client) sendRequest(req *Request) (*Response, error) {
+func (c *Client) sendRequest(ctx context.Context, req *Request) (*Response, error) {
+	if req.RequestID == "" {
+		req.RequestID = tracing.RequestIDFromContext(ctx)
+	}
+	if req.RequestID == "" {
+		req.RequestID = tracing.NewRequestID()
+	}
+
 	socketPath := GetSocketPath(c.config)
 
 	conn, err := net.DialTimeout("unix", socketPath, 2*time.Second)
@@ -135,14 +148,14 @@ func (c *Client) sendRequest(req *Request) (*Response, error) {
 	}
 
 	if !resp.Success {
-		return nil, fmt.Errorf("daemon error: %s", resp.Error)
+		return nil, fmt.Errorf("daemon error: %s (request %s)", resp.Error, req.RequestID)
 	}
 
 	return &resp, nil
 }
 
 func (c *Client) GetBoard(ctx context.Context, boardID string) (*dto.BoardDetailDto, error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type:    RequestGetBoard,
 		Payload: GetBoardPayload{BoardID: boardID},
 	})
@@ -159,7 +172,7 @@ func (c *Client) GetBoard(ctx context.Context, boardID string) (*dto.BoardDetail
 }
 
 func (c *Client) ListBoards(ctx context.Context) (*dto.PaginatedResponse[dto.BoardDto], error) {
-	resp, err := c.sendRequest(&Request{Type: RequestListBoards})
+	resp, err := c.sendRequest(ctx, &Request{Type: RequestListBoards})
 	if err != nil {
 		return nil, err
 	}
@@ -178,7 +191,7 @@ func (c *Client) GetActiveBoard(ctx context.Context) (string, error) {
 		payload.SessionName = sessionName
 	}
 
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type:    RequestGetActiveBoard,
 		Payload: payload,
 	})
@@ -209,7 +222,7 @@ func getCurrentTmuxSession() string {
 }
 
 func (c *Client) CreateBoard(ctx context.Context, projectID, name, description string) (*dto.BoardDto, error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type: RequestCreateBoard,
 		Payload: CreateBoardPayload{
 			ProjectID:   projectID,
@@ -230,7 +243,7 @@ func (c *Client) CreateBoard(ctx context.Context, projectID, name, description s
 }
 
 func (c *Client) ListTasks(ctx context.Context, columnID string, page, limit int) (*dto.PaginatedResponse[dto.TaskDto], error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type: RequestListTasks,
 		Payload: ListTasksPayload{
 			ColumnID: columnID,
@@ -251,7 +264,7 @@ func (c *Client) ListTasks(ctx context.Context, columnID string, page, limit int
 }
 
 func (c *Client) CreateTask(ctx context.Context, title, description, priority, columnID string) (*dto.TaskDto, error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type: RequestAddTask,
 		Payload: AddTaskPayload{
 			Title:       title,
@@ -273,7 +286,7 @@ func (c *Client) CreateTask(ctx context.Context, title, description, priority, c
 }
 
 func (c *Client) MoveTask(ctx context.Context, taskID, targetColumnID string) (*dto.TaskDto, error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type: RequestMoveTask,
 		Payload: MoveTaskPayload{
 			TaskID:         taskID,
@@ -293,7 +306,7 @@ func (c *Client) MoveTask(ctx context.Context, taskID, targetColumnID string) (*
 }
 
 func (c *Client) UpdateTask(ctx context.Context, taskID string, fields map[string]interface{}) (*dto.TaskDto, error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type: RequestUpdateTask,
 		Payload: UpdateTaskPayload{
 			TaskID: taskID,
@@ -313,7 +326,7 @@ func (c *Client) UpdateTask(ctx context.Context, taskID string, fields map[strin
 }
 
 func (c *Client) DeleteTask(ctx context.Context, taskID string) error {
-	_, err := c.sendRequest(&Request{
+	_, err := c.sendRequest(ctx, &Request{
 		Type:    RequestDeleteTask,
 		Payload: DeleteTaskPayload{TaskID: taskID},
 	})
@@ -321,7 +334,7 @@ func (c *Client) DeleteTask(ctx context.Context, taskID string) error {
 }
 
 func (c *Client) CreateColumn(ctx context.Context, boardID, name string) error {
-	_, err := c.sendRequest(&Request{
+	_, err := c.sendRequest(ctx, &Request{
 		Type: RequestAddColumn,
 		Payload: AddColumnPayload{
 			BoardID: boardID,
@@ -332,7 +345,7 @@ func (c *Client) CreateColumn(ctx context.Context, boardID, name string) error {
 }
 
 func (c *Client) DeleteColumn(ctx context.Context, boardID, columnID string) error {
-	_, err := c.sendRequest(&Request{
+	_, err := c.sendRequest(ctx, &Request{
 		Type: RequestDeleteColumn,
 		Payload: DeleteColumnPayload{
 			BoardID:  boardID,
@@ -343,7 +356,7 @@ func (c *Client) DeleteColumn(ctx context.Context, boardID, columnID string) err
 }
 
 func (c *Client) ListNotes(ctx context.Context, projectID, noteType string) ([]dto.NoteDto, error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type: RequestListNotes,
 		Payload: ListNotesPayload{
 			ProjectID: projectID,
@@ -363,7 +376,7 @@ func (c *Client) ListNotes(ctx context.Context, projectID, noteType string) ([]d
 }
 
 func (c *Client) GetNote(ctx context.Context, noteID string) (*dto.NoteDto, error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type:    RequestGetNote,
 		Payload: GetNotePayload{NoteID: noteID},
 	})
@@ -380,7 +393,7 @@ func (c *Client) GetNote(ctx context.Context, noteID string) (*dto.NoteDto, erro
 }
 
 func (c *Client) CreateNote(ctx context.Context, noteType, title, content string, tags []string) (*dto.NoteDto, error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type: RequestCreateNote,
 		Payload: CreateNotePayload{
 			Type:    noteType,
@@ -402,7 +415,7 @@ func (c *Client) CreateNote(ctx context.Context, noteType, title, content string
 }
 
 func (c *Client) UpdateNote(ctx context.Context, noteID string, title, content *string, tags []string) (*dto.NoteDto, error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type: RequestUpdateNote,
 		Payload: UpdateNotePayload{
 			NoteID:  noteID,
@@ -424,15 +437,15 @@ func (c *Client) UpdateNote(ctx context.Context, noteID string, title, content *
 }
 
 func (c *Client) DeleteNote(ctx context.Context, noteID string) error {
-	_, err := c.sendRequest(&Request{
+	_, err := c.sendRequest(ctx, &Request{
 		Type:    RequestDeleteNote,
 		Payload: DeleteNotePayload{NoteID: noteID},
 	})
 	return err
 }
 
-func (c *Client) GetAgendaView(ctx context.Context, mode, anchorDate, timezone string) (json.RawMessage, error) {
-	resp, err := c.sendRequest(&Request{
+func (c *Client) GetAgendaView(ctx context.Context, mode, anchorDate, timezone string) (*dto.AgendaViewDto, error) {
+	resp, err := c.sendRequest(ctx, &Request{
 		Type: RequestGetAgendaView,
 		Payload: GetAgendaViewPayload{
 			Mode:       mode,
@@ -444,16 +457,16 @@ func (c *Client) GetAgendaView(ctx context.Context, mode, anchorDate, timezone s
 		return nil, err
 	}
 
-	data, err := json.Marshal(resp.Data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal response data: %w", err)
+	var view dto.AgendaViewDto
+	if err := c.decodeResponseData(resp.Data, &view); err != nil {
+		return nil, err
 	}
 
-	return data, nil
+	return &view, nil
 }
 
 func (c *Client) StartTimer(ctx context.Context, projectID, taskID, description string) (*Response, error) {
-	return c.sendRequest(&Request{
+	return c.sendRequest(ctx, &Request{
 		Type: RequestStartTimer,
 		Payload: StartTimerPayload{
 			ProjectID:   projectID,
@@ -464,7 +477,7 @@ func (c *Client) StartTimer(ctx context.Context, projectID, taskID, description
 }
 
 func (c *Client) StopTimer(ctx context.Context, projectID, taskID string) (*Response, error) {
-	return c.sendRequest(&Request{
+	return c.sendRequest(ctx, &Request{
 		Type: RequestStopTimer,
 		Payload: StopTimerPayload{
 			ProjectID: projectID,
@@ -473,12 +486,229 @@ func (c *Client) StopTimer(ctx context.Context, projectID, taskID string) (*Resp
 	})
 }
 
+// StopTimerByRef stops the timer picked by ref, which may name it by ID.
+func (c *Client) StopTimerByRef(ctx context.Context, ref TimerPayload) (*Response, error) {
+	return c.sendRequest(ctx, &Request{
+		Type: RequestStopTimer,
+		Payload: StopTimerPayload{
+			TimerID:   ref.TimerID,
+			ProjectID: ref.ProjectID,
+			TaskID:    ref.TaskID,
+		},
+	})
+}
+
+// LogTime records time after the fact and returns the created log.
+func (c *Client) LogTime(ctx context.Context, payload LogTimePayload) (*dto.TimeLogDto, error) {
+	resp, err := c.sendRequest(ctx, &Request{Type: RequestLogTime, Payload: payload})
+	if err != nil {
+		return nil, err
+	}
+
+	var log dto.TimeLogDto
+	if err := c.decodeResponseData(resp.Data, &log); err != nil {
+		return nil, err
+	}
+
+	return &log, nil
+}
+
+func (c *Client) PauseTimer(ctx context.Context, ref TimerPayload) (*Response, error) {
+	return c.sendRequest(ctx, &Request{Type: RequestPauseTimer, Payload: ref})
+}
+
+func (c *Client) ResumeTimer(ctx context.Context, ref TimerPayload) (*Response, error) {
+	return c.sendRequest(ctx, &Request{Type: RequestResumeTimer, Payload: ref})
+}
+
+// UpdateTimer changes the description and/or task of a running timer.
+func (c *Client) UpdateTimer(ctx context.Context, ref TimerPayload, description, taskID *string) (*Response, error) {
+	return c.sendRequest(ctx, &Request{
+		Type: RequestUpdateTimer,
+		Payload: UpdateTimerPayload{
+			TimerPayload: ref,
+			Description:  description,
+			NewTaskID:    taskID,
+		},
+	})
+}
+
+// DiscardTimer drops a running timer without logging its time.
+func (c *Client) DiscardTimer(ctx context.Context, ref TimerPayload) (*Response, error) {
+	return c.sendRequest(ctx, &Request{Type: RequestDiscardTimer, Payload: ref})
+}
+
 func (c *Client) GetActiveTimers(ctx context.Context) (*Response, error) {
-	return c.sendRequest(&Request{Type: RequestGetActiveTimers})
+	return c.sendRequest(ctx, &Request{Type: RequestGetActiveTimers})
+}
+
+// GetIdlePeriods returns idle time cut from auto-tracked timers that is
+// waiting for a keep or discard decision.
+func (c *Client) GetIdlePeriods(ctx context.Context) ([]IdlePeriod, error) {
+	resp, err := c.sendRequest(ctx, &Request{Type: RequestGetIdlePeriods})
+	if err != nil {
+		return nil, err
+	}
+
+	var periods []IdlePeriod
+	if err := c.decodeResponseData(resp.Data, &periods); err != nil {
+		return nil, err
+	}
+
+	return periods, nil
+}
+
+func (c *Client) ResolveIdlePeriod(ctx context.Context, id string, keep bool) error {
+	_, err := c.sendRequest(ctx, &Request{
+		Type:    RequestResolveIdle,
+		Payload: ResolveIdlePeriodPayload{ID: id, Keep: keep},
+	})
+	return err
+}
+
+// GetProjectPrompts returns the projects and boards the daemon is waiting
+// to create until the user confirms.
+func (c *Client) GetProjectPrompts(ctx context.Context) ([]ProjectPrompt, error) {
+	resp, err := c.sendRequest(ctx, &Request{Type: RequestGetProjectPrompts})
+	if err != nil {
+		return nil, err
+	}
+
+	var prompts []ProjectPrompt
+	if err := c.decodeResponseData(resp.Data, &prompts); err != nil {
+		return nil, err
+	}
+
+	return prompts, nil
+}
+
+func (c *Client) ResolveProjectPrompt(ctx context.Context, id string, create bool) error {
+	_, err := c.sendRequest(ctx, &Request{
+		Type:    RequestResolveProjectPrompt,
+		Payload: ResolveProjectPromptPayload{ID: id, Create: create},
+	})
+	return err
+}
+
+func (c *Client) StartFocus(ctx context.Context, payload StartFocusPayload) (*FocusStatus, error) {
+	return c.focusRequest(ctx, &Request{Type: RequestStartFocus, Payload: payload})
+}
+
+func (c *Client) StopFocus(ctx context.Context) (*FocusStatus, error) {
+	return c.focusRequest(ctx, &Request{Type: RequestStopFocus})
+}
+
+func (c *Client) SkipFocusPhase(ctx context.Context) (*FocusStatus, error) {
+	return c.focusRequest(ctx, &Request{Type: RequestSkipFocusPhase})
+}
+
+// GetFocusStatus returns the running focus session, or nil if there is none.
+func (c *Client) GetFocusStatus(ctx context.Context) (*FocusStatus, error) {
+	return c.focusRequest(ctx, &Request{Type: RequestGetFocusStatus})
+}
+
+func (c *Client) focusRequest(ctx context.Context, req *Request) (*FocusStatus, error) {
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Data == nil {
+		return nil, nil
+	}
+
+	var status FocusStatus
+	if err := c.decodeResponseData(resp.Data, &status); err != nil {
+		return nil, err
+	}
+
+	return &status, nil
+}
+
+func (c *Client) ListReminders(ctx context.Context) ([]Reminder, error) {
+	resp, err := c.sendRequest(ctx, &Request{Type: RequestListReminders})
+	if err != nil {
+		return nil, err
+	}
+
+	var reminders []Reminder
+	if err := c.decodeResponseData(resp.Data, &reminders); err != nil {
+		return nil, err
+	}
+
+	return reminders, nil
+}
+
+// SnoozeReminder rings a reminder again after d; zero uses the configured
+// snooze.
+func (c *Client) SnoozeReminder(ctx context.Context, id string, d time.Duration) (*Reminder, error) {
+	return c.reminderRequest(ctx, &Request{
+		Type:    RequestSnoozeReminder,
+		Payload: SnoozeReminderPayload{ID: id, Minutes: int(d / time.Minute)},
+	})
+}
+
+func (c *Client) DismissReminder(ctx context.Context, id string) (*Reminder, error) {
+	return c.reminderRequest(ctx, &Request{
+		Type:    RequestDismissReminder,
+		Payload: DismissReminderPayload{ID: id},
+	})
+}
+
+func (c *Client) TestNotification(ctx context.Context) error {
+	_, err := c.sendRequest(ctx, &Request{Type: RequestTestNotification})
+	return err
+}
+
+func (c *Client) reminderRequest(ctx context.Context, req *Request) (*Reminder, error) {
+	resp, err := c.sendRequest(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	var reminder Reminder
+	if err := c.decodeResponseData(resp.Data, &reminder); err != nil {
+		return nil, err
+	}
+
+	return &reminder, nil
+}
+
+func (c *Client) ListActionRules(ctx context.Context) ([]ActionRuleInfo, error) {
+	resp, err := c.sendRequest(ctx, &Request{Type: RequestListActionRules})
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []ActionRuleInfo
+	if err := c.decodeResponseData(resp.Data, &rules); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
+
+// DryRunActions returns what the rules would do, without doing it.
+func (c *Client) DryRunActions(ctx context.Context, payload DryRunActionsPayload) ([]ActionPlan, error) {
+	resp, err := c.sendRequest(ctx, &Request{Type: RequestDryRunActions, Payload: payload})
+	if err != nil {
+		return nil, err
+	}
+
+	var plans []ActionPlan
+	if err := c.decodeResponseData(resp.Data, &plans); err != nil {
+		return nil, err
+	}
+
+	return plans, nil
+}
+
+func (c *Client) ReportShell(ctx context.Context, payload ReportShellPayload) error {
+	_, err := c.sendRequest(ctx, &Request{Type: RequestReportShell, Payload: payload})
+	return err
 }
 
 func (c *Client) ListProjects(ctx context.Context) (*Response, error) {
-	return c.sendRequest(&Request{Type: RequestListProjects})
+	return c.sendRequest(ctx, &Request{Type: RequestListProjects})
 }
 
 func (c *Client) IsHealthy() bool {
@@ -602,7 +832,13 @@ func (c *Client) Notifications() <-chan *Notification {
 }
 
 func (c *Client) SendRequest(reqType string, payload interface{}) (*Response, error) {
-	return c.sendRequest(&Request{
+	return c.SendRequestContext(context.Background(), reqType, payload)
+}
+
+// SendRequestContext is SendRequest with the correlation ID taken from ctx,
+// so several requests made for one user action share the same ID.
+func (c *Client) SendRequestContext(ctx context.Context, reqType string, payload interface{}) (*Response, error) {
+	return c.sendRequest(ctx, &Request{
 		Type:    reqType,
 		Payload: payload,
 	})
@@ -622,7 +858,7 @@ func (c *Client) decodeResponseData(data interface{}, target interface{}) error
 }
 
 func (c *Client) ListProjectsTyped(ctx context.Context) (*dto.PaginatedResponse[dto.ProjectDto], error) {
-	resp, err := c.sendRequest(&Request{Type: RequestListProjects})
+	resp, err := c.sendRequest(ctx, &Request{Type: RequestListProjects})
 	if err != nil {
 		return nil, err
 	}
@@ -636,7 +872,7 @@ func (c *Client) ListProjectsTyped(ctx context.Context) (*dto.PaginatedResponse[
 }
 
 func (c *Client) GetProject(ctx context.Context, projectID string) (*dto.ProjectDto, error) {
-	resp, err := c.sendRequest(&Request{
+	resp, err := c.sendRequest(ctx, &Request{
 		Type:    RequestGetProject,
 		Payload: GetProjectPayload{ProjectID: projectID},
 	})
@@ -651,3 +887,91 @@ func (c *Client) GetProject(ctx context.Context, projectID string) (*dto.Project
 
 	return &project, nil
 }
+
+func (c *Client) GetTask(ctx context.Context, taskID string) (*dto.TaskDto, error) {
+	resp, err := c.sendRequest(ctx, &Request{
+		Type:    RequestGetTask,
+		Payload: GetTaskPayload{TaskID: taskID},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var task dto.TaskDto
+	if err := c.decodeResponseData(resp.Data, &task); err != nil {
+		return nil, err
+	}
+
+	return &task, nil
+}
+
+// GetTimeSummary returns the minutes logged per project between start and
+// end; with projectID set, only that project's total.
+func (c *Client) GetTimeSummary(ctx context.Context, projectID string, start, end time.Time) ([]dto.TimeSummaryDto, error) {
+	resp, err := c.sendRequest(ctx, &Request{
+		Type:    RequestGetTimeSummary,
+		Payload: TimeSummaryPayload{ProjectID: projectID, Start: start, End: end},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var summary []dto.TimeSummaryDto
+	if err := c.decodeResponseData(resp.Data, &summary); err != nil {
+		return nil, err
+	}
+
+	return summary, nil
+}
+
+// GetDailyTimeLogs returns the logs the backend files under date
+// (YYYY-MM-DD).
+func (c *Client) GetDailyTimeLogs(ctx context.Context, date string) ([]dto.TimeLogRecordDto, error) {
+	resp, err := c.sendRequest(ctx, &Request{
+		Type:    RequestGetDailyLogs,
+		Payload: DailyTimeLogsPayload{Date: date},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var logs []dto.TimeLogRecordDto
+	if err := c.decodeResponseData(resp.Data, &logs); err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
+
+func (c *Client) GetTraces(ctx context.Context) (*TracesResponse, error) {
+	resp, err := c.sendRequest(ctx, &Request{Type: RequestGetTraces})
+	if err != nil {
+		return nil, err
+	}
+
+	var traces TracesResponse
+	if err := c.decodeResponseData(resp.Data, &traces); err != nil {
+		return nil, err
+	}
+
+	return &traces, nil
+}
+
+func (c *Client) WhoAmI(ctx context.Context) (*WhoAmIResponse, error) {
+	resp, err := c.sendRequest(ctx, &Request{Type: RequestWhoAmI})
+	if err != nil {
+		return nil, err
+	}
+
+	var whoami WhoAmIResponse
+	if err := c.decodeResponseData(resp.Data, &whoami); err != nil {
+		return nil, err
+	}
+
+	return &whoami, nil
+}
+
+func (c *Client) ReloadToken(ctx context.Context) error {
+	_, err := c.sendRequest(ctx, &Request{Type: RequestReloadToken})
+	return err
+}
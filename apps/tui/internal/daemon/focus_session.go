@@ -0,0 +1,336 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"cadence/internal/domain/entity"
+	"cadence/internal/infrastructure/config"
+)
+
+const (
+	FocusPhaseWork       = "work"
+	FocusPhaseShortBreak = "short_break"
+	FocusPhaseLongBreak  = "long_break"
+)
+
+// FocusOptions are the phase lengths of a focus session. Zero fields take
+// the configured defaults.
+type FocusOptions struct {
+	Work           time.Duration
+	ShortBreak     time.Duration
+	LongBreak      time.Duration
+	LongBreakEvery int
+	Cycles         int
+}
+
+// focusOptions fills unset options from the config, and the config's unset
+// fields from the classic 25/5/15 pomodoro.
+func focusOptions(opts FocusOptions, cfg config.FocusConfig) FocusOptions {
+	minutes := func(d time.Duration, configured, fallback int) time.Duration {
+		if d > 0 {
+			return d
+		}
+		if configured > 0 {
+			return time.Duration(configured) * time.Minute
+		}
+		return time.Duration(fallback) * time.Minute
+	}
+
+	opts.Work = minutes(opts.Work, cfg.Work, 25)
+	opts.ShortBreak = minutes(opts.ShortBreak, cfg.ShortBreak, 5)
+	opts.LongBreak = minutes(opts.LongBreak, cfg.LongBreak, 15)
+	if opts.LongBreakEvery <= 0 {
+		opts.LongBreakEvery = cfg.LongBreakEvery
+	}
+	if opts.LongBreakEvery <= 0 {
+		opts.LongBreakEvery = 4
+	}
+	if opts.Cycles <= 0 {
+		opts.Cycles = cfg.Cycles
+	}
+	return opts
+}
+
+// focusSession alternates a manual timer between work phases and breaks.
+// The timer is paused during breaks, which are recorded on the log, so the
+// whole session is one backend entry.
+type focusSession struct {
+	id         string
+	log        *entity.TimeLog
+	opts       FocusOptions
+	phase      string
+	phaseStart time.Time
+	phaseEnd   time.Time
+	completed  int
+	timer      *time.Timer
+}
+
+// SetFocusNotifier sets the function told about every focus phase change.
+// It is called with the manager unlocked.
+func (tm *TimeTrackingManager) SetFocusNotifier(notify func(FocusStatus)) {
+	tm.focusNotifier = notify
+}
+
+// StartFocus starts a focus session on a task. Without a project or task
+// it uses whatever auto-tracking currently attributes time to. A manual
+// timer already running for the task becomes the session's timer.
+func (tm *TimeTrackingManager) StartFocus(projectID, taskID, description string, opts FocusOptions) (*FocusStatus, error) {
+	tm.mu.Lock()
+
+	if tm.focus != nil {
+		tm.mu.Unlock()
+		return nil, entity.ErrFocusSessionRunning
+	}
+
+	if projectID == "" && taskID == "" {
+		projectID, taskID = tm.currentProjectID, tm.currentTaskID
+	}
+	if projectID == "" {
+		tm.mu.Unlock()
+		return nil, fmt.Errorf("no task given and none is being tracked")
+	}
+
+	now := time.Now()
+	key := timerKey(projectID, taskID)
+	log, ok := tm.activeTimers[key]
+	if ok && log.IsPaused() {
+		_ = log.Resume(now)
+	}
+	if !ok || !log.IsRunning() {
+		var err error
+		log, err = entity.NewTimeLog(uuid.New().String(), projectID, entity.TimeLogSourceTimer, now)
+		if err != nil {
+			tm.mu.Unlock()
+			return nil, err
+		}
+		if taskID != "" {
+			log.SetTaskID(taskID)
+		}
+		tm.activeTimers[key] = log
+	}
+	if description != "" {
+		log.SetDescription(description)
+	}
+	log.SetMetadata("focus", "true")
+
+	session := &focusSession{
+		id:   uuid.New().String(),
+		log:  log,
+		opts: focusOptions(opts, tm.config.TimeTracking.Focus),
+	}
+	tm.focus = session
+	tm.enterFocusPhaseLocked(session, FocusPhaseWork, now)
+
+	fmt.Printf("[TimeTrackingManager] Started focus session for %s (%s work)\n", key, session.opts.Work)
+	status := tm.focusStatusLocked(session)
+	tm.mu.Unlock()
+
+	tm.notifyFocus(status)
+	return &status, nil
+}
+
+// StopFocus ends the focus session and logs its timer.
+func (tm *TimeTrackingManager) StopFocus(ctx context.Context) (*FocusStatus, error) {
+	tm.mu.Lock()
+	session := tm.focus
+	if session == nil {
+		tm.mu.Unlock()
+		return nil, entity.ErrNoFocusSession
+	}
+	status, log := tm.finishFocusLocked(session, time.Now())
+	tm.mu.Unlock()
+
+	tm.focusChanged(ctx, status, log)
+	return &status, nil
+}
+
+// SkipFocusPhase ends the current phase early and moves to the next one.
+func (tm *TimeTrackingManager) SkipFocusPhase(ctx context.Context) (*FocusStatus, error) {
+	tm.mu.Lock()
+	session := tm.focus
+	if session == nil {
+		tm.mu.Unlock()
+		return nil, entity.ErrNoFocusSession
+	}
+	status, log := tm.advanceFocusLocked(session, time.Now())
+	tm.mu.Unlock()
+
+	tm.focusChanged(ctx, status, log)
+	return &status, nil
+}
+
+// FocusStatus returns the running focus session, or nil.
+func (tm *TimeTrackingManager) FocusStatus() *FocusStatus {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	if tm.focus == nil {
+		return nil
+	}
+	status := tm.focusStatusLocked(tm.focus)
+	return &status
+}
+
+// onFocusPhaseEnd runs when a phase's time is up.
+func (tm *TimeTrackingManager) onFocusPhaseEnd(session *focusSession) {
+	tm.mu.Lock()
+	if tm.focus != session {
+		tm.mu.Unlock()
+		return
+	}
+	status, log := tm.advanceFocusLocked(session, time.Now())
+	tm.mu.Unlock()
+
+	tm.focusChanged(context.Background(), status, log)
+}
+
+// advanceFocusLocked moves the session to its next phase. After the last
+// cycle it finishes the session and returns the log to send.
+func (tm *TimeTrackingManager) advanceFocusLocked(session *focusSession, now time.Time) (FocusStatus, *entity.TimeLog) {
+	if session.phase != FocusPhaseWork {
+		_ = session.log.AddBreak(session.phaseStart, now)
+		if session.log.IsPaused() {
+			_ = session.log.Resume(now)
+		}
+		tm.enterFocusPhaseLocked(session, FocusPhaseWork, now)
+		return tm.focusStatusLocked(session), nil
+	}
+
+	session.completed++
+	if session.opts.Cycles > 0 && session.completed >= session.opts.Cycles {
+		return tm.finishFocusLocked(session, now)
+	}
+
+	if !session.log.IsPaused() {
+		_ = session.log.Pause(now)
+	}
+	phase := FocusPhaseShortBreak
+	if session.completed%session.opts.LongBreakEvery == 0 {
+		phase = FocusPhaseLongBreak
+	}
+	tm.enterFocusPhaseLocked(session, phase, now)
+	return tm.focusStatusLocked(session), nil
+}
+
+func (tm *TimeTrackingManager) enterFocusPhaseLocked(session *focusSession, phase string, now time.Time) {
+	length := session.opts.Work
+	switch phase {
+	case FocusPhaseShortBreak:
+		length = session.opts.ShortBreak
+	case FocusPhaseLongBreak:
+		length = session.opts.LongBreak
+	}
+
+	session.phase = phase
+	session.phaseStart = now
+	session.phaseEnd = now.Add(length)
+
+	if session.timer != nil {
+		session.timer.Stop()
+	}
+	session.timer = time.AfterFunc(length, func() { tm.onFocusPhaseEnd(session) })
+
+	if session.completed > 0 || phase != FocusPhaseWork {
+		fmt.Printf("[TimeTrackingManager] Focus phase %s for %s\n", phase, length)
+	}
+}
+
+// finishFocusLocked stops the session's timer and returns it to be sent to
+// the backend once the manager is unlocked, or nil when it was not
+// running. A break in progress is recorded up to now.
+func (tm *TimeTrackingManager) finishFocusLocked(session *focusSession, now time.Time) (FocusStatus, *entity.TimeLog) {
+	if session.phase != FocusPhaseWork {
+		_ = session.log.AddBreak(session.phaseStart, now)
+	}
+	session.log.SetMetadata("focus_cycles", strconv.Itoa(session.completed))
+
+	status := tm.focusStatusLocked(session)
+	status.Cycle = session.completed
+	status.Finished = true
+	status.PhaseEnd = now
+
+	tm.dropFocusLocked(session.log)
+	var stopped *entity.TimeLog
+	if session.log.IsRunning() {
+		_ = session.log.Stop(now)
+		stopped = session.log
+	}
+	for key, timer := range tm.activeTimers {
+		if timer == session.log {
+			delete(tm.activeTimers, key)
+		}
+	}
+
+	fmt.Printf("[TimeTrackingManager] Finished focus session after %d cycles (%s worked)\n",
+		session.completed, session.log.Duration().Round(time.Second))
+	return status, stopped
+}
+
+// endFocusLocked ends the focus session when its timer is stopped or
+// discarded through the regular timer commands, which log it themselves.
+// It returns the status to notify once the manager is unlocked, or nil
+// when the timer is not the session's.
+func (tm *TimeTrackingManager) endFocusLocked(log *entity.TimeLog) *FocusStatus {
+	session := tm.focus
+	if session == nil || session.log != log {
+		return nil
+	}
+
+	status := tm.focusStatusLocked(session)
+	status.Cycle = session.completed
+	status.Finished = true
+	status.PhaseEnd = time.Now()
+	tm.dropFocusLocked(log)
+	return &status
+}
+
+func (tm *TimeTrackingManager) dropFocusLocked(log *entity.TimeLog) {
+	if tm.focus == nil || tm.focus.log != log {
+		return
+	}
+	if tm.focus.timer != nil {
+		tm.focus.timer.Stop()
+	}
+	tm.focus = nil
+}
+
+func (tm *TimeTrackingManager) focusStatusLocked(session *focusSession) FocusStatus {
+	cycle := session.completed
+	if session.phase == FocusPhaseWork {
+		cycle++
+	}
+	return FocusStatus{
+		ID:          session.id,
+		TimerID:     session.log.ID(),
+		ProjectID:   session.log.ProjectID(),
+		TaskID:      session.log.TaskID(),
+		Description: session.log.Description(),
+		Phase:       session.phase,
+		PhaseStart:  session.phaseStart,
+		PhaseEnd:    session.phaseEnd,
+		Cycle:       cycle,
+		Cycles:      session.opts.Cycles,
+		Worked:      int64(session.log.Duration() / time.Second),
+	}
+}
+
+// focusChanged sends a finished session's log, if any, and tells the
+// notifier. Like fireDue it runs unlocked, so a slow backend does not
+// hold up the manager.
+func (tm *TimeTrackingManager) focusChanged(ctx context.Context, status FocusStatus, log *entity.TimeLog) {
+	if log != nil {
+		tm.sendTimeLogToBackend(ctx, log)
+	}
+	tm.notifyFocus(status)
+}
+
+func (tm *TimeTrackingManager) notifyFocus(status FocusStatus) {
+	if tm.focusNotifier != nil {
+		tm.focusNotifier(status)
+	}
+}
@@ -0,0 +1,184 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"cadence/internal/domain/entity"
+	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/fakebackend"
+)
+
+func TestFocusOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		opts FocusOptions
+		cfg  config.FocusConfig
+		want FocusOptions
+	}{
+		{
+			name: "classic pomodoro",
+			want: FocusOptions{Work: 25 * time.Minute, ShortBreak: 5 * time.Minute, LongBreak: 15 * time.Minute, LongBreakEvery: 4},
+		},
+		{
+			name: "config",
+			cfg:  config.FocusConfig{Work: 50, ShortBreak: 10, LongBreak: 30, LongBreakEvery: 2, Cycles: 6},
+			want: FocusOptions{Work: 50 * time.Minute, ShortBreak: 10 * time.Minute, LongBreak: 30 * time.Minute, LongBreakEvery: 2, Cycles: 6},
+		},
+		{
+			name: "options over config",
+			opts: FocusOptions{Work: 45 * time.Minute, Cycles: 1},
+			cfg:  config.FocusConfig{Work: 50, ShortBreak: 10, Cycles: 6},
+			want: FocusOptions{Work: 45 * time.Minute, ShortBreak: 10 * time.Minute, LongBreak: 15 * time.Minute, LongBreakEvery: 4, Cycles: 1},
+		},
+	}
+	for _, tt := range tests {
+		if got := focusOptions(tt.opts, tt.cfg); got != tt.want {
+			t.Errorf("%s: focusOptions = %+v, want %+v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestFocusSessionCycles(t *testing.T) {
+	backend := fakebackend.New()
+	backend.Seed()
+	posted := &postedTimeLogs{next: backend.Transport()}
+	tm, client := newFakeTimeTracking(posted)
+	ctx := context.Background()
+	task := firstTask(t, client)
+
+	var phases []string
+	tm.SetFocusNotifier(func(status FocusStatus) {
+		phase := status.Phase
+		if status.Finished {
+			phase = "finished"
+		}
+		phases = append(phases, fmt.Sprintf("%s %d", phase, status.Cycle))
+	})
+
+	if _, err := tm.SkipFocusPhase(ctx); !errors.Is(err, entity.ErrNoFocusSession) {
+		t.Errorf("SkipFocusPhase without a session error = %v, want %v", err, entity.ErrNoFocusSession)
+	}
+
+	// Phases are an hour long, so only skipping moves the session on
+	opts := FocusOptions{Work: time.Hour, ShortBreak: time.Hour, LongBreak: time.Hour, LongBreakEvery: 2, Cycles: 3}
+	if _, err := tm.StartFocus(task.ProjectID, task.ID, "Deep work", opts); err != nil {
+		t.Fatalf("StartFocus: %v", err)
+	}
+	if _, err := tm.StartFocus(task.ProjectID, task.ID, "", opts); !errors.Is(err, entity.ErrFocusSessionRunning) {
+		t.Errorf("second StartFocus error = %v, want %v", err, entity.ErrFocusSessionRunning)
+	}
+	for i := 0; i < 5; i++ {
+		time.Sleep(2 * time.Millisecond)
+		if _, err := tm.SkipFocusPhase(ctx); err != nil {
+			t.Fatalf("SkipFocusPhase %d: %v", i+1, err)
+		}
+	}
+
+	want := "work 1, short_break 1, work 2, long_break 2, work 3, finished 3"
+	if got := strings.Join(phases, ", "); got != want {
+		t.Errorf("phases = %s, want %s", got, want)
+	}
+	if tm.FocusStatus() != nil {
+		t.Error("the session is still running after its last cycle")
+	}
+	if timers := tm.GetActiveTimers(); len(timers) != 0 {
+		t.Errorf("%d timers still active after the session", len(timers))
+	}
+
+	logs := posted.sent()
+	if len(logs) != 1 {
+		t.Fatalf("sent %d time logs, want the session as one", len(logs))
+	}
+	log := logs[0]
+	if log.TaskID == nil || *log.TaskID != task.ID || log.Source != entity.TimeLogSourceTimer.String() {
+		t.Errorf("sent %+v, want a timer log for task %s", log, task.ID)
+	}
+	if log.Metadata["focus"] != "true" || log.Metadata["focus_cycles"] != "3" || log.Metadata["description"] != "Deep work" {
+		t.Errorf("metadata = %v, want the focus session and its description", log.Metadata)
+	}
+	if breaks := log.Metadata["breaks"]; strings.Count(breaks, ",") != 1 {
+		t.Errorf("breaks = %q, want the short and the long break", breaks)
+	}
+}
+
+// blockingTimeLogs holds every time log sent to the backend until release
+// is closed.
+type blockingTimeLogs struct {
+	next    http.RoundTripper
+	sending chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingTimeLogs) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost && req.URL.Path == "/time-logs" {
+		b.sending <- struct{}{}
+		<-b.release
+	}
+	return b.next.RoundTrip(req)
+}
+
+func TestFocusSessionLogsWithoutHoldingTheManager(t *testing.T) {
+	tests := []struct {
+		name string
+		end  func(tm *TimeTrackingManager) error
+	}{
+		{"last cycle", func(tm *TimeTrackingManager) error {
+			_, err := tm.SkipFocusPhase(context.Background())
+			return err
+		}},
+		{"stop focus", func(tm *TimeTrackingManager) error {
+			_, err := tm.StopFocus(context.Background())
+			return err
+		}},
+		{"stop timer", func(tm *TimeTrackingManager) error {
+			_, err := tm.StopTimer(context.Background(), TimerRef{})
+			return err
+		}},
+	}
+
+	for _, tt := range tests {
+		backend := fakebackend.New()
+		backend.Seed()
+		blocking := &blockingTimeLogs{next: backend.Transport(), sending: make(chan struct{}, 1), release: make(chan struct{})}
+		tm, client := newFakeTimeTracking(blocking)
+		task := firstTask(t, client)
+
+		opts := FocusOptions{Work: time.Hour, Cycles: 1}
+		if _, err := tm.StartFocus(task.ProjectID, task.ID, "", opts); err != nil {
+			t.Fatalf("%s: StartFocus: %v", tt.name, err)
+		}
+
+		ended := make(chan error, 1)
+		go func() { ended <- tt.end(tm) }()
+		select {
+		case <-blocking.sending:
+		case <-time.After(5 * time.Second):
+			t.Fatalf("%s: the session's log was not sent", tt.name)
+		}
+
+		// The backend is still busy with the log, and the manager answers
+		read := make(chan struct{})
+		go func() {
+			tm.FocusStatus()
+			tm.GetActiveTimers()
+			close(read)
+		}()
+		select {
+		case <-read:
+		case <-time.After(time.Second):
+			t.Errorf("%s: the manager is locked while the log is sent", tt.name)
+		}
+
+		close(blocking.release)
+		if err := <-ended; err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+		}
+		<-read
+	}
+}
@@ -0,0 +1,77 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/fakebackend"
+	"cadence/internal/infrastructure/httpclient"
+)
+
+// newFakeTimeTracking returns a manager whose backend client sends its
+// requests through rt.
+func newFakeTimeTracking(rt http.RoundTripper) (*TimeTrackingManager, *httpclient.BackendClient) {
+	client := httpclient.NewBackendClient(fakebackend.BaseURL, 5*time.Second)
+	client.SetTransport(rt)
+	return NewTimeTrackingManager(&config.Config{}, client, nil, nil), client
+}
+
+// firstTask returns the first task of the seeded Demo project.
+func firstTask(t *testing.T, client *httpclient.BackendClient) dto.TaskDto {
+	t.Helper()
+	ctx := context.Background()
+
+	projects, err := client.ListProjects(ctx, 1, 50)
+	if err != nil || len(projects.Items) == 0 {
+		t.Fatalf("ListProjects = %v, %v", projects, err)
+	}
+	boards, err := client.ListBoards(ctx, 1, 50, projects.Items[0].ID, "")
+	if err != nil || len(boards.Items) == 0 {
+		t.Fatalf("ListBoards = %v, %v", boards, err)
+	}
+	tasks, err := client.ListTasks(ctx, boards.Items[0].ID, "", 1, 50)
+	if err != nil || len(tasks.Items) == 0 {
+		t.Fatalf("ListTasks = %v, %v", tasks, err)
+	}
+	return tasks.Items[0]
+}
+
+// postedTimeLogs records the time logs sent to the backend on their way
+// through.
+type postedTimeLogs struct {
+	next http.RoundTripper
+	mu   sync.Mutex
+	logs []dto.TimeLogCreateRequest
+}
+
+func (p *postedTimeLogs) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method == http.MethodPost && req.URL.Path == "/time-logs" && req.Body != nil {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		var log dto.TimeLogCreateRequest
+		if err := json.Unmarshal(body, &log); err == nil {
+			p.mu.Lock()
+			p.logs = append(p.logs, log)
+			p.mu.Unlock()
+		}
+	}
+	return p.next.RoundTrip(req)
+}
+
+func (p *postedTimeLogs) sent() []dto.TimeLogCreateRequest {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]dto.TimeLogCreateRequest(nil), p.logs...)
+}
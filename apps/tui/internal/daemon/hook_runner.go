@@ -0,0 +1,246 @@
+package daemon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/config"
+)
+
+// hookEnvPassthrough is the part of the daemon's environment hooks see;
+// everything else a hook gets is CADENCE_* variables.
+var hookEnvPassthrough = []string{
+	"PATH", "HOME", "USER", "LOGNAME", "SHELL", "LANG", "LC_ALL", "TZ", "TMPDIR",
+	"DISPLAY", "WAYLAND_DISPLAY", "XDG_RUNTIME_DIR", "DBUS_SESSION_BUS_ADDRESS",
+}
+
+// hookEvent is what a hook reads on stdin. Payload is the request a pre-
+// hook may veto; Data is the notification's data or, for pre- hooks, the
+// task as it is before the change.
+type hookEvent struct {
+	Event   string      `json:"event"`
+	BoardID string      `json:"board_id,omitempty"`
+	Time    time.Time   `json:"time"`
+	Data    interface{} `json:"data,omitempty"`
+	Payload interface{} `json:"payload,omitempty"`
+}
+
+// HookRunner runs the executables in <dir>/<event>.d/ when the daemon
+// sends a notification, in name order like run-parts. Hooks for pre-
+// events, such as pre-task_moved, run before a change and can refuse it by
+// exiting non-zero.
+//
+// Hooks run with a trimmed environment in their own process group, which
+// is killed when the timeout passes. Files writable by others are skipped.
+type HookRunner struct {
+	dir     string
+	timeout time.Duration
+	slots   chan struct{}
+}
+
+func NewHookRunner(cfg config.HooksConfig) *HookRunner {
+	timeout := time.Duration(cfg.Timeout) * time.Second
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+	concurrent := cfg.MaxConcurrent
+	if concurrent <= 0 {
+		concurrent = 4
+	}
+
+	return &HookRunner{
+		dir:     cfg.Dir,
+		timeout: timeout,
+		slots:   make(chan struct{}, concurrent),
+	}
+}
+
+// HasHooks reports whether any hook would run for an event.
+func (h *HookRunner) HasHooks(event string) bool {
+	return len(h.hooks(event)) > 0
+}
+
+// Run runs the hooks for an event in the background, one after another in
+// name order; a failing hook does not stop the ones after it. Hooks of
+// different events run side by side, at most MaxConcurrent at a time.
+func (h *HookRunner) Run(event hookEvent) {
+	hooks := h.hooks(event.Event)
+	if len(hooks) == 0 {
+		return
+	}
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	go func() {
+		for _, path := range hooks {
+			h.slots <- struct{}{}
+			_, err := h.runHook(context.Background(), path, event)
+			<-h.slots
+
+			if err != nil {
+				fmt.Printf("[Hooks] %s failed: %v\n", h.hookName(path), err)
+			}
+		}
+	}()
+}
+
+// RunPre runs the hooks for a pre- event one after another and returns an
+// error naming the first that refuses the change. A hook that times out
+// refuses it too.
+func (h *HookRunner) RunPre(ctx context.Context, event hookEvent) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+
+	for _, path := range h.hooks(event.Event) {
+		output, err := h.runHook(ctx, path, event)
+		if err == nil {
+			continue
+		}
+
+		reason := lastLine(output)
+		if reason == "" {
+			reason = err.Error()
+		}
+		fmt.Printf("[Hooks] %s refused %s: %s\n", h.hookName(path), strings.TrimPrefix(event.Event, "pre-"), reason)
+		return fmt.Errorf("refused by hook %s: %s", h.hookName(path), reason)
+	}
+	return nil
+}
+
+// hooks lists the executables for an event in name order, skipping
+// hidden and backup files and files writable by group or others.
+func (h *HookRunner) hooks(event string) []string {
+	if h.dir == "" || event == "" {
+		return nil
+	}
+	dir := filepath.Join(h.dir, event+".d")
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+
+	var hooks []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".") || strings.HasSuffix(name, "~") {
+			continue
+		}
+		path := filepath.Join(dir, name)
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() || info.Mode().Perm()&0111 == 0 {
+			continue
+		}
+		if info.Mode().Perm()&0022 != 0 {
+			fmt.Printf("[Hooks] Skipping %s: writable by group or others\n", path)
+			continue
+		}
+		hooks = append(hooks, path)
+	}
+	sort.Strings(hooks)
+	return hooks
+}
+
+// runHook runs one hook with the event on stdin and logs its output.
+func (h *HookRunner) runHook(ctx context.Context, path string, event hookEvent) (string, error) {
+	input, err := json.Marshal(event)
+	if err != nil {
+		return "", err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, path)
+	cmd.Dir = filepath.Dir(path)
+	cmd.Env = hookEnv(event, filepath.Base(path))
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+	cmd.Cancel = func() error {
+		return syscall.Kill(-cmd.Process.Pid, syscall.SIGKILL)
+	}
+	cmd.WaitDelay = time.Second
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+	err = cmd.Run()
+
+	output := strings.TrimRight(out.String(), "\n")
+	for _, line := range strings.Split(output, "\n") {
+		if line != "" {
+			fmt.Printf("[Hooks] %s: %s\n", h.hookName(path), line)
+		}
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		return output, fmt.Errorf("timed out after %s", h.timeout)
+	}
+	return output, err
+}
+
+// hookName is a hook's path below the hooks directory, such as
+// "task_moved.d/10-chat".
+func (h *HookRunner) hookName(path string) string {
+	if rel, err := filepath.Rel(h.dir, path); err == nil {
+		return rel
+	}
+	return path
+}
+
+// hookEnv builds a hook's environment: a few of the daemon's variables and
+// CADENCE_* variables describing the event.
+func hookEnv(event hookEvent, hook string) []string {
+	var env []string
+	for _, key := range hookEnvPassthrough {
+		if value, ok := os.LookupEnv(key); ok {
+			env = append(env, key+"="+value)
+		}
+	}
+
+	var task *dto.TaskDto
+	switch data := event.Data.(type) {
+	case *dto.TaskDto:
+		task = data
+	case dto.TaskDto:
+		task = &data
+	}
+
+	boardID := event.BoardID
+	if boardID == "" && task != nil {
+		boardID = task.BoardID
+	}
+	env = append(env,
+		"CADENCE_EVENT="+event.Event,
+		"CADENCE_HOOK="+hook,
+		"CADENCE_BOARD_ID="+boardID,
+	)
+
+	if task != nil {
+		env = append(env,
+			"CADENCE_TASK_ID="+task.ID,
+			"CADENCE_TASK_KEY="+taskKey(*task),
+			"CADENCE_TASK_TITLE="+task.Title,
+			"CADENCE_TASK_STATUS="+task.Status,
+			"CADENCE_TASK_PRIORITY="+stringValue(task.Priority),
+			"CADENCE_COLUMN_ID="+task.ColumnID,
+			"CADENCE_PROJECT_ID="+task.ProjectID,
+		)
+	}
+	return env
+}
+
+func lastLine(s string) string {
+	lines := strings.Split(strings.TrimSpace(s), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
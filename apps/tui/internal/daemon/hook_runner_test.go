@@ -0,0 +1,175 @@
+package daemon
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/config"
+)
+
+// writeHook writes an executable shell script for an event and sets its
+// mode, so the umask does not decide it.
+func writeHook(t *testing.T, dir, event, name, script string, mode os.FileMode) string {
+	t.Helper()
+	eventDir := filepath.Join(dir, event+".d")
+	if err := os.MkdirAll(eventDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(eventDir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chmod(path, mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestHookRunnerSkipsUnsafeHooks(t *testing.T) {
+	dir := t.TempDir()
+	writeHook(t, dir, "task_moved", "10-run", "true", 0o755)
+	writeHook(t, dir, "task_moved", "20-group-writable", "true", 0o775)
+	writeHook(t, dir, "task_moved", "30-world-writable", "true", 0o757)
+	writeHook(t, dir, "task_moved", "40-not-executable", "true", 0o644)
+	writeHook(t, dir, "task_moved", ".hidden", "true", 0o755)
+	writeHook(t, dir, "task_moved", "50-backup~", "true", 0o755)
+	writeHook(t, dir, "task_moved", "05-also-run", "true", 0o700)
+	if err := os.Mkdir(filepath.Join(dir, "task_moved.d", "60-dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	h := NewHookRunner(config.HooksConfig{Dir: dir})
+	var names []string
+	for _, path := range h.hooks("task_moved") {
+		names = append(names, h.hookName(path))
+	}
+	want := "task_moved.d/05-also-run task_moved.d/10-run"
+	if got := strings.Join(names, " "); got != want {
+		t.Errorf("hooks = %s, want %s", got, want)
+	}
+
+	if h.HasHooks("task_created") {
+		t.Error("HasHooks reports hooks for an event without a directory")
+	}
+	if NewHookRunner(config.HooksConfig{}).HasHooks("task_moved") {
+		t.Error("HasHooks reports hooks without a hooks directory")
+	}
+}
+
+func TestHookRunnerRunPre(t *testing.T) {
+	task := &dto.TaskDto{ID: "t1", Title: "Ship it", BoardID: "b1", Status: dto.TaskStatusTodo}
+
+	tests := []struct {
+		name    string
+		hooks   map[string]string
+		wantErr string
+		// ran lists the hooks expected to have left a marker
+		ran []string
+	}{
+		{
+			name:  "all hooks allow",
+			hooks: map[string]string{"10-a": "exit 0", "20-b": "exit 0"},
+			ran:   []string{"10-a", "20-b"},
+		},
+		{
+			name: "a veto stops the hooks after it",
+			hooks: map[string]string{
+				"10-a":    "exit 0",
+				"20-veto": "echo checking; echo 'column is frozen'; exit 1",
+				"30-c":    "exit 0",
+			},
+			wantErr: "refused by hook pre-task_moved.d/20-veto: column is frozen",
+			ran:     []string{"10-a", "20-veto"},
+		},
+		{
+			name:    "a silent veto reports the exit status",
+			hooks:   map[string]string{"10-veto": "exit 3"},
+			wantErr: "refused by hook pre-task_moved.d/10-veto: exit status 3",
+			ran:     []string{"10-veto"},
+		},
+		{
+			name:    "the event and task reach the hook",
+			hooks:   map[string]string{"10-check": `[ "$CADENCE_EVENT" = pre-task_moved ] && [ "$CADENCE_TASK_TITLE" = "Ship it" ] && [ "$CADENCE_BOARD_ID" = b1 ] && grep -q '"column_id":"c2"' || { echo wrong input; exit 1; }`},
+			ran:     []string{"10-check"},
+			wantErr: "",
+		},
+	}
+
+	for _, tt := range tests {
+		dir := t.TempDir()
+		markers := t.TempDir()
+		for name, script := range tt.hooks {
+			writeHook(t, dir, "pre-task_moved", name, "touch "+filepath.Join(markers, name)+"\n"+script, 0o755)
+		}
+
+		h := NewHookRunner(config.HooksConfig{Dir: dir})
+		err := h.RunPre(context.Background(), hookEvent{
+			Event:   "pre-task_moved",
+			Data:    task,
+			Payload: map[string]string{"column_id": "c2"},
+		})
+		switch {
+		case tt.wantErr == "" && err != nil:
+			t.Errorf("%s: RunPre error: %v", tt.name, err)
+		case tt.wantErr != "" && (err == nil || err.Error() != tt.wantErr):
+			t.Errorf("%s: RunPre error = %v, want %q", tt.name, err, tt.wantErr)
+		}
+
+		entries, _ := os.ReadDir(markers)
+		var ran []string
+		for _, entry := range entries {
+			ran = append(ran, entry.Name())
+		}
+		if strings.Join(ran, " ") != strings.Join(tt.ran, " ") {
+			t.Errorf("%s: ran %v, want %v", tt.name, ran, tt.ran)
+		}
+	}
+}
+
+func TestHookRunnerRunPreTimeout(t *testing.T) {
+	dir := t.TempDir()
+	// The background sleep keeps the process group busy; it is killed too
+	writeHook(t, dir, "pre-task_deleted", "10-slow", "sleep 30 & sleep 30", 0o755)
+
+	h := NewHookRunner(config.HooksConfig{Dir: dir, Timeout: 1})
+	start := time.Now()
+	err := h.RunPre(context.Background(), hookEvent{Event: "pre-task_deleted"})
+	if err == nil || !strings.Contains(err.Error(), "timed out after 1s") {
+		t.Errorf("RunPre error = %v, want a timeout refusal", err)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("RunPre took %s after a 1s timeout", elapsed)
+	}
+}
+
+func TestHookRunnerRunsPostHooksInOrder(t *testing.T) {
+	dir := t.TempDir()
+	log := filepath.Join(t.TempDir(), "log")
+	// The first hook is the slowest, so running side by side would reorder them
+	writeHook(t, dir, "task_updated", "10-a", "sleep 0.3; echo a >> "+log, 0o755)
+	writeHook(t, dir, "task_updated", "20-b", "exit 1", 0o755)
+	writeHook(t, dir, "task_updated", "30-c", "echo c >> "+log, 0o755)
+
+	h := NewHookRunner(config.HooksConfig{Dir: dir})
+	h.Run(hookEvent{Event: "task_updated"})
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		data, _ := os.ReadFile(log)
+		if lines := strings.Fields(string(data)); len(lines) == 2 {
+			if got := strings.Join(lines, " "); got != "a c" {
+				t.Errorf("hooks ran as %q, want %q", got, "a c")
+			}
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("hooks did not finish, log: %q", data)
+		}
+		time.Sleep(20 * time.Millisecond)
+	}
+}
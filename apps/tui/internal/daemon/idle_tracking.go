@@ -0,0 +1,150 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+
+	"cadence/internal/domain/entity"
+)
+
+// maxIdlePeriods bounds the idle periods kept for the keep/discard prompt;
+// the oldest are discarded first.
+const maxIdlePeriods = 20
+
+// checkIdle stops auto-tracked timers once the user has been inactive for
+// IdleThreshold seconds. Timers end at the last activity, not when the idle
+// state was noticed. It reports whether the user is still idle, in which
+// case no new auto timer should start.
+func (tm *TimeTrackingManager) checkIdle(ctx context.Context) bool {
+	threshold := time.Duration(tm.config.TimeTracking.IdleThreshold) * time.Second
+	if tm.idleDetector == nil || threshold <= 0 {
+		return false
+	}
+
+	lastActivity, err := tm.idleDetector.LastActivity()
+	if err != nil || lastActivity.IsZero() {
+		return false
+	}
+
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	if time.Since(lastActivity) < threshold {
+		if tm.idle {
+			tm.idle = false
+			tm.endIdlePeriodsLocked(lastActivity)
+			fmt.Printf("[TimeTrackingManager] Activity resumed at %s\n", lastActivity.Format(time.Kitchen))
+		}
+		return false
+	}
+
+	if tm.idle {
+		return true
+	}
+	tm.idle = true
+
+	for key, timer := range tm.autoTimers {
+		if !timer.IsRunning() {
+			continue
+		}
+
+		end := lastActivity
+		if end.Before(timer.StartTime()) {
+			end = timer.StartTime()
+		}
+		_ = timer.Stop(end)
+		tm.sendTimeLogToBackend(ctx, timer)
+		fmt.Printf("[TimeTrackingManager] Idle since %s, stopped timer for %s\n", end.Format(time.Kitchen), key)
+
+		if tm.config.TimeTracking.PromptIdle {
+			tm.addIdlePeriodLocked(&IdlePeriod{
+				ID:        uuid.New().String(),
+				ProjectID: timer.ProjectID(),
+				TaskID:    timer.TaskID(),
+				Start:     end,
+			})
+		}
+	}
+
+	tm.autoTimers = make(map[string]*entity.TimeLog)
+	tm.currentProjectID = ""
+	tm.currentTaskID = ""
+	return true
+}
+
+func (tm *TimeTrackingManager) addIdlePeriodLocked(period *IdlePeriod) {
+	tm.idlePeriods = append(tm.idlePeriods, period)
+	if len(tm.idlePeriods) > maxIdlePeriods {
+		tm.idlePeriods = tm.idlePeriods[len(tm.idlePeriods)-maxIdlePeriods:]
+	}
+}
+
+func (tm *TimeTrackingManager) endIdlePeriodsLocked(end time.Time) {
+	for _, period := range tm.idlePeriods {
+		if period.End.IsZero() {
+			period.End = end
+		}
+	}
+}
+
+// IdlePeriods returns the finished idle periods still waiting for a keep or
+// discard decision.
+func (tm *TimeTrackingManager) IdlePeriods() []IdlePeriod {
+	tm.mu.RLock()
+	defer tm.mu.RUnlock()
+
+	periods := make([]IdlePeriod, 0, len(tm.idlePeriods))
+	for _, period := range tm.idlePeriods {
+		if !period.End.IsZero() {
+			periods = append(periods, *period)
+		}
+	}
+	return periods
+}
+
+// ResolveIdlePeriod forgets an idle period. With keep, its time is logged
+// to the project and task the stopped timer was tracking.
+func (tm *TimeTrackingManager) ResolveIdlePeriod(ctx context.Context, id string, keep bool) error {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	index := -1
+	for i, period := range tm.idlePeriods {
+		if period.ID == id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		return fmt.Errorf("idle period %s not found", id)
+	}
+
+	period := tm.idlePeriods[index]
+	if period.End.IsZero() {
+		return fmt.Errorf("idle period %s has not ended yet", id)
+	}
+	tm.idlePeriods = append(tm.idlePeriods[:index], tm.idlePeriods[index+1:]...)
+
+	if !keep {
+		return nil
+	}
+
+	log, err := entity.NewTimeLog(uuid.New().String(), period.ProjectID, entity.TimeLogSourceTmux, period.Start)
+	if err != nil {
+		return err
+	}
+	if period.TaskID != "" {
+		log.SetTaskID(period.TaskID)
+	}
+	log.SetMetadata("idle_kept", "true")
+	if err := log.Stop(period.End); err != nil {
+		return err
+	}
+
+	tm.sendTimeLogToBackend(ctx, log)
+	fmt.Printf("[TimeTrackingManager] Kept %s of idle time for %s\n", log.Duration().Round(time.Second), period.ProjectID)
+	return nil
+}
@@ -0,0 +1,163 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/domain/entity"
+)
+
+// futureTolerance allows a logged entry to end slightly after now, so
+// "30m" logged at the end of a meeting is not rejected for clock skew.
+const futureTolerance = time.Minute
+
+// LogTime records work done earlier as a manual time log. Unless
+// allowOverlap is set, it is rejected when it overlaps a running timer or a
+// log already recorded for the same task.
+func (tm *TimeTrackingManager) LogTime(
+	ctx context.Context,
+	projectID, taskID string,
+	start time.Time,
+	duration time.Duration,
+	description string,
+	allowOverlap bool,
+) (*dto.TimeLogDto, error) {
+	if duration <= 0 {
+		return nil, entity.ErrInvalidDuration
+	}
+	end := start.Add(duration)
+	if end.After(time.Now().Add(futureTolerance)) {
+		return nil, entity.ErrTimeLogInFuture
+	}
+
+	if !allowOverlap {
+		if err := tm.checkOverlap(ctx, projectID, taskID, start, end); err != nil {
+			return nil, err
+		}
+	}
+
+	log, err := entity.NewTimeLog(uuid.New().String(), projectID, entity.TimeLogSourceManual, start)
+	if err != nil {
+		return nil, err
+	}
+	if taskID != "" {
+		log.SetTaskID(taskID)
+	}
+	if description != "" {
+		log.SetDescription(description)
+	}
+	if err := log.SetDuration(duration); err != nil {
+		return nil, err
+	}
+
+	created, err := tm.backendClient.CreateTimeLog(ctx, timeLogCreateRequest(log))
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[TimeTrackingManager] Logged %s for %s at %s\n", duration, timerKey(projectID, taskID), start.Format(time.RFC3339))
+	return created, nil
+}
+
+func (tm *TimeTrackingManager) checkOverlap(ctx context.Context, projectID, taskID string, start, end time.Time) error {
+	key := timerKey(projectID, taskID)
+	now := time.Now()
+
+	tm.mu.RLock()
+	for _, timers := range []map[string]*entity.TimeLog{tm.activeTimers, tm.autoTimers} {
+		if timer, ok := timers[key]; ok && timer.IsRunning() {
+			if start.Before(now) && end.After(timer.StartTime()) {
+				tm.mu.RUnlock()
+				return fmt.Errorf("%w: a timer for %s has been running since %s",
+					entity.ErrTimeLogOverlap, key, timer.StartTime().Local().Format("2006-01-02 15:04"))
+			}
+		}
+	}
+	tm.mu.RUnlock()
+
+	// The backend only lists logs per task, so project-level entries are
+	// checked against running timers alone.
+	if taskID == "" {
+		return nil
+	}
+
+	logs, err := tm.backendClient.ListTimeLogs(ctx, taskID)
+	if err != nil {
+		return fmt.Errorf("checking for overlapping logs: %w", err)
+	}
+	for _, existing := range logs {
+		logStart, logEnd, ok := timeLogSpan(existing)
+		if !ok {
+			continue
+		}
+		if start.Before(logEnd) && end.After(logStart) {
+			return fmt.Errorf("%w: %s to %s is already logged",
+				entity.ErrTimeLogOverlap, logStart.Local().Format("2006-01-02 15:04"), logEnd.Local().Format("15:04"))
+		}
+	}
+	return nil
+}
+
+// timeLogSpan returns when a backend log started and ended. Logs without a
+// parsable start or a duration are skipped.
+func timeLogSpan(log dto.TimeLogDto) (time.Time, time.Time, bool) {
+	start, err := time.Parse(time.RFC3339, log.StartTime)
+	if err != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	if log.EndTime != nil {
+		if end, err := time.Parse(time.RFC3339, *log.EndTime); err == nil {
+			return start, end, true
+		}
+	}
+	if log.Duration == nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, start.Add(time.Duration(*log.Duration) * time.Minute), true
+}
+
+// ResolveTask finds a task by ID or by slug such as CAD-12 and returns its
+// ID and project.
+func (tm *TimeTrackingManager) ResolveTask(ctx context.Context, ref string) (string, string, error) {
+	task, err := tm.backendClient.GetTask(ctx, ref)
+	if err == nil {
+		return task.ID, task.ProjectID, nil
+	}
+	if tm.taskResolver == nil || !slugKeyPattern.MatchString(strings.ToLower(ref)) {
+		return "", "", err
+	}
+
+	projects, listErr := tm.backendClient.ListProjects(ctx, 1, taskIndexPageSize)
+	if listErr != nil {
+		return "", "", err
+	}
+
+	// Slugs start with the project's slug, so those projects are searched
+	// first; the rest only if none of them has the task.
+	var matching, others []dto.ProjectDto
+	for _, p := range projects.Items {
+		if p.Slug != "" && strings.HasPrefix(strings.ToLower(ref), strings.ToLower(p.Slug)+"-") {
+			matching = append(matching, p)
+		} else {
+			others = append(others, p)
+		}
+	}
+	for _, p := range append(matching, others...) {
+		if id := tm.taskResolver.lookupSlug(ctx, p.ID, ref); id != "" {
+			return id, p.ID, nil
+		}
+	}
+	return "", "", fmt.Errorf("task %s not found", ref)
+}
+
+func timerKey(projectID, taskID string) string {
+	if taskID != "" {
+		return taskID
+	}
+	return projectID
+}
@@ -1,5 +1,12 @@
 package daemon
 
+import (
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/tracing"
+)
+
 const (
 	RequestGetBoard       = "get_board"
 	RequestListBoards     = "list_boards"
@@ -12,6 +19,7 @@ const (
 	RequestAddColumn      = "add_column"
 	RequestDeleteColumn   = "delete_column"
 	RequestGetActiveBoard = "get_active_board"
+	RequestGetTask        = "get_task"
 
 	RequestListNotes  = "list_notes"
 	RequestGetNote    = "get_note"
@@ -31,28 +39,62 @@ const (
 	RequestStartTimer      = "start_timer"
 	RequestStopTimer       = "stop_timer"
 	RequestGetActiveTimers = "get_active_timers"
+	RequestPauseTimer      = "pause_timer"
+	RequestResumeTimer     = "resume_timer"
+	RequestUpdateTimer     = "update_timer"
+	RequestDiscardTimer    = "discard_timer"
+	RequestLogTime         = "log_time"
+	RequestGetTimeSummary  = "get_time_summary"
+	RequestGetDailyLogs    = "get_daily_time_logs"
+	RequestGetIdlePeriods  = "get_idle_periods"
+	RequestResolveIdle     = "resolve_idle_period"
+	RequestStartFocus      = "start_focus"
+	RequestStopFocus       = "stop_focus"
+	RequestSkipFocusPhase  = "skip_focus_phase"
+	RequestGetFocusStatus  = "get_focus_status"
+
+	RequestListReminders    = "list_reminders"
+	RequestSnoozeReminder   = "snooze_reminder"
+	RequestDismissReminder  = "dismiss_reminder"
+	RequestTestNotification = "test_notification"
+
+	RequestListActionRules = "list_action_rules"
+	RequestDryRunActions   = "dry_run_actions"
+
+	RequestReportShell          = "report_shell"
+	RequestGetProjectPrompts    = "get_project_prompts"
+	RequestResolveProjectPrompt = "resolve_project_prompt"
 
 	RequestListProjects = "list_projects"
 	RequestGetProject   = "get_project"
 	RequestReloadToken  = "reload_token"
-
-	NotificationBoardUpdated = "board_updated"
-	NotificationTaskCreated  = "task_created"
-	NotificationTaskUpdated  = "task_updated"
-	NotificationTaskMoved    = "task_moved"
-	NotificationTaskDeleted  = "task_deleted"
-	NotificationPong         = "pong"
+	RequestGetTraces    = "get_traces"
+	RequestWhoAmI       = "whoami"
+
+	NotificationBoardUpdated    = "board_updated"
+	NotificationTaskCreated     = "task_created"
+	NotificationTaskUpdated     = "task_updated"
+	NotificationTaskMoved       = "task_moved"
+	NotificationTaskDeleted     = "task_deleted"
+	NotificationPong            = "pong"
+	NotificationAuthRequired    = "auth_required"
+	NotificationAccountSwitched = "account_switched"
+	NotificationFocusPhase      = "focus_phase"
+	NotificationReminder        = "reminder"
+	NotificationProjectPrompt   = "project_prompt"
 )
 
 type Request struct {
-	Type    string      `json:"type"`
-	Payload interface{} `json:"payload,omitempty"`
+	Type      string      `json:"type"`
+	RequestID string      `json:"request_id,omitempty"`
+	Payload   interface{} `json:"payload,omitempty"`
 }
 
 type Response struct {
-	Success bool        `json:"success"`
-	Data    interface{} `json:"data,omitempty"`
-	Error   string      `json:"error,omitempty"`
+	Success   bool        `json:"success"`
+	RequestID string      `json:"request_id,omitempty"`
+	Data      interface{} `json:"data,omitempty"`
+	Error     string      `json:"error,omitempty"`
 }
 
 type Notification struct {
@@ -117,10 +159,165 @@ type StartTimerPayload struct {
 }
 
 type StopTimerPayload struct {
+	TimerID   string `json:"timer_id,omitempty"`
 	ProjectID string `json:"project_id"`
 	TaskID    string `json:"task_id,omitempty"`
 }
 
+// TimerPayload picks a running manual timer by ID, project or task; when
+// all are empty the only running timer is used.
+type TimerPayload struct {
+	TimerID   string `json:"timer_id,omitempty"`
+	ProjectID string `json:"project_id,omitempty"`
+	TaskID    string `json:"task_id,omitempty"`
+}
+
+// UpdateTimerPayload changes a running timer. Nil fields are left as they
+// are; an empty TaskID detaches the timer from its task.
+type UpdateTimerPayload struct {
+	TimerPayload
+	Description *string `json:"description,omitempty"`
+	NewTaskID   *string `json:"new_task_id,omitempty"`
+}
+
+// LogTimePayload records time after the fact. TaskID may be a task ID or
+// a slug such as CAD-12; ProjectID is then looked up from the task.
+type LogTimePayload struct {
+	ProjectID       string    `json:"project_id,omitempty"`
+	TaskID          string    `json:"task_id,omitempty"`
+	Start           time.Time `json:"start"`
+	DurationSeconds int64     `json:"duration_seconds"`
+	Description     string    `json:"description,omitempty"`
+	AllowOverlap    bool      `json:"allow_overlap,omitempty"`
+}
+
+// IdlePeriod is time cut from an auto-tracked timer because the user was
+// idle. End is zero while the user is still away.
+type IdlePeriod struct {
+	ID          string    `json:"id"`
+	ProjectID   string    `json:"project_id"`
+	ProjectName string    `json:"project_name,omitempty"`
+	TaskID      string    `json:"task_id,omitempty"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+}
+
+type ResolveIdlePeriodPayload struct {
+	ID   string `json:"id"`
+	Keep bool   `json:"keep"`
+}
+
+// ProjectPrompt asks whether to create the project and board a session
+// directory resolved to, with session_tracking.create set to ask.
+// ProjectExists is set when only the board is missing.
+type ProjectPrompt struct {
+	ID            string `json:"id"`
+	WorkingDir    string `json:"working_dir"`
+	Project       string `json:"project"`
+	Board         string `json:"board"`
+	ProjectExists bool   `json:"project_exists,omitempty"`
+}
+
+type ResolveProjectPromptPayload struct {
+	ID     string `json:"id"`
+	Create bool   `json:"create"`
+}
+
+// StartFocusPayload starts a focus session. TaskID may be a slug; with
+// neither ProjectID nor TaskID the auto-tracked task is used. Zero lengths
+// take the configured defaults.
+type StartFocusPayload struct {
+	ProjectID         string `json:"project_id,omitempty"`
+	TaskID            string `json:"task_id,omitempty"`
+	Description       string `json:"description,omitempty"`
+	WorkMinutes       int    `json:"work_minutes,omitempty"`
+	ShortBreakMinutes int    `json:"short_break_minutes,omitempty"`
+	LongBreakMinutes  int    `json:"long_break_minutes,omitempty"`
+	LongBreakEvery    int    `json:"long_break_every,omitempty"`
+	Cycles            int    `json:"cycles,omitempty"`
+}
+
+// FocusStatus is the state of a focus session, sent with every
+// focus_phase notification. Cycle counts work phases, including the one in
+// progress; once Finished, it counts the completed ones.
+type FocusStatus struct {
+	ID          string    `json:"id"`
+	TimerID     string    `json:"timer_id"`
+	ProjectID   string    `json:"project_id"`
+	TaskID      string    `json:"task_id,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Phase       string    `json:"phase"`
+	PhaseStart  time.Time `json:"phase_start"`
+	PhaseEnd    time.Time `json:"phase_end"`
+	Cycle       int       `json:"cycle"`
+	Cycles      int       `json:"cycles,omitempty"`
+	Worked      int64     `json:"worked_seconds"`
+	Finished    bool      `json:"finished,omitempty"`
+}
+
+// Reminder is a notification the daemon has scheduled or already shown.
+// DueAt is when the task is due, the agenda item starts or the alarm is set
+// for; FireAt is when the reminder rings next, after any snooze.
+type Reminder struct {
+	ID      string    `json:"id"`
+	Source  string    `json:"source"`
+	Title   string    `json:"title"`
+	Body    string    `json:"body,omitempty"`
+	TaskID  string    `json:"task_id,omitempty"`
+	DueAt   time.Time `json:"due_at"`
+	FireAt  time.Time `json:"fire_at"`
+	Fired   bool      `json:"fired"`
+	Snoozed bool      `json:"snoozed,omitempty"`
+}
+
+// SnoozeReminderPayload rings a reminder again after Minutes; zero uses
+// the configured snooze. ID may be a unique prefix.
+type SnoozeReminderPayload struct {
+	ID      string `json:"id"`
+	Minutes int    `json:"minutes,omitempty"`
+}
+
+// DismissReminderPayload silences a reminder. ID may be a unique prefix.
+type DismissReminderPayload struct {
+	ID string `json:"id"`
+}
+
+// ActionRuleInfo describes a configured automation rule. Error explains
+// why an invalid rule never runs.
+type ActionRuleInfo struct {
+	Name       string     `json:"name"`
+	Trigger    string     `json:"trigger"`
+	Conditions []string   `json:"conditions,omitempty"`
+	Actions    []string   `json:"actions"`
+	NextRun    *time.Time `json:"next_run,omitempty"`
+	Disabled   bool       `json:"disabled,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// DryRunActionsPayload asks what the rules would do. Rule evaluates one
+// rule as if it fired now; Event evaluates the rules for an event on Task.
+// Set holds field changes, such as status or column, applied to a copy of
+// the task as the change that caused the event.
+type DryRunActionsPayload struct {
+	Event string            `json:"event,omitempty"`
+	Rule  string            `json:"rule,omitempty"`
+	Task  string            `json:"task,omitempty"`
+	Set   map[string]string `json:"set,omitempty"`
+}
+
+// ActionPlan is what a rule did, or in a dry run would do, for a task.
+// Reason says why a rule whose trigger fired did not match.
+type ActionPlan struct {
+	Rule    string   `json:"rule"`
+	Trigger string   `json:"trigger"`
+	TaskID  string   `json:"task_id,omitempty"`
+	Task    string   `json:"task,omitempty"`
+	Matched bool     `json:"matched"`
+	Reason  string   `json:"reason,omitempty"`
+	Actions []string `json:"actions,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
 type ListNotesPayload struct {
 	ProjectID string `json:"project_id,omitempty"`
 	NoteType  string `json:"note_type,omitempty"`
@@ -181,9 +378,53 @@ type GetProjectPayload struct {
 	ProjectID string `json:"project_id"`
 }
 
+type GetTaskPayload struct {
+	TaskID string `json:"task_id"`
+}
+
+// TimeSummaryPayload asks for per-project totals, for every project or only
+// ProjectID. Zero times leave the range open.
+type TimeSummaryPayload struct {
+	ProjectID string    `json:"project_id,omitempty"`
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+}
+
+type DailyTimeLogsPayload struct {
+	Date string `json:"date"`
+}
+
 type ListTasksPayload struct {
 	BoardID  string `json:"board_id,omitempty"`
 	ColumnID string `json:"column_id,omitempty"`
 	Page     int    `json:"page"`
 	Limit    int    `json:"limit"`
 }
+
+type TracesResponse struct {
+	SlowThresholdMs int64            `json:"slow_threshold_ms"`
+	Traces          []*tracing.Trace `json:"traces"`
+}
+
+type WhoAmIResponse struct {
+	Account       string           `json:"account,omitempty"`
+	Authenticated bool             `json:"authenticated"`
+	User          *dto.AuthUserDto `json:"user,omitempty"`
+	ExpiresAt     *time.Time       `json:"expires_at,omitempty"`
+	Reason        string           `json:"reason,omitempty"`
+}
+
+// ReportShellPayload is what shell integration sends from a prompt hook:
+// the shell's directory and the terminal it runs in. Exit is set when the
+// shell exits.
+type ReportShellPayload struct {
+	Shell    string `json:"shell"`
+	PID      int    `json:"pid"`
+	Host     string `json:"host,omitempty"`
+	Cwd      string `json:"cwd"`
+	TTY      string `json:"tty,omitempty"`
+	Terminal string `json:"terminal,omitempty"`
+	Window   string `json:"window,omitempty"`
+	SSH      bool   `json:"ssh,omitempty"`
+	Exit     bool   `json:"exit,omitempty"`
+}
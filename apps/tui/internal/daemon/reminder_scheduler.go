@@ -0,0 +1,552 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/domain/service"
+	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/httpclient"
+	"cadence/pkg/timeparse"
+)
+
+const (
+	ReminderSourceTaskDue   = "task_due"
+	ReminderSourceAgenda    = "agenda"
+	ReminderSourceAlarmPlan = "alarm_plan"
+
+	// reminderTick is how often due reminders are checked; the backend is
+	// only read every CheckInterval.
+	reminderTick = 15 * time.Second
+
+	// reminderMissedAfter drops reminders that should have rung this long
+	// ago, so a daemon started in the afternoon does not replay the
+	// morning's reminders.
+	reminderMissedAfter = 10 * time.Minute
+
+	// reminderKeepAfter is how long after its due time a reminder is still
+	// listed, and how long an alarm keeps repeating.
+	reminderKeepAfter = time.Hour
+
+	reminderTaskPageSize = 100
+	reminderMaxTaskPages = 20
+)
+
+// reminderState is a reminder with what the scheduler needs to ring it.
+type reminderState struct {
+	Reminder
+	scheduledAt time.Time
+	repeat      time.Duration
+	rangAt      time.Time
+	dismissed   bool
+	urgency     service.Urgency
+}
+
+// ReminderScheduler reminds of due tasks, upcoming agenda items and the
+// backend's alarm plans. It reads them every CheckInterval and rings
+// reminders through the notifier when their time comes.
+type ReminderScheduler struct {
+	config        *config.Config
+	backendClient *httpclient.BackendClient
+	notifier      service.Notifier
+	onFire        func(Reminder)
+
+	dueBefore    []time.Duration
+	agendaBefore time.Duration
+
+	reminders   map[string]*reminderState
+	lastRefresh time.Time
+
+	mu       sync.Mutex
+	stopChan chan struct{}
+	stopped  bool
+}
+
+func NewReminderScheduler(
+	cfg *config.Config,
+	backendClient *httpclient.BackendClient,
+	notifier service.Notifier,
+) *ReminderScheduler {
+	r := &ReminderScheduler{
+		config:        cfg,
+		backendClient: backendClient,
+		notifier:      notifier,
+		reminders:     make(map[string]*reminderState),
+		stopChan:      make(chan struct{}),
+	}
+
+	for _, s := range cfg.Reminders.DueBefore {
+		d, err := timeparse.ParseDuration(s)
+		if err != nil {
+			fmt.Printf("[Reminders] Ignoring due_before %q: %v\n", s, err)
+			continue
+		}
+		r.dueBefore = append(r.dueBefore, d)
+	}
+	if cfg.Reminders.AgendaBefore != "" {
+		d, err := timeparse.ParseDuration(cfg.Reminders.AgendaBefore)
+		if err != nil {
+			fmt.Printf("[Reminders] Ignoring agenda_before %q: %v\n", cfg.Reminders.AgendaBefore, err)
+		} else {
+			r.agendaBefore = d
+		}
+	}
+
+	return r
+}
+
+// SetFireHandler sets the function told about every reminder that rings.
+func (r *ReminderScheduler) SetFireHandler(fn func(Reminder)) {
+	r.onFire = fn
+}
+
+func (r *ReminderScheduler) Start(ctx context.Context) {
+	go r.loop(ctx)
+}
+
+func (r *ReminderScheduler) Stop() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.stopped {
+		return
+	}
+	r.stopped = true
+	close(r.stopChan)
+}
+
+func (r *ReminderScheduler) loop(ctx context.Context) {
+	interval := time.Duration(r.config.Reminders.CheckInterval) * time.Second
+	if interval <= 0 {
+		interval = time.Minute
+	}
+
+	r.refresh(ctx)
+	r.fireDue()
+
+	ticker := time.NewTicker(reminderTick)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if time.Since(r.lastRefresh) >= interval {
+				r.refresh(ctx)
+			}
+			r.fireDue()
+		case <-r.stopChan:
+			return
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// refresh reads the reminder sources and merges them into the known
+// reminders. A source that fails keeps its reminders from the last read.
+func (r *ReminderScheduler) refresh(ctx context.Context) {
+	now := time.Now()
+	candidates := make(map[string]*reminderState)
+	read := make(map[string]bool)
+
+	if len(r.dueBefore) > 0 {
+		if err := r.collectDueTasks(ctx, now, candidates); err != nil {
+			fmt.Printf("[Reminders] Failed to read due tasks: %v\n", err)
+		} else {
+			read[ReminderSourceTaskDue] = true
+		}
+	}
+
+	if r.agendaBefore > 0 || r.config.Reminders.AlarmPlans {
+		if err := r.collectAgenda(ctx, now, candidates); err != nil {
+			fmt.Printf("[Reminders] Failed to read the agenda: %v\n", err)
+		} else {
+			read[ReminderSourceAgenda] = true
+			read[ReminderSourceAlarmPlan] = true
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for id, existing := range r.reminders {
+		if _, ok := candidates[id]; !ok && read[existing.Source] {
+			delete(r.reminders, id)
+		}
+	}
+
+	for id, candidate := range candidates {
+		existing, ok := r.reminders[id]
+		if ok && existing.scheduledAt.Equal(candidate.scheduledAt) {
+			existing.Title, existing.Body = candidate.Title, candidate.Body
+			continue
+		}
+
+		// New, or moved to another time: schedule afresh. Reminders whose
+		// time passed while nobody was watching are kept quiet.
+		if now.Sub(candidate.scheduledAt) > reminderMissedAfter {
+			candidate.dismissed = true
+		}
+		r.reminders[id] = candidate
+	}
+
+	r.lastRefresh = now
+}
+
+func (r *ReminderScheduler) collectDueTasks(ctx context.Context, now time.Time, out map[string]*reminderState) error {
+	for page := 1; page <= reminderMaxTaskPages; page++ {
+		tasks, err := r.backendClient.ListTasks(ctx, "", "", page, reminderTaskPageSize)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range tasks.Items {
+			if task.DueDate == nil || task.CompletedAt != nil ||
+				task.Status == dto.TaskStatusDone || task.Status == dto.TaskStatusCancelled {
+				continue
+			}
+			due, err := parseReminderTime(*task.DueDate)
+			if err != nil || now.Sub(due) > reminderKeepAfter {
+				continue
+			}
+
+			for _, before := range r.dueBefore {
+				fireAt := due.Add(-before)
+				id := fmt.Sprintf("due:%s:%d", task.ID, int(before/time.Minute))
+				out[id] = &reminderState{
+					Reminder: Reminder{
+						ID:     id,
+						Source: ReminderSourceTaskDue,
+						Title:  "Due " + relativeDue(due, now, before) + ": " + taskLabel(task),
+						Body:   "Due " + formatReminderTime(due, now),
+						TaskID: task.ID,
+						DueAt:  due,
+						FireAt: fireAt,
+					},
+					scheduledAt: fireAt,
+					urgency:     service.UrgencyNormal,
+				}
+			}
+		}
+
+		if len(tasks.Items) < reminderTaskPageSize || page*reminderTaskPageSize >= tasks.Total {
+			return nil
+		}
+	}
+	return nil
+}
+
+// collectAgenda reads today's and tomorrow's agenda for scheduled items
+// and for the routines whose alarm plans should ring.
+func (r *ReminderScheduler) collectAgenda(ctx context.Context, now time.Time, out map[string]*reminderState) error {
+	timezone := localTimezone()
+	routineTasks := make(map[string]string)
+	for _, day := range []time.Time{now, now.AddDate(0, 0, 1)} {
+		view, err := r.backendClient.GetAgendaView(ctx, dto.AgendaViewModeDay, day.Format("2006-01-02"), timezone)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range agendaViewItems(view) {
+			if item.RoutineTaskID != nil && item.RoutineTask != nil {
+				routineTasks[*item.RoutineTaskID] = item.RoutineTask.Name
+			}
+			if r.agendaBefore > 0 {
+				r.addAgendaItem(item, now, out)
+			}
+		}
+	}
+
+	if !r.config.Reminders.AlarmPlans {
+		return nil
+	}
+	for routineTaskID, name := range routineTasks {
+		plans, err := r.backendClient.ListAlarmPlans(ctx, routineTaskID)
+		if err != nil {
+			return err
+		}
+		for _, plan := range plans {
+			r.addAlarmPlan(plan, name, now, out)
+		}
+	}
+	return nil
+}
+
+func (r *ReminderScheduler) addAgendaItem(item dto.AgendaItemEnrichedDto, now time.Time, out map[string]*reminderState) {
+	if item.StartAt == nil || item.Status == dto.AgendaItemStatusCompleted || item.Status == dto.AgendaItemStatusSkipped {
+		return
+	}
+	start, err := parseReminderTime(*item.StartAt)
+	if err != nil || now.Sub(start) > reminderKeepAfter {
+		return
+	}
+
+	title := "Agenda item"
+	taskID := ""
+	switch {
+	case item.Task != nil:
+		title = item.Task.Title
+		taskID = item.Task.ID
+	case item.RoutineTask != nil:
+		title = item.RoutineTask.Name
+	}
+
+	fireAt := start.Add(-r.agendaBefore)
+	id := "agenda:" + item.ID
+	out[id] = &reminderState{
+		Reminder: Reminder{
+			ID:     id,
+			Source: ReminderSourceAgenda,
+			Title:  title,
+			Body:   "Starts " + formatReminderTime(start, now),
+			TaskID: taskID,
+			DueAt:  start,
+			FireAt: fireAt,
+		},
+		scheduledAt: fireAt,
+		urgency:     service.UrgencyNormal,
+	}
+}
+
+func (r *ReminderScheduler) addAlarmPlan(plan dto.AlarmPlanDto, routineName string, now time.Time, out map[string]*reminderState) {
+	if plan.Status != dto.AlarmPlanStatusPending && plan.Status != dto.AlarmPlanStatusActive {
+		return
+	}
+	target, err := parseReminderTime(plan.TargetAt)
+	if err != nil || now.Sub(target) > reminderKeepAfter {
+		return
+	}
+
+	title := "Alarm"
+	switch plan.Type {
+	case "WAKE":
+		title = "Time to wake up"
+	case "SLEEP":
+		title = "Time for bed"
+	case "STEP":
+		title = "Step goal"
+	}
+
+	id := "alarm:" + plan.ID
+	out[id] = &reminderState{
+		Reminder: Reminder{
+			ID:     id,
+			Source: ReminderSourceAlarmPlan,
+			Title:  title,
+			Body:   routineName,
+			DueAt:  target,
+			FireAt: target,
+		},
+		scheduledAt: target,
+		repeat:      time.Duration(plan.RepeatIntervalMinutes) * time.Minute,
+		urgency:     service.UrgencyCritical,
+	}
+}
+
+// fireDue rings every reminder whose time has come. Alarms with a repeat
+// interval ring again until dismissed, for at most reminderKeepAfter.
+func (r *ReminderScheduler) fireDue() {
+	now := time.Now()
+	var due []reminderState
+
+	r.mu.Lock()
+	for _, state := range r.reminders {
+		if state.dismissed || now.Before(state.FireAt) || !state.rangAt.Before(state.FireAt) {
+			continue
+		}
+
+		state.rangAt = now
+		state.Fired = true
+		state.Snoozed = false
+		due = append(due, *state)
+
+		if state.repeat > 0 && now.Add(state.repeat).Before(state.DueAt.Add(reminderKeepAfter)) {
+			state.FireAt = now.Add(state.repeat)
+		}
+	}
+	r.mu.Unlock()
+
+	sort.Slice(due, func(i, j int) bool { return due[i].FireAt.Before(due[j].FireAt) })
+	for _, state := range due {
+		fmt.Printf("[Reminders] %s: %s\n", state.Title, state.Body)
+		if r.notifier != nil {
+			if err := r.notifier.Notify(state.Title, state.Body, state.urgency); err != nil {
+				fmt.Printf("[Reminders] Failed to notify: %v\n", err)
+			}
+		}
+		if r.onFire != nil {
+			r.onFire(state.Reminder)
+		}
+	}
+}
+
+// List returns the reminders that are scheduled or have rung and were not
+// dismissed, soonest first.
+func (r *ReminderScheduler) List() []Reminder {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reminders := make([]Reminder, 0, len(r.reminders))
+	for _, state := range r.reminders {
+		if !state.dismissed {
+			reminders = append(reminders, state.Reminder)
+		}
+	}
+	sort.Slice(reminders, func(i, j int) bool { return reminders[i].FireAt.Before(reminders[j].FireAt) })
+	return reminders
+}
+
+// Snooze rings a reminder again after d; zero uses the configured snooze.
+func (r *ReminderScheduler) Snooze(id string, d time.Duration) (*Reminder, error) {
+	if d <= 0 {
+		d = time.Duration(r.config.Reminders.SnoozeMinutes) * time.Minute
+	}
+	if d <= 0 {
+		d = 10 * time.Minute
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, err := r.findLocked(id)
+	if err != nil {
+		return nil, err
+	}
+	state.FireAt = time.Now().Add(d)
+	state.Snoozed = true
+	state.dismissed = false
+
+	reminder := state.Reminder
+	return &reminder, nil
+}
+
+// Dismiss silences a reminder, including further alarm repeats.
+func (r *ReminderScheduler) Dismiss(id string) (*Reminder, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	state, err := r.findLocked(id)
+	if err != nil {
+		return nil, err
+	}
+	state.dismissed = true
+
+	reminder := state.Reminder
+	return &reminder, nil
+}
+
+// findLocked finds a reminder by ID or by a prefix matching only one of
+// the listed reminders.
+func (r *ReminderScheduler) findLocked(id string) (*reminderState, error) {
+	if state, ok := r.reminders[id]; ok {
+		return state, nil
+	}
+
+	var match *reminderState
+	for key, state := range r.reminders {
+		if !state.dismissed && strings.HasPrefix(key, id) {
+			if match != nil {
+				return nil, fmt.Errorf("reminder ID %s is ambiguous", id)
+			}
+			match = state
+		}
+	}
+	if match == nil {
+		return nil, fmt.Errorf("reminder %s not found", id)
+	}
+	return match, nil
+}
+
+// agendaViewItems returns every item of a day view.
+func agendaViewItems(view *dto.AgendaViewDto) []dto.AgendaItemEnrichedDto {
+	items := append([]dto.AgendaItemEnrichedDto{}, view.AllDayItems...)
+	for _, hour := range view.Hours {
+		items = append(items, hour.Items...)
+	}
+	if special := view.SpecialItems; special != nil {
+		for _, item := range []*dto.AgendaItemEnrichedDto{special.Wakeup, special.Sleep, special.Step} {
+			if item != nil {
+				items = append(items, *item)
+			}
+		}
+	}
+	return items
+}
+
+// localTimezone returns the IANA name of the local time zone, which the
+// agenda view needs to cut days at local midnight.
+func localTimezone() string {
+	if tz := time.Local.String(); tz != "Local" {
+		return tz
+	}
+	if env := os.Getenv("TZ"); env != "" {
+		return env
+	}
+	if target, err := os.Readlink("/etc/localtime"); err == nil {
+		if idx := strings.Index(target, "/zoneinfo/"); idx != -1 {
+			return target[idx+len("/zoneinfo/"):]
+		}
+	}
+	if data, err := os.ReadFile("/etc/timezone"); err == nil {
+		if tz := strings.TrimSpace(string(data)); tz != "" {
+			return tz
+		}
+	}
+	return "UTC"
+}
+
+// parseReminderTime reads the backend's timestamps; a bare date means
+// midnight local time.
+func parseReminderTime(s string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	return time.ParseInLocation("2006-01-02", s, time.Local)
+}
+
+// relativeDue phrases when a task is due as seen from its reminder, such
+// as "in 1h" or "now".
+func relativeDue(due, now time.Time, before time.Duration) string {
+	if before <= 0 || !due.After(now) {
+		return "now"
+	}
+	if before%(24*time.Hour) == 0 {
+		return fmt.Sprintf("in %dd", before/(24*time.Hour))
+	}
+	// Drop zero minutes and seconds only: 1h30m0s is 1h30m, not 1h3
+	s := before.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return "in " + s
+}
+
+func formatReminderTime(t, now time.Time) string {
+	t = t.Local()
+	y, m, d := t.Date()
+	ny, nm, nd := now.Local().Date()
+	switch {
+	case y == ny && m == nm && d == nd:
+		return "today at " + t.Format("15:04")
+	case y == ny && m == nm && d == nd+1:
+		return "tomorrow at " + t.Format("15:04")
+	}
+	return t.Format("Mon Jan 2 at 15:04")
+}
+
+// taskLabel prefixes a task's title with its key, such as "CAD-12".
+func taskLabel(task dto.TaskDto) string {
+	if key := taskKey(task); key != task.ID {
+		return key + " " + task.Title
+	}
+	return task.Title
+}
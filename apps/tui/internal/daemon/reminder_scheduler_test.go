@@ -0,0 +1,140 @@
+package daemon
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/domain/service"
+	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/fakebackend"
+)
+
+// recordingNotifier keeps the titles of the notifications it shows.
+type recordingNotifier struct {
+	shown []string
+}
+
+func (n *recordingNotifier) Notify(title, body string, urgency service.Urgency) error {
+	n.shown = append(n.shown, title)
+	return nil
+}
+
+func (n *recordingNotifier) IsAvailable() bool {
+	return true
+}
+
+func TestRelativeDue(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	tests := []struct {
+		due    time.Time
+		before time.Duration
+		want   string
+	}{
+		{now.Add(time.Hour), time.Hour, "in 1h"},
+		{now.Add(90 * time.Minute), 90 * time.Minute, "in 1h30m"},
+		{now.Add(15 * time.Minute), 15 * time.Minute, "in 15m"},
+		{now.Add(48 * time.Hour), 48 * time.Hour, "in 2d"},
+		{now.Add(time.Minute), 0, "now"},
+		{now.Add(-time.Minute), time.Hour, "now"},
+	}
+	for _, tt := range tests {
+		if got := relativeDue(tt.due, now, tt.before); got != tt.want {
+			t.Errorf("relativeDue(%s before) = %q, want %q", tt.before, got, tt.want)
+		}
+	}
+}
+
+func TestReminderSchedulerDueTasks(t *testing.T) {
+	backend := fakebackend.New()
+	backend.Seed()
+	_, client := newFakeTimeTracking(backend.Transport())
+	ctx := context.Background()
+
+	task := firstTask(t, client)
+	setDue := func(due time.Time) {
+		t.Helper()
+		s := due.UTC().Format(time.RFC3339)
+		if _, err := client.UpdateTask(ctx, task.ID, dto.TaskUpdateRequest{DueDate: &s}); err != nil {
+			t.Fatalf("UpdateTask: %v", err)
+		}
+	}
+	// The hour before is five minutes gone, recent enough to still ring
+	setDue(time.Now().Add(55 * time.Minute))
+
+	cfg := &config.Config{Reminders: config.RemindersConfig{DueBefore: []string{"1h", "15m", "soon"}}}
+	notifier := &recordingNotifier{}
+	r := NewReminderScheduler(cfg, client, notifier)
+	var fired []string
+	r.SetFireHandler(func(reminder Reminder) {
+		if reminder.TaskID == task.ID {
+			fired = append(fired, reminder.ID)
+		}
+	})
+
+	hourBefore, quarterBefore := "due:"+task.ID+":60", "due:"+task.ID+":15"
+	listed := func() string {
+		var ids []string
+		for _, reminder := range r.List() {
+			if reminder.TaskID == task.ID {
+				ids = append(ids, reminder.ID)
+			}
+		}
+		return strings.Join(ids, " ")
+	}
+
+	r.refresh(ctx)
+	if got, want := listed(), hourBefore+" "+quarterBefore; got != want {
+		t.Fatalf("listed %s, want %s", got, want)
+	}
+
+	r.fireDue()
+	r.fireDue()
+	if got := strings.Join(fired, " "); got != hourBefore {
+		t.Errorf("fired %s, want only %s and only once", got, hourBefore)
+	}
+	if len(notifier.shown) == 0 || !strings.HasPrefix(notifier.shown[len(notifier.shown)-1], "Due in 1h: ") {
+		t.Errorf("notifications = %q, want one for the task due in an hour", notifier.shown)
+	}
+
+	// A refresh keeps a reminder that rang from ringing again
+	r.refresh(ctx)
+	r.fireDue()
+	if len(fired) != 1 {
+		t.Errorf("fired %v after a refresh, want no repeat", fired)
+	}
+
+	if _, err := r.Snooze("due:"+task.ID, time.Millisecond); err == nil {
+		t.Error("Snooze with an ambiguous prefix succeeded, want an error")
+	}
+	if _, err := r.Snooze(hourBefore[:len(hourBefore)-1], time.Millisecond); err != nil {
+		t.Fatalf("Snooze by prefix: %v", err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	r.fireDue()
+	if got := strings.Join(fired, " "); got != hourBefore+" "+hourBefore {
+		t.Errorf("fired %s after a snooze, want %s again", got, hourBefore)
+	}
+
+	if _, err := r.Dismiss(hourBefore); err != nil {
+		t.Fatalf("Dismiss: %v", err)
+	}
+	if got := listed(); got != quarterBefore {
+		t.Errorf("listed %s after a dismissal, want %s", got, quarterBefore)
+	}
+
+	// Due dates long past are dropped, and missed reminders stay quiet
+	setDue(time.Now().Add(-2 * time.Hour))
+	r.refresh(ctx)
+	if got := listed(); got != "" {
+		t.Errorf("listed %s for a task due hours ago, want none", got)
+	}
+	setDue(time.Now().Add(-30 * time.Minute))
+	r.refresh(ctx)
+	r.fireDue()
+	if got := listed(); got != "" || len(fired) != 2 {
+		t.Errorf("listed %q and fired %v for missed reminders, want none", got, fired)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net"
+	"net/http"
 	"os"
 	"path/filepath"
 	"sync"
@@ -12,21 +13,32 @@ import (
 	"time"
 
 	"cadence/internal/application/dto"
+	"cadence/internal/domain/entity"
 	"cadence/internal/domain/service"
 	"cadence/internal/infrastructure/auth"
 	"cadence/internal/infrastructure/config"
 	"cadence/internal/infrastructure/httpclient"
+	"cadence/internal/infrastructure/tracing"
 )
 
+const traceHistorySize = 50
+
 type Server struct {
 	config              *config.Config
 	backendClient       *httpclient.BackendClient
-	tokenStore          *auth.TokenStore
+	accounts            *auth.Accounts
 	sessionTracker      service.SessionTracker
+	idleDetector        service.IdleDetector
+	notifier            service.Notifier
 	vcsProvider         service.VCSProvider
 	changeWatcher       service.ChangeWatcher
 	sessionManager      *SessionManager
 	timeTrackingManager *TimeTrackingManager
+	reminderScheduler   *ReminderScheduler
+	actionEngine        *ActionEngine
+	hookRunner          *HookRunner
+	traces              *tracing.Recorder
+	auth                authState
 	listener            net.Listener
 	mu                  sync.RWMutex
 	subscribers         map[string]map[net.Conn]chan *Notification
@@ -37,28 +49,70 @@ func NewServer(cfg *config.Config) (*Server, error) {
 	timeout := time.Duration(cfg.Backend.Timeout) * time.Second
 	client := httpclient.NewBackendClient(cfg.Backend.URL, timeout)
 
-	tokenStore, err := auth.NewTokenStore()
-	if err != nil {
-		return nil, fmt.Errorf("failed to create token store: %w", err)
+	if !cfg.Backend.DisableHTTPCache {
+		if cache, err := newResponseCache(); err != nil {
+			fmt.Printf("HTTP cache disabled: %v\n", err)
+		} else {
+			client.SetCache(cache)
+		}
 	}
 
-	if token, err := tokenStore.Load(); err == nil && token != "" {
-		client.SetAuthToken(token)
-		fmt.Println("Auth token loaded")
+	accounts, err := auth.NewAccounts(cfg.Auth)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open accounts: %w", err)
 	}
 
-	return &Server{
+	s := &Server{
 		config:        cfg,
 		backendClient: client,
-		tokenStore:    tokenStore,
+		accounts:      accounts,
+		traces:        tracing.NewRecorder(time.Duration(cfg.Daemon.SlowRequestMs)*time.Millisecond, traceHistorySize),
 		subscribers:   make(map[string]map[net.Conn]chan *Notification),
-	}, nil
+	}
+	creds, err := s.loadCredentials()
+	if err != nil {
+		return nil, err
+	}
+	if creds.token != "" {
+		if err := s.applyCredentials(creds); err != nil {
+			return nil, err
+		}
+		fmt.Printf("Auth token loaded for account %s\n", creds.account)
+	}
+	s.auth.name = creds.account
+
+	// Sessions may have been extended since we last looked, so re-check
+	// with the backend instead of assuming the token is dead.
+	s.auth.onExpiration = func() {
+		_, _ = s.refreshSession(context.Background())
+	}
+	client.SetUnauthorizedHandler(func() {
+		s.requireAuth("backend rejected the session token")
+	})
+
+	return s, nil
+}
+
+func newResponseCache() (*httpclient.ResponseCache, error) {
+	cacheDir, err := httpclient.DefaultCacheDir()
+	if err != nil {
+		return nil, err
+	}
+	return httpclient.NewResponseCache(cacheDir)
 }
 
 func (s *Server) SetSessionTracker(st service.SessionTracker) {
 	s.sessionTracker = st
 }
 
+func (s *Server) SetIdleDetector(d service.IdleDetector) {
+	s.idleDetector = d
+}
+
+func (s *Server) SetNotifier(n service.Notifier) {
+	s.notifier = n
+}
+
 func (s *Server) SetVCSProvider(vcs service.VCSProvider) {
 	s.vcsProvider = vcs
 }
@@ -67,6 +121,10 @@ func (s *Server) SetChangeWatcher(cw service.ChangeWatcher) {
 	s.changeWatcher = cw
 }
 
+func (s *Server) SetBackendTransport(rt http.RoundTripper) {
+	s.backendClient.SetTransport(rt)
+}
+
 func (s *Server) Start() error {
 	if err := s.acquireLock(); err != nil {
 		return err
@@ -74,6 +132,16 @@ func (s *Server) Start() error {
 
 	ctx := context.Background()
 
+	if s.config.Hooks.Enabled {
+		s.hookRunner = NewHookRunner(s.config.Hooks)
+	}
+
+	go func() {
+		if whoami, err := s.refreshSession(ctx); err == nil && whoami.User != nil {
+			fmt.Printf("Signed in as %s\n", whoami.User.Email)
+		}
+	}()
+
 	if s.sessionTracker != nil && s.changeWatcher != nil {
 		s.sessionManager = NewSessionManager(
 			s.config,
@@ -82,6 +150,9 @@ func (s *Server) Start() error {
 			s.changeWatcher,
 			s.vcsProvider,
 		)
+		s.sessionManager.SetPromptNotifier(func(prompt ProjectPrompt) {
+			s.broadcast(&Notification{Type: NotificationProjectPrompt, Data: prompt})
+		})
 
 		if err := s.sessionManager.Start(ctx); err != nil {
 			return fmt.Errorf("failed to start session manager: %w", err)
@@ -96,6 +167,15 @@ func (s *Server) Start() error {
 			s.sessionTracker,
 			s.vcsProvider,
 		)
+		if s.idleDetector != nil {
+			s.timeTrackingManager.SetIdleDetector(s.idleDetector)
+		}
+		if s.sessionManager != nil {
+			s.timeTrackingManager.SetSessionManager(s.sessionManager)
+		}
+		s.timeTrackingManager.SetFocusNotifier(func(status FocusStatus) {
+			s.broadcast(&Notification{Type: NotificationFocusPhase, Data: status})
+		})
 
 		if err := s.timeTrackingManager.Start(ctx); err != nil {
 			return fmt.Errorf("failed to start time tracking: %w", err)
@@ -103,6 +183,28 @@ func (s *Server) Start() error {
 		fmt.Println("Time tracking started")
 	}
 
+	if s.config.Reminders.Enabled {
+		s.reminderScheduler = NewReminderScheduler(s.config, s.backendClient, s.notifier)
+		s.reminderScheduler.SetFireHandler(func(reminder Reminder) {
+			s.broadcast(&Notification{Type: NotificationReminder, Data: reminder})
+		})
+		s.reminderScheduler.Start(ctx)
+		fmt.Println("Reminders started")
+	}
+
+	if s.config.Actions.Enabled {
+		s.actionEngine = NewActionEngine(s.config, s.backendClient, s.timeTrackingManager, s.notifier)
+		s.actionEngine.SetTaskChangedHandler(func(notificationType string, task *dto.TaskDto) {
+			s.notifySubscribers(task.BoardID, &Notification{
+				Type:    notificationType,
+				BoardID: task.BoardID,
+				Data:    task,
+			})
+		})
+		s.actionEngine.Start(ctx)
+		fmt.Printf("Action engine started (%d rules)\n", len(s.config.Actions.Rules))
+	}
+
 	socketDir := s.config.Daemon.SocketDir
 	if err := os.MkdirAll(socketDir, 0755); err != nil {
 		return fmt.Errorf("failed to create socket directory: %w", err)
@@ -145,6 +247,7 @@ func (s *Server) handleConnection(conn net.Conn) {
 	decoder := json.NewDecoder(conn)
 	encoder := json.NewEncoder(conn)
 
+	waitStart := time.Now()
 	for {
 		var req Request
 		if err := decoder.Decode(&req); err != nil {
@@ -156,20 +259,48 @@ func (s *Server) handleConnection(conn net.Conn) {
 			return
 		}
 
-		resp := s.handleRequest(&req)
-		if err := encoder.Encode(resp); err != nil {
+		if req.RequestID == "" {
+			req.RequestID = tracing.NewRequestID()
+		}
+		trace := tracing.NewTrace(req.RequestID, req.Type, waitStart)
+		trace.AddSpan("socket_wait", time.Since(waitStart), nil)
+
+		resp := s.handleRequest(tracing.WithTrace(context.Background(), trace), &req)
+		resp.RequestID = req.RequestID
+
+		encodeStart := time.Now()
+		err := encoder.Encode(resp)
+		trace.AddSpan("encode_response", time.Since(encodeStart), err)
+		s.finishTrace(trace, resp)
+		if err != nil {
 			return
 		}
 
 		if req.Type != RequestUnsubscribe && req.Type != RequestPing {
 			return
 		}
+		waitStart = time.Now()
 	}
 }
 
-func (s *Server) handleRequest(req *Request) *Response {
-	ctx := context.Background()
+// finishTrace logs requests that were slow or failed and keeps them for
+// get_traces. Pings are skipped since their socket wait is idle time.
+func (s *Server) finishTrace(trace *tracing.Trace, resp *Response) {
+	if trace.Type == RequestPing {
+		return
+	}
 
+	trace.Finish(time.Now(), resp.Error)
+	if s.traces.Record(trace) {
+		if resp.Error != "" {
+			fmt.Printf("[Trace] failed request %s\n", trace)
+		} else {
+			fmt.Printf("[Trace] slow request %s\n", trace)
+		}
+	}
+}
+
+func (s *Server) handleRequest(ctx context.Context, req *Request) *Response {
 	switch req.Type {
 	case RequestGetBoard:
 		return s.handleGetBoard(ctx, req)
@@ -202,11 +333,60 @@ func (s *Server) handleRequest(req *Request) *Response {
 		return s.handleStopTimer(ctx, req)
 	case RequestGetActiveTimers:
 		return s.handleGetActiveTimers(ctx)
+	case RequestPauseTimer:
+		return s.handlePauseTimer(ctx, req)
+	case RequestResumeTimer:
+		return s.handleResumeTimer(ctx, req)
+	case RequestUpdateTimer:
+		return s.handleUpdateTimer(ctx, req)
+	case RequestDiscardTimer:
+		return s.handleDiscardTimer(ctx, req)
+	case RequestLogTime:
+		return s.handleLogTime(ctx, req)
+	case RequestGetIdlePeriods:
+		return s.handleGetIdlePeriods(ctx)
+	case RequestResolveIdle:
+		return s.handleResolveIdlePeriod(ctx, req)
+	case RequestStartFocus:
+		return s.handleStartFocus(ctx, req)
+	case RequestStopFocus:
+		return s.handleStopFocus(ctx)
+	case RequestSkipFocusPhase:
+		return s.handleSkipFocusPhase(ctx)
+	case RequestGetFocusStatus:
+		return s.handleGetFocusStatus()
+
+	case RequestListReminders:
+		return s.handleListReminders()
+	case RequestSnoozeReminder:
+		return s.handleSnoozeReminder(req)
+	case RequestDismissReminder:
+		return s.handleDismissReminder(req)
+	case RequestTestNotification:
+		return s.handleTestNotification()
+
+	case RequestListActionRules:
+		return s.handleListActionRules()
+	case RequestDryRunActions:
+		return s.handleDryRunActions(ctx, req)
+
+	case RequestReportShell:
+		return s.handleReportShell(req)
+	case RequestGetProjectPrompts:
+		return s.handleGetProjectPrompts()
+	case RequestResolveProjectPrompt:
+		return s.handleResolveProjectPrompt(ctx, req)
 
 	case RequestListProjects:
 		return s.handleListProjects(ctx)
 	case RequestGetProject:
 		return s.handleGetProject(ctx, req)
+	case RequestGetTask:
+		return s.handleGetTask(ctx, req)
+	case RequestGetTimeSummary:
+		return s.handleGetTimeSummary(ctx, req)
+	case RequestGetDailyLogs:
+		return s.handleGetDailyTimeLogs(ctx, req)
 
 	case RequestListNotes:
 		return s.handleListNotes(ctx, req)
@@ -229,7 +409,11 @@ func (s *Server) handleRequest(req *Request) *Response {
 		return s.handleCompleteAgendaItem(ctx, req)
 
 	case RequestReloadToken:
-		return s.handleReloadToken()
+		return s.handleReloadToken(ctx)
+	case RequestWhoAmI:
+		return s.handleWhoAmI(ctx)
+	case RequestGetTraces:
+		return s.handleGetTraces()
 
 	default:
 		return &Response{
@@ -316,6 +500,10 @@ func (s *Server) handleAddTask(ctx context.Context, req *Request) *Response {
 		createReq.Priority = &payload.Priority
 	}
 
+	if err := s.runPreHooks(ctx, NotificationTaskCreated, nil, payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
 	task, err := s.backendClient.CreateTask(ctx, createReq)
 	if err != nil {
 		return &Response{Success: false, Error: err.Error()}
@@ -326,6 +514,7 @@ func (s *Server) handleAddTask(ctx context.Context, req *Request) *Response {
 		BoardID: task.BoardID,
 		Data:    task,
 	})
+	s.dispatchAction(ActionEvent{Type: ActionEventTaskCreated, Task: task})
 
 	return &Response{Success: true, Data: task}
 }
@@ -339,6 +528,10 @@ func (s *Server) handleMoveTask(ctx context.Context, req *Request) *Response {
 	moveReq := dto.TaskMoveRequest{
 		TargetColumnID: payload.TargetColumnID,
 	}
+	previous := s.taskBefore(ctx, payload.TaskID, NotificationTaskMoved)
+	if err := s.runPreHooks(ctx, NotificationTaskMoved, previous, payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
 
 	task, err := s.backendClient.MoveTask(ctx, payload.TaskID, moveReq)
 	if err != nil {
@@ -350,6 +543,7 @@ func (s *Server) handleMoveTask(ctx context.Context, req *Request) *Response {
 		BoardID: task.BoardID,
 		Data:    task,
 	})
+	s.dispatchAction(ActionEvent{Type: ActionEventTaskMoved, Task: task, Previous: previous})
 
 	return &Response{Success: true, Data: task}
 }
@@ -396,6 +590,11 @@ func (s *Server) handleUpdateTask(ctx context.Context, req *Request) *Response {
 		}
 	}
 
+	previous := s.taskBefore(ctx, payload.TaskID, NotificationTaskUpdated)
+	if err := s.runPreHooks(ctx, NotificationTaskUpdated, previous, payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
 	task, err := s.backendClient.UpdateTask(ctx, payload.TaskID, updateReq)
 	if err != nil {
 		return &Response{Success: false, Error: err.Error()}
@@ -406,6 +605,7 @@ func (s *Server) handleUpdateTask(ctx context.Context, req *Request) *Response {
 		BoardID: task.BoardID,
 		Data:    task,
 	})
+	s.dispatchAction(ActionEvent{Type: ActionEventTaskUpdated, Task: task, Previous: previous})
 
 	return &Response{Success: true, Data: task}
 }
@@ -416,10 +616,25 @@ func (s *Server) handleDeleteTask(ctx context.Context, req *Request) *Response {
 		return &Response{Success: false, Error: err.Error()}
 	}
 
+	// Read first: the notification needs the task's board.
+	previous, _ := s.backendClient.GetTask(ctx, payload.TaskID)
+	if err := s.runPreHooks(ctx, NotificationTaskDeleted, previous, payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
 	if err := s.backendClient.DeleteTask(ctx, payload.TaskID); err != nil {
 		return &Response{Success: false, Error: err.Error()}
 	}
 
+	if previous != nil {
+		s.notifySubscribers(previous.BoardID, &Notification{
+			Type:    NotificationTaskDeleted,
+			BoardID: previous.BoardID,
+			Data:    previous,
+		})
+		s.dispatchAction(ActionEvent{Type: ActionEventTaskDeleted, Task: previous})
+	}
+
 	return &Response{Success: true, Data: "task deleted"}
 }
 
@@ -479,17 +694,26 @@ func (s *Server) handleStartTimer(ctx context.Context, req *Request) *Response {
 		return &Response{Success: false, Error: err.Error()}
 	}
 
+	if payload.ProjectID == "" && payload.TaskID != "" {
+		taskID, projectID, err := s.timeTrackingManager.ResolveTask(ctx, payload.TaskID)
+		if err != nil {
+			return &Response{Success: false, Error: err.Error()}
+		}
+		payload.TaskID, payload.ProjectID = taskID, projectID
+	}
+	if payload.ProjectID == "" {
+		return &Response{Success: false, Error: "project_id or task_id is required"}
+	}
+
 	log, err := s.timeTrackingManager.StartTimer(ctx, payload.ProjectID, payload.TaskID, payload.Description)
 	if err != nil {
 		return &Response{Success: false, Error: err.Error()}
 	}
+	s.dispatchAction(ActionEvent{Type: ActionEventTimerStarted, TaskID: log.TaskID()})
 
-	return &Response{Success: true, Data: map[string]interface{}{
-		"id":         log.ID(),
-		"project_id": log.ProjectID(),
-		"start_time": log.StartTime(),
-		"running":    log.IsRunning(),
-	}}
+	data := timerData(log)
+	data["running"] = log.IsRunning()
+	return &Response{Success: true, Data: data}
 }
 
 func (s *Server) handleStopTimer(ctx context.Context, req *Request) *Response {
@@ -502,18 +726,122 @@ func (s *Server) handleStopTimer(ctx context.Context, req *Request) *Response {
 		return &Response{Success: false, Error: err.Error()}
 	}
 
-	log, err := s.timeTrackingManager.StopTimer(ctx, payload.ProjectID, payload.TaskID)
+	log, err := s.timeTrackingManager.StopTimer(ctx, TimerRef{
+		ID:        payload.TimerID,
+		ProjectID: payload.ProjectID,
+		TaskID:    payload.TaskID,
+	})
 	if err != nil {
 		return &Response{Success: false, Error: err.Error()}
 	}
 
-	return &Response{Success: true, Data: map[string]interface{}{
+	data := timerData(log)
+	data["end_time"] = log.EndTime()
+	return &Response{Success: true, Data: data}
+}
+
+func (s *Server) handlePauseTimer(ctx context.Context, req *Request) *Response {
+	return s.handleTimerAction(req, s.timeTrackingManager.PauseTimer)
+}
+
+func (s *Server) handleResumeTimer(ctx context.Context, req *Request) *Response {
+	return s.handleTimerAction(req, s.timeTrackingManager.ResumeTimer)
+}
+
+func (s *Server) handleDiscardTimer(ctx context.Context, req *Request) *Response {
+	return s.handleTimerAction(req, s.timeTrackingManager.DiscardTimer)
+}
+
+func (s *Server) handleUpdateTimer(ctx context.Context, req *Request) *Response {
+	if s.timeTrackingManager == nil {
+		return &Response{Success: false, Error: "time tracking not available"}
+	}
+
+	var payload UpdateTimerPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	log, err := s.timeTrackingManager.UpdateTimer(ctx, payload.ref(), payload.Description, payload.NewTaskID)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	return &Response{Success: true, Data: timerData(log)}
+}
+
+func (s *Server) handleLogTime(ctx context.Context, req *Request) *Response {
+	if s.timeTrackingManager == nil {
+		return &Response{Success: false, Error: "time tracking not available"}
+	}
+
+	var payload LogTimePayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	if payload.TaskID != "" {
+		taskID, projectID, err := s.timeTrackingManager.ResolveTask(ctx, payload.TaskID)
+		if err != nil {
+			return &Response{Success: false, Error: err.Error()}
+		}
+		payload.TaskID = taskID
+		if payload.ProjectID == "" {
+			payload.ProjectID = projectID
+		}
+	}
+	if payload.ProjectID == "" {
+		return &Response{Success: false, Error: "project_id or task_id is required"}
+	}
+
+	log, err := s.timeTrackingManager.LogTime(ctx, payload.ProjectID, payload.TaskID, payload.Start,
+		time.Duration(payload.DurationSeconds)*time.Second, payload.Description, payload.AllowOverlap)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	return &Response{Success: true, Data: log}
+}
+
+func (s *Server) handleTimerAction(req *Request, action func(TimerRef) (*entity.TimeLog, error)) *Response {
+	if s.timeTrackingManager == nil {
+		return &Response{Success: false, Error: "time tracking not available"}
+	}
+
+	var payload TimerPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	log, err := action(payload.ref())
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	return &Response{Success: true, Data: timerData(log)}
+}
+
+func (p TimerPayload) ref() TimerRef {
+	return TimerRef{ID: p.TimerID, ProjectID: p.ProjectID, TaskID: p.TaskID}
+}
+
+func timerData(log *entity.TimeLog) map[string]interface{} {
+	data := map[string]interface{}{
 		"id":         log.ID(),
 		"project_id": log.ProjectID(),
+		"source":     log.Source().String(),
 		"start_time": log.StartTime(),
-		"end_time":   log.EndTime(),
 		"duration":   log.Duration().Seconds(),
-	}}
+		"paused":     log.IsPaused(),
+		"segments":   len(log.Segments()),
+	}
+	if log.TaskID() != "" {
+		data["task_id"] = log.TaskID()
+	}
+	if log.Description() != "" {
+		data["description"] = log.Description()
+	}
+	return data
 }
 
 func (s *Server) handleGetActiveTimers(ctx context.Context) *Response {
@@ -525,20 +853,275 @@ func (s *Server) handleGetActiveTimers(ctx context.Context) *Response {
 	result := make([]map[string]interface{}, 0, len(timers))
 
 	for _, t := range timers {
-		entry := map[string]interface{}{
-			"id":         t.ID(),
-			"project_id": t.ProjectID(),
-			"source":     t.Source().String(),
-			"start_time": t.StartTime(),
-			"duration":   t.Duration().Seconds(),
+		result = append(result, timerData(t))
+	}
+
+	return &Response{Success: true, Data: result}
+}
+
+func (s *Server) handleGetIdlePeriods(ctx context.Context) *Response {
+	if s.timeTrackingManager == nil {
+		return &Response{Success: false, Error: "time tracking not available"}
+	}
+
+	periods := s.timeTrackingManager.IdlePeriods()
+	if len(periods) > 0 {
+		// Names are for display only, so a failed lookup is not an error.
+		if projects, err := s.backendClient.ListProjects(ctx, 1, 100); err == nil {
+			for i := range periods {
+				for _, p := range projects.Items {
+					if p.ID == periods[i].ProjectID {
+						periods[i].ProjectName = p.Name
+					}
+				}
+			}
 		}
-		if t.TaskID() != "" {
-			entry["task_id"] = t.TaskID()
+	}
+
+	return &Response{Success: true, Data: periods}
+}
+
+func (s *Server) handleResolveIdlePeriod(ctx context.Context, req *Request) *Response {
+	if s.timeTrackingManager == nil {
+		return &Response{Success: false, Error: "time tracking not available"}
+	}
+
+	var payload ResolveIdlePeriodPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	if err := s.timeTrackingManager.ResolveIdlePeriod(ctx, payload.ID, payload.Keep); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	return &Response{Success: true, Data: "resolved"}
+}
+
+func (s *Server) handleStartFocus(ctx context.Context, req *Request) *Response {
+	if s.timeTrackingManager == nil {
+		return &Response{Success: false, Error: "time tracking not available"}
+	}
+
+	var payload StartFocusPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	if payload.ProjectID == "" && payload.TaskID != "" {
+		taskID, projectID, err := s.timeTrackingManager.ResolveTask(ctx, payload.TaskID)
+		if err != nil {
+			return &Response{Success: false, Error: err.Error()}
 		}
-		result = append(result, entry)
+		payload.TaskID, payload.ProjectID = taskID, projectID
 	}
 
-	return &Response{Success: true, Data: result}
+	status, err := s.timeTrackingManager.StartFocus(payload.ProjectID, payload.TaskID, payload.Description, FocusOptions{
+		Work:           time.Duration(payload.WorkMinutes) * time.Minute,
+		ShortBreak:     time.Duration(payload.ShortBreakMinutes) * time.Minute,
+		LongBreak:      time.Duration(payload.LongBreakMinutes) * time.Minute,
+		LongBreakEvery: payload.LongBreakEvery,
+		Cycles:         payload.Cycles,
+	})
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	return &Response{Success: true, Data: status}
+}
+
+func (s *Server) handleStopFocus(ctx context.Context) *Response {
+	if s.timeTrackingManager == nil {
+		return &Response{Success: false, Error: "time tracking not available"}
+	}
+
+	status, err := s.timeTrackingManager.StopFocus(ctx)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	return &Response{Success: true, Data: status}
+}
+
+func (s *Server) handleSkipFocusPhase(ctx context.Context) *Response {
+	if s.timeTrackingManager == nil {
+		return &Response{Success: false, Error: "time tracking not available"}
+	}
+
+	status, err := s.timeTrackingManager.SkipFocusPhase(ctx)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	return &Response{Success: true, Data: status}
+}
+
+// handleGetFocusStatus returns the running focus session, or null.
+func (s *Server) handleGetFocusStatus() *Response {
+	if s.timeTrackingManager == nil {
+		return &Response{Success: false, Error: "time tracking not available"}
+	}
+	return &Response{Success: true, Data: s.timeTrackingManager.FocusStatus()}
+}
+
+func (s *Server) handleListReminders() *Response {
+	if s.reminderScheduler == nil {
+		return &Response{Success: false, Error: "reminders not enabled"}
+	}
+	return &Response{Success: true, Data: s.reminderScheduler.List()}
+}
+
+func (s *Server) handleSnoozeReminder(req *Request) *Response {
+	if s.reminderScheduler == nil {
+		return &Response{Success: false, Error: "reminders not enabled"}
+	}
+
+	var payload SnoozeReminderPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	reminder, err := s.reminderScheduler.Snooze(payload.ID, time.Duration(payload.Minutes)*time.Minute)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	return &Response{Success: true, Data: reminder}
+}
+
+func (s *Server) handleDismissReminder(req *Request) *Response {
+	if s.reminderScheduler == nil {
+		return &Response{Success: false, Error: "reminders not enabled"}
+	}
+
+	var payload DismissReminderPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	reminder, err := s.reminderScheduler.Dismiss(payload.ID)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	return &Response{Success: true, Data: reminder}
+}
+
+func (s *Server) handleListActionRules() *Response {
+	if s.actionEngine == nil {
+		return &Response{Success: false, Error: "actions not enabled"}
+	}
+	return &Response{Success: true, Data: s.actionEngine.Rules()}
+}
+
+func (s *Server) handleDryRunActions(ctx context.Context, req *Request) *Response {
+	if s.actionEngine == nil {
+		return &Response{Success: false, Error: "actions not enabled"}
+	}
+
+	var payload DryRunActionsPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	plans, err := s.actionEngine.DryRun(ctx, payload)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	return &Response{Success: true, Data: plans}
+}
+
+func (s *Server) handleReportShell(req *Request) *Response {
+	tracker, ok := s.sessionTracker.(*ShellSessionTracker)
+	if !ok {
+		return &Response{Success: false, Error: "shell integration not enabled (session_tracking.tracker_type: shell)"}
+	}
+
+	var payload ReportShellPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	tracker.Report(payload)
+	return &Response{Success: true}
+}
+
+func (s *Server) handleGetProjectPrompts() *Response {
+	if s.sessionManager == nil {
+		return &Response{Success: true, Data: []ProjectPrompt{}}
+	}
+	return &Response{Success: true, Data: s.sessionManager.ProjectPrompts()}
+}
+
+func (s *Server) handleResolveProjectPrompt(ctx context.Context, req *Request) *Response {
+	if s.sessionManager == nil {
+		return &Response{Success: false, Error: "session tracking not available"}
+	}
+
+	var payload ResolveProjectPromptPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	if err := s.sessionManager.ResolveProjectPrompt(ctx, payload.ID, payload.Create); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	return &Response{Success: true, Data: "resolved"}
+}
+
+// taskBefore reads a task before a change, for pre- hooks and for rules
+// that compare with its old state. It returns nil when nothing needs it.
+func (s *Server) taskBefore(ctx context.Context, taskID, event string) *dto.TaskDto {
+	wanted := (s.actionEngine != nil && s.actionEngine.HasEventRules()) ||
+		(s.hookRunner != nil && s.hookRunner.HasHooks("pre-"+event))
+	if !wanted {
+		return nil
+	}
+	task, err := s.backendClient.GetTask(ctx, taskID)
+	if err != nil {
+		return nil
+	}
+	return task
+}
+
+// runPreHooks runs the pre- hooks of a change with the task as it is and
+// the request. An error means a hook refused the change.
+func (s *Server) runPreHooks(ctx context.Context, event string, task *dto.TaskDto, payload interface{}) error {
+	if s.hookRunner == nil {
+		return nil
+	}
+	hook := hookEvent{Event: "pre-" + event, Payload: payload}
+	if task != nil {
+		hook.BoardID = task.BoardID
+		hook.Data = task
+	}
+	return s.hookRunner.RunPre(ctx, hook)
+}
+
+// runHooks starts the hooks for a notification.
+func (s *Server) runHooks(notification *Notification) {
+	if s.hookRunner == nil {
+		return
+	}
+	s.hookRunner.Run(hookEvent{
+		Event:   notification.Type,
+		BoardID: notification.BoardID,
+		Data:    notification.Data,
+	})
+}
+
+func (s *Server) dispatchAction(event ActionEvent) {
+	if s.actionEngine != nil && s.actionEngine.HasEventRules() {
+		s.actionEngine.Dispatch(event)
+	}
+}
+
+// handleTestNotification sends a notification through the configured
+// notifiers, whether or not reminders are enabled.
+func (s *Server) handleTestNotification() *Response {
+	if s.notifier == nil {
+		return &Response{Success: false, Error: "no notifiers configured"}
+	}
+	if err := s.notifier.Notify("Cadence", "Notifications are working.", service.UrgencyNormal); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	return &Response{Success: true, Data: "sent"}
 }
 
 func (s *Server) handleListProjects(ctx context.Context) *Response {
@@ -550,6 +1133,48 @@ func (s *Server) handleListProjects(ctx context.Context) *Response {
 	return &Response{Success: true, Data: projects}
 }
 
+func (s *Server) handleGetTask(ctx context.Context, req *Request) *Response {
+	var payload GetTaskPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	task, err := s.backendClient.GetTask(ctx, payload.TaskID)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	return &Response{Success: true, Data: task}
+}
+
+func (s *Server) handleGetTimeSummary(ctx context.Context, req *Request) *Response {
+	var payload TimeSummaryPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	summary, err := s.backendClient.GetTimeSummary(ctx, payload.ProjectID, payload.Start, payload.End)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	return &Response{Success: true, Data: summary}
+}
+
+func (s *Server) handleGetDailyTimeLogs(ctx context.Context, req *Request) *Response {
+	var payload DailyTimeLogsPayload
+	if err := s.decodePayload(req.Payload, &payload); err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	logs, err := s.backendClient.GetDailyTimeLogs(ctx, payload.Date)
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+
+	return &Response{Success: true, Data: logs}
+}
+
 func (s *Server) handleGetProject(ctx context.Context, req *Request) *Response {
 	var payload struct {
 		ProjectID string `json:"project_id"`
@@ -719,16 +1344,40 @@ func (s *Server) handleCompleteAgendaItem(ctx context.Context, req *Request) *Re
 	return &Response{Success: true, Data: item}
 }
 
-func (s *Server) handleReloadToken() *Response {
-	token, err := s.tokenStore.Load()
+// handleReloadToken picks up a new login or a switch to another account
+// made with `cadence login` or `cadence auth use`.
+func (s *Server) handleReloadToken(ctx context.Context) *Response {
+	creds, err := s.loadCredentials()
+	if err != nil {
+		return &Response{Success: false, Error: err.Error()}
+	}
+	if creds.token == "" {
+		return &Response{Success: false, Error: fmt.Sprintf("no token stored for account %s", creds.account)}
+	}
+
+	if creds.account != s.auth.accountName() {
+		err = s.switchAccount(ctx, creds)
+	} else {
+		err = s.applyCredentials(creds)
+	}
 	if err != nil {
-		return &Response{Success: false, Error: fmt.Sprintf("failed to load token: %v", err)}
+		return &Response{Success: false, Error: err.Error()}
 	}
 
-	s.backendClient.SetAuthToken(token)
+	// Validate right away so the new expiry is tracked; a failure here is
+	// reported through whoami and auth_required rather than failing reload.
+	_, _ = s.refreshSession(ctx)
+
 	return &Response{Success: true, Data: "token reloaded"}
 }
 
+func (s *Server) handleGetTraces() *Response {
+	return &Response{Success: true, Data: TracesResponse{
+		SlowThresholdMs: s.traces.Threshold().Milliseconds(),
+		Traces:          s.traces.Recent(),
+	}}
+}
+
 func (s *Server) decodePayload(payload interface{}, target interface{}) error {
 	data, err := json.Marshal(payload)
 	if err != nil {
@@ -743,6 +1392,13 @@ func (s *Server) decodePayload(payload interface{}, target interface{}) error {
 }
 
 func (s *Server) Stop() error {
+	if s.reminderScheduler != nil {
+		s.reminderScheduler.Stop()
+	}
+	if s.actionEngine != nil {
+		s.actionEngine.Stop()
+	}
+
 	if s.timeTrackingManager != nil {
 		if err := s.timeTrackingManager.Stop(); err != nil {
 			fmt.Printf("Error stopping time tracking manager: %v\n", err)
@@ -764,6 +1420,9 @@ func (s *Server) Stop() error {
 }
 
 func (s *Server) lockFilePath() string {
+	if s.config.Profile != "" {
+		return filepath.Join(s.config.Daemon.SocketDir, fmt.Sprintf("cadence-%s.pid", s.config.Profile))
+	}
 	return filepath.Join(s.config.Daemon.SocketDir, "cadence.pid")
 }
 
@@ -841,6 +1500,8 @@ func (s *Server) handleSubscribe(conn net.Conn, encoder *json.Encoder, req *Requ
 }
 
 func (s *Server) notifySubscribers(boardID string, notification *Notification) {
+	s.runHooks(notification)
+
 	s.subMu.RLock()
 	defer s.subMu.RUnlock()
 
@@ -857,6 +1518,38 @@ func (s *Server) notifySubscribers(boardID string, notification *Notification) {
 	}
 }
 
+// broadcast sends a notification to every subscribed client, whatever
+// board it is watching.
+func (s *Server) broadcast(notification *Notification) {
+	s.runHooks(notification)
+
+	s.subMu.RLock()
+	defer s.subMu.RUnlock()
+
+	for _, subscribers := range s.subscribers {
+		for _, ch := range subscribers {
+			select {
+			case ch <- notification:
+			default:
+			}
+		}
+	}
+}
+
+// dropSubscribers ends every subscription. Clients notice the closed
+// connection and subscribe again to whatever board they load next.
+func (s *Server) dropSubscribers() {
+	s.subMu.Lock()
+	defer s.subMu.Unlock()
+
+	for _, subscribers := range s.subscribers {
+		for _, ch := range subscribers {
+			close(ch)
+		}
+	}
+	s.subscribers = make(map[string]map[net.Conn]chan *Notification)
+}
+
 func (s *Server) cleanupSubscriber(conn net.Conn) {
 	s.subMu.Lock()
 	defer s.subMu.Unlock()
@@ -3,6 +3,7 @@ package daemon
 import (
 	"context"
 	"fmt"
+	"os"
 	"path/filepath"
 	"strings"
 	"sync"
@@ -23,9 +24,18 @@ type SessionManager struct {
 	vcsProvider    service.VCSProvider
 	activeSession  *entity.Session
 	watchedPaths   map[string]bool
-	mu             sync.RWMutex
-	stopChan       chan struct{}
-	stopped        bool
+	rules          *sessionRules
+	// prompts are projects and boards waiting for the user to confirm
+	// their creation; declined are those the user said no to.
+	prompts        []*ProjectPrompt
+	declined       map[string]bool
+	promptNotifier func(ProjectPrompt)
+	// resolve makes the next sync resolve the active session again
+	resolve  bool
+	resync   chan struct{}
+	mu       sync.RWMutex
+	stopChan chan struct{}
+	stopped  bool
 }
 
 func NewSessionManager(
@@ -42,6 +52,8 @@ func NewSessionManager(
 		changeWatcher:  changeWatcher,
 		vcsProvider:    vcsProvider,
 		watchedPaths:   make(map[string]bool),
+		declined:       make(map[string]bool),
+		resync:         make(chan struct{}, 1),
 		stopChan:       make(chan struct{}),
 		stopped:        false,
 	}
@@ -54,7 +66,7 @@ func (sm *SessionManager) Start(ctx context.Context) error {
 	}
 
 	if !sm.sessionTracker.IsAvailable() {
-		fmt.Println("[SessionManager] Session tracker is not available (tmux may not be running)")
+		fmt.Printf("[SessionManager] Session tracker is not available (%s may not be running)\n", sm.config.SessionTracking.TrackerType)
 		return nil
 	}
 
@@ -97,8 +109,16 @@ func (sm *SessionManager) pollLoop(ctx context.Context) {
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
+	// Changes the tracker pushes are handled at once; polling remains for
+	// trackers without events and for changes they do not report.
+	events := sm.sessionTracker.Events()
+
 	for {
 		select {
+		case <-events:
+			sm.syncSessions(ctx)
+		case <-sm.resync:
+			sm.syncSessions(ctx)
 		case <-ticker.C:
 			sm.syncSessions(ctx)
 		case <-sm.stopChan:
@@ -109,6 +129,10 @@ func (sm *SessionManager) pollLoop(ctx context.Context) {
 	}
 }
 
+// sessionResolutionKeys are the metadata keys resolveProjectForSession
+// sets on a session.
+var sessionResolutionKeys = []string{"project_id", "board_id", "unresolved"}
+
 func (sm *SessionManager) syncSessions(ctx context.Context) {
 	activeSession, err := sm.sessionTracker.GetActiveSession()
 	if err != nil {
@@ -116,8 +140,28 @@ func (sm *SessionManager) syncSessions(ctx context.Context) {
 		return
 	}
 
+	rulesChanged := sm.reloadRules()
+
 	sm.mu.Lock()
 	previousSession := sm.activeSession
+	// Events can fire on every prompt or pane switch; the same directory
+	// keeps the project and board it was resolved to, or stays unresolved,
+	// until the rules change.
+	resolved := activeSession != nil && previousSession != nil &&
+		previousSession.Name() == activeSession.Name() &&
+		previousSession.WorkingDir() == activeSession.WorkingDir() &&
+		(previousSession.HasMetadata("board_id") || previousSession.HasMetadata("unresolved")) &&
+		!rulesChanged && !sm.resolve
+	sm.resolve = false
+	if resolved {
+		// Only the resolution carries over: the client, window and pane
+		// are the tracker's and may have changed.
+		for _, key := range sessionResolutionKeys {
+			if value, ok := previousSession.GetMetadata(key); ok {
+				activeSession.SetMetadata(key, value)
+			}
+		}
+	}
 	sm.activeSession = activeSession
 	sm.mu.Unlock()
 
@@ -129,23 +173,93 @@ func (sm *SessionManager) syncSessions(ctx context.Context) {
 	} else if previousSession != nil && activeSession != nil && previousSession.Name() != activeSession.Name() {
 		fmt.Printf("[SessionManager] Active session changed: %s -> %s (working dir: %s)\n",
 			previousSession.Name(), activeSession.Name(), activeSession.WorkingDir())
+	} else if previousSession != nil && activeSession != nil && paneChanged(previousSession, activeSession) {
+		pane, _ := activeSession.GetMetadata("pane_id")
+		fmt.Printf("[SessionManager] Active pane changed: %s %s (working dir: %s)\n",
+			activeSession.Name(), pane, activeSession.WorkingDir())
 	}
 
 	if sm.config.SessionTracking.GitSync.WatchForChanges && activeSession != nil {
 		sm.setupWatcher(activeSession)
 	}
 
-	if activeSession != nil {
+	if activeSession != nil && !resolved {
 		sm.resolveProjectForSession(ctx, activeSession)
 	}
 }
 
-func (sm *SessionManager) resolveProjectForSession(ctx context.Context, session *entity.Session) {
-	workingDir := session.WorkingDir()
-	if workingDir == "" {
-		return
+// paneChanged reports whether the focus moved to another window or pane
+// within a session, for trackers that report them.
+func paneChanged(previous, current *entity.Session) bool {
+	for _, key := range []string{"window_id", "pane_id"} {
+		before, _ := previous.GetMetadata(key)
+		after, _ := current.GetMetadata(key)
+		if before != after {
+			return true
+		}
+	}
+	return false
+}
+
+// reloadRules reads the rules file again when it was created, changed or
+// removed, reporting whether the rules changed. A file that fails to load
+// keeps the rules from before.
+func (sm *SessionManager) reloadRules() bool {
+	path := sm.config.SessionTracking.RulesFile
+	if path == "" {
+		return false
 	}
 
+	sm.mu.RLock()
+	current := sm.rules
+	sm.mu.RUnlock()
+
+	info, err := os.Stat(path)
+	if err != nil {
+		if current == nil {
+			return false
+		}
+		if !os.IsNotExist(err) {
+			fmt.Printf("[SessionManager] Error reading rules: %v\n", err)
+			return false
+		}
+		fmt.Printf("[SessionManager] Rules file %s removed\n", path)
+		sm.mu.Lock()
+		sm.rules = nil
+		sm.mu.Unlock()
+		sm.dropStalePrompts()
+		return true
+	}
+	if current != nil && info.ModTime().Equal(current.modTime) {
+		return false
+	}
+
+	rules, err := loadSessionRules(path, info.ModTime())
+	if err != nil {
+		fmt.Printf("[SessionManager] Error reading rules: %v\n", err)
+		if current != nil {
+			// Not again until the file changes
+			sm.mu.Lock()
+			current.modTime = info.ModTime()
+			sm.mu.Unlock()
+		}
+		return false
+	}
+
+	fmt.Printf("[SessionManager] Loaded %d rules and %d ignored paths from %s\n",
+		len(rules.rules), len(rules.ignore), path)
+	sm.mu.Lock()
+	sm.rules = rules
+	sm.mu.Unlock()
+	sm.dropStalePrompts()
+	return true
+}
+
+// sessionTarget derives the project and board of a directory. By default
+// the project is the repository and the board the app or package of a
+// monorepo (apps/<name>, packages/<name>); the rules file can map
+// directories elsewhere or ignore them.
+func (sm *SessionManager) sessionTarget(workingDir string) sessionTarget {
 	// Derive project name and repo root from VCS
 	var projectName, repoRoot string
 	if sm.vcsProvider != nil && sm.vcsProvider.IsRepository(workingDir) {
@@ -170,26 +284,66 @@ func (sm *SessionManager) resolveProjectForSession(ctx context.Context, session
 		}
 	}
 
-	// Find or create project
-	project, err := sm.findOrCreateProject(ctx, projectName)
+	sm.mu.RLock()
+	rules := sm.rules
+	sm.mu.RUnlock()
+	return rules.resolve(workingDir, projectName, boardName)
+}
+
+func (sm *SessionManager) resolveProjectForSession(ctx context.Context, session *entity.Session) {
+	workingDir := session.WorkingDir()
+	if workingDir == "" {
+		return
+	}
+
+	target := sm.sessionTarget(workingDir)
+	if target.ignored {
+		session.SetMetadata("unresolved", "ignored")
+		fmt.Printf("[SessionManager] Ignoring %s\n", workingDir)
+		return
+	}
+
+	project, err := sm.findProject(ctx, target.project)
 	if err != nil {
 		fmt.Printf("[SessionManager] Error resolving project: %v\n", err)
 		return
 	}
-	session.SetMetadata("project_id", project.ID)
+	var board *dto.BoardDto
+	if project != nil {
+		board, err = sm.findBoard(ctx, target.board, project.ID)
+		if err != nil {
+			fmt.Printf("[SessionManager] Error resolving board: %v\n", err)
+			return
+		}
+	}
 
-	// Find or create board
-	board, err := sm.findOrCreateBoard(ctx, boardName, project.ID)
-	if err != nil {
-		fmt.Printf("[SessionManager] Error resolving board: %v\n", err)
+	if board == nil && sm.config.SessionTracking.Create == "ask" {
+		session.SetMetadata("unresolved", sm.askToCreate(target, workingDir, project != nil))
 		return
 	}
+
+	if project == nil {
+		if project, err = sm.createProject(ctx, target.project); err != nil {
+			fmt.Printf("[SessionManager] Error resolving project: %v\n", err)
+			return
+		}
+	}
+	session.SetMetadata("project_id", project.ID)
+
+	if board == nil {
+		if board, err = sm.createBoard(ctx, target.board, project.ID); err != nil {
+			fmt.Printf("[SessionManager] Error resolving board: %v\n", err)
+			return
+		}
+	}
 	session.SetMetadata("board_id", board.ID)
+	session.RemoveMetadata("unresolved")
 
 	fmt.Printf("[SessionManager] Resolved project=%q board=%q for %s\n", project.Name, board.Name, workingDir)
 }
 
-func (sm *SessionManager) findOrCreateProject(ctx context.Context, name string) (*dto.ProjectDto, error) {
+// findProject returns the project with a name, or nil if there is none.
+func (sm *SessionManager) findProject(ctx context.Context, name string) (*dto.ProjectDto, error) {
 	projects, err := sm.backendClient.ListProjects(ctx, 1, 100)
 	if err != nil {
 		return nil, fmt.Errorf("listing projects: %w", err)
@@ -200,7 +354,10 @@ func (sm *SessionManager) findOrCreateProject(ctx context.Context, name string)
 			return &projects.Items[i], nil
 		}
 	}
+	return nil, nil
+}
 
+func (sm *SessionManager) createProject(ctx context.Context, name string) (*dto.ProjectDto, error) {
 	project, err := sm.backendClient.CreateProject(ctx, dto.ProjectCreateRequest{Name: name})
 	if err != nil {
 		return nil, fmt.Errorf("creating project %q: %w", name, err)
@@ -209,7 +366,9 @@ func (sm *SessionManager) findOrCreateProject(ctx context.Context, name string)
 	return project, nil
 }
 
-func (sm *SessionManager) findOrCreateBoard(ctx context.Context, name, projectID string) (*dto.BoardDto, error) {
+// findBoard returns the board of a project with a name, or nil if there
+// is none.
+func (sm *SessionManager) findBoard(ctx context.Context, name, projectID string) (*dto.BoardDto, error) {
 	boards, err := sm.backendClient.ListBoards(ctx, 1, 100, projectID, "")
 	if err != nil {
 		return nil, fmt.Errorf("listing boards: %w", err)
@@ -220,7 +379,10 @@ func (sm *SessionManager) findOrCreateBoard(ctx context.Context, name, projectID
 			return &boards.Items[i], nil
 		}
 	}
+	return nil, nil
+}
 
+func (sm *SessionManager) createBoard(ctx context.Context, name, projectID string) (*dto.BoardDto, error) {
 	board, err := sm.backendClient.CreateBoard(ctx, dto.BoardCreateRequest{Name: name, ProjectID: projectID})
 	if err != nil {
 		return nil, fmt.Errorf("creating board %q: %w", name, err)
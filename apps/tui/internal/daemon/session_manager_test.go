@@ -0,0 +1,79 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+
+	"cadence/internal/domain/entity"
+	"cadence/internal/domain/service"
+	"cadence/internal/infrastructure/config"
+)
+
+// fixedSessionTracker reports the session it holds as the active one.
+type fixedSessionTracker struct {
+	session *entity.Session
+}
+
+func (t *fixedSessionTracker) ListSessions() ([]*entity.Session, error) {
+	return []*entity.Session{t.session}, nil
+}
+
+func (t *fixedSessionTracker) GetActiveSession() (*entity.Session, error) {
+	return t.session, nil
+}
+
+func (t *fixedSessionTracker) IsAvailable() bool {
+	return true
+}
+
+func (t *fixedSessionTracker) Events() <-chan service.SessionEvent {
+	return nil
+}
+
+func TestSyncSessionsKeepsResolutionOnly(t *testing.T) {
+	session := func(metadata map[string]string) *entity.Session {
+		t.Helper()
+		s, err := entity.NewSession("work", "/home/ada/src/cadence", "tmux")
+		if err != nil {
+			t.Fatal(err)
+		}
+		for key, value := range metadata {
+			s.SetMetadata(key, value)
+		}
+		return s
+	}
+
+	tracker := &fixedSessionTracker{session: session(map[string]string{
+		"window_id":    "@1",
+		"pane_id":      "%4",
+		"pane_command": "zsh",
+	})}
+	sm := NewSessionManager(&config.Config{}, nil, tracker, nil, nil)
+	sm.activeSession = session(map[string]string{
+		"client":       "/dev/pts/2",
+		"window_id":    "@1",
+		"pane_id":      "%3",
+		"pane_command": "vim",
+		"project_id":   "p1",
+		"board_id":     "b1",
+	})
+
+	sm.syncSessions(context.Background())
+
+	got := sm.GetActiveSession().Metadata()
+	want := map[string]string{
+		"window_id":    "@1",
+		"pane_id":      "%4",
+		"pane_command": "zsh",
+		"project_id":   "p1",
+		"board_id":     "b1",
+	}
+	if len(got) != len(want) {
+		t.Errorf("metadata = %v, want %v", got, want)
+	}
+	for key, value := range want {
+		if got[key] != value {
+			t.Errorf("%s = %q, want %q", key, got[key], value)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// maxProjectPrompts bounds the prompts kept for the TUI; the oldest are
+// dropped first.
+const maxProjectPrompts = 20
+
+// SetPromptNotifier sets the function told about every new prompt to
+// create a project or board.
+func (sm *SessionManager) SetPromptNotifier(notify func(ProjectPrompt)) {
+	sm.promptNotifier = notify
+}
+
+// askToCreate records that a directory resolved to a project or board that
+// does not exist, instead of creating it. It returns how the session stays
+// unresolved: pending until the user answers, or declined when they
+// already said no since the daemon started.
+func (sm *SessionManager) askToCreate(target sessionTarget, workingDir string, projectExists bool) string {
+	key := strings.ToLower(target.project + "/" + target.board)
+
+	sm.mu.Lock()
+	if sm.declined[key] {
+		sm.mu.Unlock()
+		return "declined"
+	}
+	for _, prompt := range sm.prompts {
+		if strings.ToLower(prompt.Project+"/"+prompt.Board) == key {
+			sm.mu.Unlock()
+			return "pending"
+		}
+	}
+
+	prompt := ProjectPrompt{
+		ID:            uuid.New().String(),
+		WorkingDir:    workingDir,
+		Project:       target.project,
+		Board:         target.board,
+		ProjectExists: projectExists,
+	}
+	sm.prompts = append(sm.prompts, &prompt)
+	if len(sm.prompts) > maxProjectPrompts {
+		sm.prompts = sm.prompts[len(sm.prompts)-maxProjectPrompts:]
+	}
+	notify := sm.promptNotifier
+	sm.mu.Unlock()
+
+	fmt.Printf("[SessionManager] Asking to create project=%q board=%q for %s\n", prompt.Project, prompt.Board, workingDir)
+	if notify != nil {
+		notify(prompt)
+	}
+	return "pending"
+}
+
+// dropStalePrompts forgets prompts whose directory resolves elsewhere
+// under changed rules.
+func (sm *SessionManager) dropStalePrompts() {
+	prompts := sm.ProjectPrompts()
+	stale := make(map[string]bool)
+	for _, prompt := range prompts {
+		target := sm.sessionTarget(prompt.WorkingDir)
+		if target.ignored || !strings.EqualFold(target.project, prompt.Project) ||
+			!strings.EqualFold(target.board, prompt.Board) {
+			stale[prompt.ID] = true
+		}
+	}
+	if len(stale) == 0 {
+		return
+	}
+
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	kept := sm.prompts[:0]
+	for _, prompt := range sm.prompts {
+		if !stale[prompt.ID] {
+			kept = append(kept, prompt)
+		}
+	}
+	sm.prompts = kept
+}
+
+// ProjectPrompts returns the projects and boards waiting for the user to
+// confirm their creation.
+func (sm *SessionManager) ProjectPrompts() []ProjectPrompt {
+	sm.mu.RLock()
+	defer sm.mu.RUnlock()
+
+	prompts := make([]ProjectPrompt, 0, len(sm.prompts))
+	for _, prompt := range sm.prompts {
+		prompts = append(prompts, *prompt)
+	}
+	return prompts
+}
+
+// ResolveProjectPrompt answers a prompt. With create, the project and
+// board are created; otherwise directories resolving to them stay without
+// a board until the daemon restarts. Either way the active session is
+// resolved again.
+func (sm *SessionManager) ResolveProjectPrompt(ctx context.Context, id string, create bool) error {
+	sm.mu.Lock()
+	index := -1
+	for i, prompt := range sm.prompts {
+		if prompt.ID == id {
+			index = i
+			break
+		}
+	}
+	if index < 0 {
+		sm.mu.Unlock()
+		return fmt.Errorf("project prompt %s not found", id)
+	}
+	prompt := sm.prompts[index]
+	sm.mu.Unlock()
+
+	if create {
+		// Either may have been created since the prompt was made
+		project, err := sm.findProject(ctx, prompt.Project)
+		if err == nil && project == nil {
+			project, err = sm.createProject(ctx, prompt.Project)
+		}
+		if err != nil {
+			return err
+		}
+		board, err := sm.findBoard(ctx, prompt.Board, project.ID)
+		if err == nil && board == nil {
+			_, err = sm.createBoard(ctx, prompt.Board, project.ID)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	sm.mu.Lock()
+	for i, p := range sm.prompts {
+		if p.ID == id {
+			sm.prompts = append(sm.prompts[:i], sm.prompts[i+1:]...)
+			break
+		}
+	}
+	if !create {
+		sm.declined[strings.ToLower(prompt.Project+"/"+prompt.Board)] = true
+		fmt.Printf("[SessionManager] Not creating project=%q board=%q\n", prompt.Project, prompt.Board)
+	}
+	sm.resolve = true
+	sm.mu.Unlock()
+
+	select {
+	case sm.resync <- struct{}{}:
+	default:
+	}
+	return nil
+}
@@ -0,0 +1,214 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// sessionRulesFile is the rules file (session_tracking.rules_file) that
+// decides which project and board a session directory belongs to.
+type sessionRulesFile struct {
+	// Ignore lists directories that never get a project or board, as paths
+	// or globs: "~" is the home directory itself, "/tmp/**" is /tmp and
+	// everything below it. Entries are nodes as YAML reads a bare ~ as
+	// null.
+	Ignore []yaml.Node `yaml:"ignore,omitempty"`
+	// Rules are tried in order; the first one matching wins.
+	Rules []sessionRuleConfig `yaml:"rules,omitempty"`
+}
+
+// sessionRuleConfig matches directories with one of Dir (that directory and
+// everything below it), Path (a glob matching the directory or one of its
+// parents) or Regex (over the whole directory). Project and Board may use
+// {repo}, the repository name, and {dir}, the name of the directory the
+// rule matched; regex rules also take $1 or ${name} for their groups.
+// Left empty, they fall back to the repository and the monorepo app.
+type sessionRuleConfig struct {
+	Dir     string `yaml:"dir,omitempty"`
+	Path    string `yaml:"path,omitempty"`
+	Regex   string `yaml:"regex,omitempty"`
+	Project string `yaml:"project,omitempty"`
+	Board   string `yaml:"board,omitempty"`
+	// Ignore leaves the matched directories without a project or board.
+	Ignore bool `yaml:"ignore,omitempty"`
+}
+
+// sessionRules is a loaded rules file.
+type sessionRules struct {
+	modTime time.Time
+	ignore  []*regexp.Regexp
+	rules   []sessionRule
+}
+
+type sessionRule struct {
+	pattern *regexp.Regexp
+	// parents is set for globs, which also match below a matching directory
+	parents bool
+	regex   bool
+	project string
+	board   string
+	ignore  bool
+}
+
+// sessionTarget is the project and board a directory resolves to.
+type sessionTarget struct {
+	project string
+	board   string
+	ignored bool
+}
+
+// loadSessionRules reads and compiles a rules file.
+func loadSessionRules(path string, modTime time.Time) (*sessionRules, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var file sessionRulesFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	homeDir, _ := os.UserHomeDir()
+	rules := &sessionRules{modTime: modTime}
+
+	for _, node := range file.Ignore {
+		glob := node.Value
+		pattern, err := globPattern(expandPath(glob, homeDir))
+		if err != nil {
+			return nil, fmt.Errorf("ignore %q: %w", glob, err)
+		}
+		rules.ignore = append(rules.ignore, pattern)
+	}
+
+	for i, cfg := range file.Rules {
+		rule := sessionRule{project: cfg.Project, board: cfg.Board, ignore: cfg.Ignore}
+		switch {
+		case cfg.Dir != "" && cfg.Path == "" && cfg.Regex == "":
+			dir := filepath.Clean(expandPath(cfg.Dir, homeDir))
+			rule.pattern = regexp.MustCompile("^" + regexp.QuoteMeta(strings.TrimSuffix(dir, "/")) + "(/.*)?$")
+		case cfg.Path != "" && cfg.Dir == "" && cfg.Regex == "":
+			rule.pattern, err = globPattern(expandPath(cfg.Path, homeDir))
+			rule.parents = true
+		case cfg.Regex != "" && cfg.Dir == "" && cfg.Path == "":
+			rule.pattern, err = regexp.Compile(cfg.Regex)
+			rule.regex = true
+		default:
+			err = fmt.Errorf("needs exactly one of dir, path and regex")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("rule %d: %w", i+1, err)
+		}
+		rules.rules = append(rules.rules, rule)
+	}
+
+	return rules, nil
+}
+
+// resolve applies the rules to a directory. Names a rule leaves empty take
+// the defaults derived from the repository.
+func (r *sessionRules) resolve(workingDir, repoName, defaultBoard string) sessionTarget {
+	target := sessionTarget{project: repoName, board: defaultBoard}
+	if r == nil {
+		return target
+	}
+
+	for _, pattern := range r.ignore {
+		if pattern.MatchString(workingDir) {
+			target.ignored = true
+			return target
+		}
+	}
+
+	for _, rule := range r.rules {
+		matched, ok := rule.match(workingDir)
+		if !ok {
+			continue
+		}
+		if rule.ignore {
+			target.ignored = true
+			return target
+		}
+
+		expand := func(name string) string {
+			if rule.regex {
+				match := rule.pattern.FindStringSubmatchIndex(workingDir)
+				name = string(rule.pattern.ExpandString(nil, name, workingDir, match))
+			}
+			name = strings.NewReplacer("{repo}", repoName, "{dir}", filepath.Base(matched)).Replace(name)
+			return strings.TrimSpace(name)
+		}
+		if name := expand(rule.project); name != "" {
+			target.project = name
+		}
+		if name := expand(rule.board); name != "" {
+			target.board = name
+		}
+		return target
+	}
+
+	return target
+}
+
+// match reports whether a rule applies to a directory and which directory
+// it matched, which is a parent of it for globs matching further up.
+func (r sessionRule) match(workingDir string) (string, bool) {
+	if !r.parents {
+		return workingDir, r.pattern.MatchString(workingDir)
+	}
+	for dir := workingDir; ; dir = filepath.Dir(dir) {
+		if r.pattern.MatchString(dir) {
+			return dir, true
+		}
+		if filepath.Dir(dir) == dir {
+			return "", false
+		}
+	}
+}
+
+// globPattern compiles a path glob: * and ? match within one path element,
+// ** across elements, and a trailing /** also matches the directory itself.
+func globPattern(glob string) (*regexp.Regexp, error) {
+	glob = filepath.Clean(glob)
+
+	var b strings.Builder
+	b.WriteString("^")
+	for i := 0; i < len(glob); i++ {
+		switch {
+		case glob[i:] == "/**":
+			b.WriteString("(/.*)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**/"):
+			b.WriteString("(.*/)?")
+			i += 2
+		case strings.HasPrefix(glob[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case glob[i] == '*':
+			b.WriteString("[^/]*")
+		case glob[i] == '?':
+			b.WriteString("[^/]")
+		default:
+			b.WriteString(regexp.QuoteMeta(glob[i : i+1]))
+		}
+	}
+	b.WriteString("$")
+
+	return regexp.Compile(b.String())
+}
+
+// expandPath expands ~ and environment variables such as $HOME.
+func expandPath(path, homeDir string) string {
+	if path == "~" {
+		path = homeDir
+	} else if strings.HasPrefix(path, "~/") {
+		path = filepath.Join(homeDir, path[2:])
+	}
+	return os.ExpandEnv(path)
+}
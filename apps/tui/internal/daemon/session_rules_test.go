@@ -0,0 +1,129 @@
+package daemon
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestGlobPattern(t *testing.T) {
+	tests := []struct {
+		glob string
+		path string
+		want bool
+	}{
+		{"/a/*", "/a/b", true},
+		{"/a/*", "/a/b/c", false},
+		{"/a/*", "/a", false},
+		{"/a/**", "/a", true},
+		{"/a/**", "/a/b/c", true},
+		{"/a/**", "/ab", false},
+		{"/a/**/c", "/a/c", true},
+		{"/a/**/c", "/a/b/x/c", true},
+		{"/a/**/c", "/a/b/cd", false},
+		{"/a/**c", "/a/x/yc", true},
+		{"/a/?", "/a/b", true},
+		{"/a/?", "/a/bc", false},
+		{"/a/b.c", "/a/b.c", true},
+		{"/a/b.c", "/a/bxc", false},
+		{"/a/b/", "/a/b", true},
+	}
+	for _, tt := range tests {
+		pattern, err := globPattern(tt.glob)
+		if err != nil {
+			t.Errorf("globPattern(%q) error: %v", tt.glob, err)
+			continue
+		}
+		if got := pattern.MatchString(tt.path); got != tt.want {
+			t.Errorf("globPattern(%q) matching %q = %v, want %v", tt.glob, tt.path, got, tt.want)
+		}
+	}
+}
+
+const testSessionRules = `
+ignore:
+  - ~
+  - /tmp/**
+rules:
+  - dir: ~/work/acme
+    project: Acme
+  - path: ~/src/*
+    project: "{dir}"
+  - regex: ^/srv/(?P<client>[^/]+)/(\w+)
+    project: ${client}
+    board: $2
+  - path: ~/scratch/**
+    ignore: true
+  - dir: ~/oss
+    board: "{repo} board"
+`
+
+func TestSessionRulesResolve(t *testing.T) {
+	t.Setenv("HOME", "/home/ada")
+	rules := loadTestSessionRules(t, testSessionRules)
+
+	tests := []struct {
+		dir     string
+		repo    string
+		board   string
+		want    sessionTarget
+		comment string
+	}{
+		{"/home/ada", "", "", sessionTarget{ignored: true}, "the home directory itself"},
+		{"/home/ada/notes", "notes", "", sessionTarget{project: "notes"}, "below home is not ignored"},
+		{"/tmp", "", "", sessionTarget{ignored: true}, "trailing /** matches the directory"},
+		{"/tmp/build/out", "out", "", sessionTarget{project: "out", ignored: true}, "and below it"},
+		{"/home/ada/work/acme/apps/api", "acme", "api", sessionTarget{project: "Acme", board: "api"}, "dir rule keeps the default board"},
+		{"/home/ada/work/acmeco", "acmeco", "", sessionTarget{project: "acmeco"}, "dir rule needs a whole element"},
+		{"/home/ada/src/cadence/apps/tui", "cadence", "tui", sessionTarget{project: "cadence", board: "tui"}, "glob matches a parent"},
+		{"/home/ada/src/dotfiles", "", "", sessionTarget{project: "dotfiles"}, "{dir} without a repository"},
+		{"/srv/globex/site/www", "www", "", sessionTarget{project: "globex", board: "site"}, "regex groups by name and number"},
+		{"/home/ada/scratch/try", "try", "", sessionTarget{project: "try", ignored: true}, "ignore rule"},
+		{"/home/ada/oss/lib", "lib", "", sessionTarget{project: "lib", board: "lib board"}, "{repo} in a board"},
+	}
+	for _, tt := range tests {
+		if got := rules.resolve(tt.dir, tt.repo, tt.board); got != tt.want {
+			t.Errorf("%s: resolve(%q) = %+v, want %+v", tt.comment, tt.dir, got, tt.want)
+		}
+	}
+
+	var none *sessionRules
+	if got := none.resolve("/home/ada/x", "x", "web"); got != (sessionTarget{project: "x", board: "web"}) {
+		t.Errorf("resolve without rules = %+v, want the defaults", got)
+	}
+}
+
+func TestLoadSessionRulesErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules string
+	}{
+		{"two matchers", "rules:\n  - dir: /a\n    path: /b\n"},
+		{"no matcher", "rules:\n  - project: x\n"},
+		{"bad regex", "rules:\n  - regex: \"(\"\n"},
+		{"bad yaml", "rules: [\n"},
+	}
+	for _, tt := range tests {
+		path := filepath.Join(t.TempDir(), "rules.yaml")
+		if err := os.WriteFile(path, []byte(tt.rules), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := loadSessionRules(path, time.Now()); err == nil {
+			t.Errorf("%s: loadSessionRules succeeded, want an error", tt.name)
+		}
+	}
+}
+
+func loadTestSessionRules(t *testing.T, content string) *sessionRules {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "rules.yaml")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err := loadSessionRules(path, time.Now())
+	if err != nil {
+		t.Fatalf("loadSessionRules: %v", err)
+	}
+	return rules
+}
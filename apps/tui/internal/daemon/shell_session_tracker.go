@@ -0,0 +1,173 @@
+package daemon
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"syscall"
+	"time"
+
+	"cadence/internal/domain/entity"
+	"cadence/internal/domain/service"
+)
+
+const sessionTypeShell = "shell"
+
+// remoteShellAge is how long a shell on another host is kept without
+// reporting. Local shells are dropped as soon as their process is gone.
+const remoteShellAge = 24 * time.Hour
+
+type shellState struct {
+	report ReportShellPayload
+	seen   time.Time
+}
+
+// ShellSessionTracker implements SessionTracker from the reports of shell
+// prompt hooks (`cadence hook`), so sessions work without a multiplexer:
+// in plain terminals, IDE terminals and over SSH with the daemon socket
+// forwarded. The shell that showed a prompt last is the active session.
+type ShellSessionTracker struct {
+	hostname    string
+	shells      map[string]*shellState
+	subscribers []chan service.SessionEvent
+	mu          sync.Mutex
+}
+
+// NewShellSessionTracker creates a new ShellSessionTracker
+func NewShellSessionTracker() *ShellSessionTracker {
+	hostname, _ := os.Hostname()
+	return &ShellSessionTracker{
+		hostname: hostname,
+		shells:   make(map[string]*shellState),
+	}
+}
+
+// Report records a shell's prompt, or forgets the shell when it exits.
+func (t *ShellSessionTracker) Report(report ReportShellPayload) {
+	if report.Host == "" {
+		report.Host = t.hostname
+	}
+	key := report.Host + "/" + strconv.Itoa(report.PID)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	event := service.SessionEvent{Type: "prompt", Time: time.Now()}
+	switch {
+	case report.Exit:
+		delete(t.shells, key)
+		event.Type = "exit"
+	case report.Cwd == "":
+		return
+	default:
+		t.shells[key] = &shellState{report: report, seen: event.Time}
+	}
+
+	for _, ch := range t.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
+}
+
+// Events returns a channel that gets an event with every report, so a
+// prompt in another terminal switches the session at once.
+func (t *ShellSessionTracker) Events() <-chan service.SessionEvent {
+	ch := make(chan service.SessionEvent, 1)
+
+	t.mu.Lock()
+	t.subscribers = append(t.subscribers, ch)
+	t.mu.Unlock()
+	return ch
+}
+
+// IsAvailable is always true: shells report to the daemon whenever they
+// start, so there is nothing to wait for.
+func (t *ShellSessionTracker) IsAvailable() bool {
+	return true
+}
+
+// ListSessions returns the known shells, most recently active first
+func (t *ShellSessionTracker) ListSessions() ([]*entity.Session, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.pruneLocked()
+
+	states := make([]*shellState, 0, len(t.shells))
+	for _, state := range t.shells {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].seen.After(states[j].seen)
+	})
+
+	sessions := make([]*entity.Session, 0, len(states))
+	for _, state := range states {
+		session, err := t.session(state)
+		if err != nil {
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+	return sessions, nil
+}
+
+// GetActiveSession returns the shell that showed a prompt last
+func (t *ShellSessionTracker) GetActiveSession() (*entity.Session, error) {
+	sessions, err := t.ListSessions()
+	if err != nil || len(sessions) == 0 {
+		return nil, err
+	}
+	return sessions[0], nil
+}
+
+// pruneLocked drops shells that exited without reporting it, such as
+// bash with its own EXIT trap or a closed terminal window.
+func (t *ShellSessionTracker) pruneLocked() {
+	for key, state := range t.shells {
+		if state.report.Host != t.hostname {
+			if time.Since(state.seen) > remoteShellAge {
+				delete(t.shells, key)
+			}
+			continue
+		}
+		if err := syscall.Kill(state.report.PID, 0); errors.Is(err, syscall.ESRCH) {
+			delete(t.shells, key)
+		}
+	}
+}
+
+func (t *ShellSessionTracker) session(state *shellState) (*entity.Session, error) {
+	report := state.report
+	name := fmt.Sprintf("%s-%d", report.Shell, report.PID)
+	if report.Host != t.hostname {
+		name = report.Host + ":" + name
+	}
+
+	session, err := entity.NewSession(name, report.Cwd, sessionTypeShell)
+	if err != nil {
+		return nil, err
+	}
+
+	session.SetMetadata("shell", report.Shell)
+	session.SetMetadata("pid", strconv.Itoa(report.PID))
+	session.SetMetadata("host", report.Host)
+	if report.TTY != "" {
+		session.SetMetadata("tty", report.TTY)
+	}
+	if report.Terminal != "" {
+		session.SetMetadata("terminal", report.Terminal)
+	}
+	if report.Window != "" {
+		session.SetMetadata("window", report.Window)
+	}
+	if report.SSH {
+		session.SetMetadata("ssh", "true")
+	}
+	return session, nil
+}
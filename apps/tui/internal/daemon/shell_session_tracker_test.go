@@ -0,0 +1,94 @@
+package daemon
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShellSessionTracker(t *testing.T) {
+	tracker := NewShellSessionTracker()
+	tracker.hostname = "laptop"
+
+	// A shell that exited without saying so is dropped by its PID
+	gone := exec.Command("true")
+	if err := gone.Run(); err != nil {
+		t.Fatal(err)
+	}
+	self := os.Getpid()
+
+	report := func(r ReportShellPayload) {
+		tracker.Report(r)
+		time.Sleep(2 * time.Millisecond)
+	}
+	report(ReportShellPayload{Shell: "zsh", PID: self, Cwd: "/home/ada/src", TTY: "/dev/pts/3"})
+	report(ReportShellPayload{Shell: "bash", PID: gone.Process.Pid, Cwd: "/home/ada/tmp"})
+	report(ReportShellPayload{Shell: "bash", PID: 42, Host: "build-box", Cwd: "/srv/app", SSH: true})
+	report(ReportShellPayload{Shell: "fish", PID: 43, Host: "build-box"})
+
+	names := func() string {
+		t.Helper()
+		sessions, err := tracker.ListSessions()
+		if err != nil {
+			t.Fatalf("ListSessions: %v", err)
+		}
+		var names []string
+		for _, session := range sessions {
+			names = append(names, session.Name()+"@"+session.WorkingDir())
+		}
+		return strings.Join(names, " ")
+	}
+
+	zsh := fmt.Sprintf("zsh-%d", self)
+	if got, want := names(), "build-box:bash-42@/srv/app "+zsh+"@/home/ada/src"; got != want {
+		t.Errorf("sessions = %s, want %s", got, want)
+	}
+
+	active, err := tracker.GetActiveSession()
+	if err != nil || active == nil {
+		t.Fatalf("GetActiveSession = %v, %v", active, err)
+	}
+	if got := active.Metadata(); got["host"] != "build-box" || got["ssh"] != "true" || got["pid"] != "42" {
+		t.Errorf("remote shell metadata = %v", got)
+	}
+
+	// The shell with the latest prompt becomes the active session
+	report(ReportShellPayload{Shell: "zsh", PID: self, Cwd: "/home/ada/src/cadence"})
+	if active, _ := tracker.GetActiveSession(); active == nil || active.Name() != zsh || active.WorkingDir() != "/home/ada/src/cadence" {
+		t.Errorf("active session = %v, want %s in its new directory", active, zsh)
+	}
+
+	report(ReportShellPayload{PID: 42, Host: "build-box", Exit: true})
+	if got, want := names(), zsh+"@/home/ada/src/cadence"; got != want {
+		t.Errorf("sessions after an exit = %s, want %s", got, want)
+	}
+}
+
+func TestShellSessionTrackerEvents(t *testing.T) {
+	tracker := NewShellSessionTracker()
+	events := tracker.Events()
+	next := func() string {
+		select {
+		case event := <-events:
+			return event.Type
+		default:
+			return ""
+		}
+	}
+
+	tracker.Report(ReportShellPayload{Shell: "zsh", PID: os.Getpid(), Cwd: "/home/ada"})
+	if got := next(); got != "prompt" {
+		t.Errorf("event after a prompt = %q, want prompt", got)
+	}
+	tracker.Report(ReportShellPayload{Shell: "zsh", PID: os.Getpid(), Exit: true})
+	if got := next(); got != "exit" {
+		t.Errorf("event after an exit = %q, want exit", got)
+	}
+	tracker.Report(ReportShellPayload{Shell: "zsh", PID: os.Getpid()})
+	if got := next(); got != "" {
+		t.Errorf("event after a report without a directory = %q, want none", got)
+	}
+}
@@ -0,0 +1,320 @@
+package daemon
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/httpclient"
+)
+
+const (
+	fallbackNumber = "number"
+	fallbackTitle  = "title"
+
+	// taskIndexTTL is how long a project's slug index is trusted; a miss
+	// on an index older than taskIndexMinAge triggers an early refresh so
+	// tasks created a moment ago are found.
+	taskIndexTTL    = 5 * time.Minute
+	taskIndexMinAge = 30 * time.Second
+
+	taskIndexPageSize = 100
+)
+
+// defaultBranchPatterns are used when the config has none.
+var defaultBranchPatterns = []string{
+	`^(?:feature|bugfix|fix|hotfix|chore|refactor)/(?P<slug>[A-Za-z][A-Za-z0-9]*-\d+)`,
+	`(?P<slug>[A-Za-z][A-Za-z0-9]*-\d+)`,
+}
+
+var (
+	slugKeyPattern      = regexp.MustCompile(`^(.+?)-0*(\d+)(?:-.*)?$`)
+	branchNumberPattern = regexp.MustCompile(`(?:^|[/_-])(\d+)(?:[/_-]|$)`)
+	nonAlnumPattern     = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+type branchRules struct {
+	patterns []*regexp.Regexp
+	fallback []string
+}
+
+type taskIndex struct {
+	fetchedAt time.Time
+	bySlug    map[string]string
+	byTitle   map[string]string
+	ids       map[string]bool
+}
+
+// TaskResolver maps a branch name to a backend task ID using the branch
+// rules from time_tracking.git. Patterns are compiled once; task slugs are
+// looked up in a per-project index built from the project's boards.
+type TaskResolver struct {
+	backendClient *httpclient.BackendClient
+	global        branchRules
+	projects      map[string]branchRules
+
+	mu      sync.Mutex
+	indexes map[string]*taskIndex
+}
+
+func NewTaskResolver(cfg config.TimeTrackingGitConfig, backendClient *httpclient.BackendClient) (*TaskResolver, error) {
+	patterns := append([]string{}, cfg.BranchPatterns...)
+	if cfg.BranchPattern != "" {
+		patterns = append(patterns, cfg.BranchPattern)
+	}
+	if len(patterns) == 0 {
+		patterns = defaultBranchPatterns
+	}
+
+	global, err := compileBranchRules(patterns, cfg.Fallback)
+	if err != nil {
+		return nil, err
+	}
+
+	projects := make(map[string]branchRules, len(cfg.Projects))
+	for name, rules := range cfg.Projects {
+		projectRules := global
+		if len(rules.BranchPatterns) > 0 || len(rules.Fallback) > 0 {
+			ps := rules.BranchPatterns
+			if len(ps) == 0 {
+				ps = patterns
+			}
+			projectRules, err = compileBranchRules(ps, rules.Fallback)
+			if err != nil {
+				return nil, fmt.Errorf("project %s: %w", name, err)
+			}
+		}
+		projects[strings.ToLower(name)] = projectRules
+	}
+
+	return &TaskResolver{
+		backendClient: backendClient,
+		global:        global,
+		projects:      projects,
+		indexes:       make(map[string]*taskIndex),
+	}, nil
+}
+
+func compileBranchRules(patterns, fallback []string) (branchRules, error) {
+	rules := branchRules{fallback: fallback}
+	for _, p := range patterns {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return branchRules{}, fmt.Errorf("invalid branch pattern %q: %w", p, err)
+		}
+		rules.patterns = append(rules.patterns, re)
+	}
+	for _, f := range fallback {
+		if f != fallbackNumber && f != fallbackTitle {
+			return branchRules{}, fmt.Errorf("unknown branch fallback %q (use %s or %s)", f, fallbackNumber, fallbackTitle)
+		}
+	}
+	return rules, nil
+}
+
+// Resolve returns the ID of the task the branch refers to, or "" if no rule
+// finds a task that exists in the project.
+func (r *TaskResolver) Resolve(ctx context.Context, project *dto.ProjectDto, branch string) string {
+	rules := r.rulesFor(project)
+
+	for _, re := range rules.patterns {
+		match := re.FindStringSubmatch(branch)
+		if match == nil {
+			continue
+		}
+		if id := r.resolveMatch(ctx, project, re, match); id != "" {
+			return id
+		}
+	}
+
+	for _, fallback := range rules.fallback {
+		var id string
+		switch fallback {
+		case fallbackNumber:
+			if m := branchNumberPattern.FindStringSubmatch(branch); m != nil {
+				id = r.lookupSlug(ctx, project.ID, project.Slug+"-"+m[1])
+			}
+		case fallbackTitle:
+			id = r.lookupTitle(ctx, project.ID, branch)
+		}
+		if id != "" {
+			return id
+		}
+	}
+
+	return ""
+}
+
+func (r *TaskResolver) rulesFor(project *dto.ProjectDto) branchRules {
+	if rules, ok := r.projects[strings.ToLower(project.Name)]; ok {
+		return rules
+	}
+	if rules, ok := r.projects[strings.ToLower(project.Slug)]; ok {
+		return rules
+	}
+	return r.global
+}
+
+func (r *TaskResolver) resolveMatch(ctx context.Context, project *dto.ProjectDto, re *regexp.Regexp, match []string) string {
+	named := false
+	for i, name := range re.SubexpNames() {
+		if name == "" || match[i] == "" {
+			continue
+		}
+		named = true
+		switch name {
+		case "id":
+			if r.hasTask(ctx, project.ID, match[i]) {
+				return match[i]
+			}
+		case "slug":
+			if id := r.lookupSlug(ctx, project.ID, match[i]); id != "" {
+				return id
+			}
+		case "number":
+			if id := r.lookupSlug(ctx, project.ID, project.Slug+"-"+match[i]); id != "" {
+				return id
+			}
+		}
+	}
+	if named {
+		return ""
+	}
+
+	// Without named groups the last non-empty group (or the whole match)
+	// is taken as a slug, which fits the older single branch_pattern.
+	key := match[0]
+	for i := len(match) - 1; i > 0; i-- {
+		if match[i] != "" {
+			key = match[i]
+			break
+		}
+	}
+	return r.lookupSlug(ctx, project.ID, key)
+}
+
+func (r *TaskResolver) lookupSlug(ctx context.Context, projectID, slug string) string {
+	return r.lookup(ctx, projectID, func(idx *taskIndex) string {
+		if id, ok := idx.bySlug[strings.ToLower(slug)]; ok {
+			return id
+		}
+		return idx.bySlug[slugKey(slug)]
+	})
+}
+
+func (r *TaskResolver) lookupTitle(ctx context.Context, projectID, branch string) string {
+	name := branch
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	name = normalizeWords(name)
+	if name == "" {
+		return ""
+	}
+
+	return r.lookup(ctx, projectID, func(idx *taskIndex) string {
+		return idx.byTitle[name]
+	})
+}
+
+func (r *TaskResolver) hasTask(ctx context.Context, projectID, id string) bool {
+	return r.lookup(ctx, projectID, func(idx *taskIndex) string {
+		if idx.ids[id] {
+			return id
+		}
+		return ""
+	}) != ""
+}
+
+// lookup runs find against the project's index, refreshing it once if it
+// is stale or the key is missing from an index that is not brand new.
+func (r *TaskResolver) lookup(ctx context.Context, projectID string, find func(*taskIndex) string) string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	idx := r.indexes[projectID]
+	if idx != nil && time.Since(idx.fetchedAt) < taskIndexTTL {
+		if id := find(idx); id != "" {
+			return id
+		}
+		if time.Since(idx.fetchedAt) < taskIndexMinAge {
+			return ""
+		}
+	}
+
+	fresh, err := r.buildIndex(ctx, projectID)
+	if err != nil {
+		fmt.Printf("[TimeTrackingManager] Failed to index tasks for project %s: %v\n", projectID, err)
+		if idx != nil {
+			return find(idx)
+		}
+		return ""
+	}
+	r.indexes[projectID] = fresh
+	return find(fresh)
+}
+
+func (r *TaskResolver) buildIndex(ctx context.Context, projectID string) (*taskIndex, error) {
+	idx := &taskIndex{
+		fetchedAt: time.Now(),
+		bySlug:    make(map[string]string),
+		byTitle:   make(map[string]string),
+		ids:       make(map[string]bool),
+	}
+
+	boards, err := r.backendClient.ListBoards(ctx, 1, taskIndexPageSize, projectID, "")
+	if err != nil {
+		return nil, fmt.Errorf("listing boards: %w", err)
+	}
+
+	for _, board := range boards.Items {
+		for page := 1; ; page++ {
+			tasks, err := r.backendClient.ListTasks(ctx, board.ID, "", page, taskIndexPageSize)
+			if err != nil {
+				return nil, fmt.Errorf("listing tasks: %w", err)
+			}
+			for _, task := range tasks.Items {
+				idx.ids[task.ID] = true
+				if task.Slug != "" {
+					idx.bySlug[strings.ToLower(task.Slug)] = task.ID
+					idx.bySlug[slugKey(task.Slug)] = task.ID
+				}
+				if title := normalizeWords(task.Title); title != "" {
+					idx.byTitle[title] = task.ID
+				}
+			}
+			if len(tasks.Items) == 0 || page*taskIndexPageSize >= tasks.Total {
+				break
+			}
+		}
+	}
+
+	return idx, nil
+}
+
+// slugKey reduces a slug to "<prefix>-<number>" in lower case, so CAD-7,
+// cad-007 and CAD-7-fix-login all match the same task.
+func slugKey(slug string) string {
+	slug = strings.ToLower(slug)
+	m := slugKeyPattern.FindStringSubmatch(slug)
+	if m == nil {
+		return slug
+	}
+	n, err := strconv.Atoi(m[2])
+	if err != nil {
+		return slug
+	}
+	return m[1] + "-" + strconv.Itoa(n)
+}
+
+// normalizeWords turns "Fix login bug" and "fix_login-bug" into
+// "fix-login-bug".
+func normalizeWords(s string) string {
+	return strings.Trim(nonAlnumPattern.ReplaceAllString(strings.ToLower(s), "-"), "-")
+}
@@ -3,7 +3,8 @@ package daemon
 import (
 	"context"
 	"fmt"
-	"regexp"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -21,11 +22,18 @@ type TimeTrackingManager struct {
 	backendClient  *httpclient.BackendClient
 	sessionTracker service.SessionTracker
 	vcsProvider    service.VCSProvider
+	idleDetector   service.IdleDetector
+	taskResolver   *TaskResolver
+	sessionManager *SessionManager
 
 	activeTimers     map[string]*entity.TimeLog
 	autoTimers       map[string]*entity.TimeLog
 	currentProjectID string
 	currentTaskID    string
+	idle             bool
+	idlePeriods      []*IdlePeriod
+	focus            *focusSession
+	focusNotifier    func(FocusStatus)
 
 	mu       sync.RWMutex
 	stopChan chan struct{}
@@ -50,12 +58,29 @@ func NewTimeTrackingManager(
 	}
 }
 
+// SetIdleDetector enables idle detection for auto-tracked timers.
+func (tm *TimeTrackingManager) SetIdleDetector(d service.IdleDetector) {
+	tm.idleDetector = d
+}
+
+// SetSessionManager makes auto-tracking resolve directories through the
+// session manager's project and board rules.
+func (tm *TimeTrackingManager) SetSessionManager(sm *SessionManager) {
+	tm.sessionManager = sm
+}
+
 func (tm *TimeTrackingManager) Start(ctx context.Context) error {
 	if !tm.config.TimeTracking.Enabled {
 		fmt.Println("[TimeTrackingManager] Time tracking is disabled in config")
 		return nil
 	}
 
+	resolver, err := NewTaskResolver(tm.config.TimeTracking.Git, tm.backendClient)
+	if err != nil {
+		return err
+	}
+	tm.taskResolver = resolver
+
 	if tm.sessionTracker == nil || !tm.sessionTracker.IsAvailable() {
 		fmt.Println("[TimeTrackingManager] Session tracker not available")
 		return nil
@@ -69,23 +94,44 @@ func (tm *TimeTrackingManager) Start(ctx context.Context) error {
 
 func (tm *TimeTrackingManager) Stop() error {
 	tm.mu.Lock()
-	defer tm.mu.Unlock()
 
 	if tm.stopped {
+		tm.mu.Unlock()
 		return nil
 	}
 
 	tm.stopped = true
 	close(tm.stopChan)
 
-	ctx := context.Background()
+	// Manual, paused and focus timers are logged too, as in StopAll, so
+	// restarting the daemon does not lose them.
+	now := time.Now()
+	var stopped []*entity.TimeLog
+	if tm.focus != nil {
+		if _, log := tm.finishFocusLocked(tm.focus, now); log != nil {
+			stopped = append(stopped, log)
+		}
+	}
+	for key, timer := range tm.activeTimers {
+		if timer.IsRunning() {
+			_ = timer.Stop(now)
+			stopped = append(stopped, timer)
+			fmt.Printf("[TimeTrackingManager] Stopped timer for %s\n", key)
+		}
+		delete(tm.activeTimers, key)
+	}
 	for _, timer := range tm.autoTimers {
 		if timer.IsRunning() {
-			_ = timer.Stop(time.Now())
-			tm.sendTimeLogToBackend(ctx, timer)
+			_ = timer.Stop(now)
+			stopped = append(stopped, timer)
 		}
 	}
+	tm.mu.Unlock()
 
+	ctx := context.Background()
+	for _, timer := range stopped {
+		tm.sendTimeLogToBackend(ctx, timer)
+	}
 	return nil
 }
 
@@ -121,32 +167,204 @@ func (tm *TimeTrackingManager) StartTimer(ctx context.Context, projectID, taskID
 	return log, nil
 }
 
-func (tm *TimeTrackingManager) StopTimer(ctx context.Context, projectID, taskID string) (*entity.TimeLog, error) {
-	tm.mu.Lock()
-	defer tm.mu.Unlock()
+// TimerRef identifies a manual timer by ID, or by the project or task it
+// was started for. An empty ref matches the only running timer.
+type TimerRef struct {
+	ID        string
+	ProjectID string
+	TaskID    string
+}
 
-	key := projectID
-	if taskID != "" {
-		key = taskID
+func (tm *TimeTrackingManager) findTimerLocked(ref TimerRef) (string, *entity.TimeLog, error) {
+	if ref.ID != "" {
+		for key, timer := range tm.activeTimers {
+			if timer.ID() == ref.ID {
+				return key, timer, nil
+			}
+		}
+		return "", nil, entity.ErrTimeLogNotFound
+	}
+
+	if ref.ProjectID != "" || ref.TaskID != "" {
+		key := ref.ProjectID
+		if ref.TaskID != "" {
+			key = ref.TaskID
+		}
+		timer, ok := tm.activeTimers[key]
+		if !ok || !timer.IsRunning() {
+			return "", nil, entity.ErrTimeLogNotFound
+		}
+		return key, timer, nil
 	}
 
-	timer, ok := tm.activeTimers[key]
-	if !ok || !timer.IsRunning() {
-		return nil, entity.ErrTimeLogNotFound
+	switch len(tm.activeTimers) {
+	case 0:
+		return "", nil, entity.ErrTimeLogNotFound
+	case 1:
+		for key, timer := range tm.activeTimers {
+			return key, timer, nil
+		}
+	}
+	return "", nil, fmt.Errorf("%d timers are running; specify a timer ID, project or task", len(tm.activeTimers))
+}
+
+func (tm *TimeTrackingManager) StopTimer(ctx context.Context, ref TimerRef) (*entity.TimeLog, error) {
+	tm.mu.Lock()
+
+	key, timer, err := tm.findTimerLocked(ref)
+	if err != nil {
+		tm.mu.Unlock()
+		return nil, err
 	}
 
 	if err := timer.Stop(time.Now()); err != nil {
+		tm.mu.Unlock()
 		return nil, err
 	}
 
+	focus := tm.endFocusLocked(timer)
+	delete(tm.activeTimers, key)
+	tm.mu.Unlock()
+
 	tm.sendTimeLogToBackend(ctx, timer)
+	if focus != nil {
+		tm.notifyFocus(*focus)
+	}
+	fmt.Printf("[TimeTrackingManager] Stopped timer for %s (duration: %s)\n", key, timer.Duration())
+
+	return timer, nil
+}
+
+// PauseTimer pauses a manual timer; resuming continues the same log, so a
+// short interruption does not produce two backend entries.
+func (tm *TimeTrackingManager) PauseTimer(ref TimerRef) (*entity.TimeLog, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	key, timer, err := tm.findTimerLocked(ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := timer.Pause(time.Now()); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[TimeTrackingManager] Paused timer for %s\n", key)
+	return timer, nil
+}
+
+func (tm *TimeTrackingManager) ResumeTimer(ref TimerRef) (*entity.TimeLog, error) {
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	key, timer, err := tm.findTimerLocked(ref)
+	if err != nil {
+		return nil, err
+	}
+	if err := timer.Resume(time.Now()); err != nil {
+		return nil, err
+	}
+
+	fmt.Printf("[TimeTrackingManager] Resumed timer for %s\n", key)
+	return timer, nil
+}
+
+// UpdateTimer changes the description or task of a running timer. Nil
+// fields are left alone; an empty task ID detaches the timer from its task.
+// A timer moved to a task of another project moves to that project.
+func (tm *TimeTrackingManager) UpdateTimer(ctx context.Context, ref TimerRef, description, taskID *string) (*entity.TimeLog, error) {
+	var newTaskID, newProjectID string
+	if taskID != nil && *taskID != "" {
+		var err error
+		newTaskID, newProjectID, err = tm.ResolveTask(ctx, *taskID)
+		if err != nil {
+			return nil, err
+		}
+	}
 
+	tm.mu.Lock()
+	defer tm.mu.Unlock()
+
+	key, timer, err := tm.findTimerLocked(ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if taskID != nil && newTaskID != timer.TaskID() {
+		newKey := timer.ProjectID()
+		if newTaskID != "" {
+			newKey = newTaskID
+		}
+		if other, ok := tm.activeTimers[newKey]; ok && other != timer {
+			return nil, fmt.Errorf("a timer is already running for %s", newKey)
+		}
+
+		timer.SetTaskID(newTaskID)
+		if newProjectID != "" {
+			timer.SetProjectID(newProjectID)
+		}
+		delete(tm.activeTimers, key)
+		tm.activeTimers[newKey] = timer
+		key = newKey
+	}
+	if description != nil {
+		timer.SetDescription(*description)
+	}
+
+	fmt.Printf("[TimeTrackingManager] Updated timer for %s\n", key)
+	return timer, nil
+}
+
+// DiscardTimer drops a running timer without logging it.
+func (tm *TimeTrackingManager) DiscardTimer(ref TimerRef) (*entity.TimeLog, error) {
+	tm.mu.Lock()
+
+	key, timer, err := tm.findTimerLocked(ref)
+	if err != nil {
+		tm.mu.Unlock()
+		return nil, err
+	}
+
+	focus := tm.endFocusLocked(timer)
 	delete(tm.activeTimers, key)
-	fmt.Printf("[TimeTrackingManager] Stopped timer for %s (duration: %s)\n", key, timer.Duration())
+	tm.mu.Unlock()
 
+	if focus != nil {
+		tm.notifyFocus(*focus)
+	}
+	fmt.Printf("[TimeTrackingManager] Discarded timer for %s (%s)\n", key, timer.Duration().Round(time.Second))
 	return timer, nil
 }
 
+// StopAll stops every running timer, manual and automatic, and sends it to
+// the backend.
+func (tm *TimeTrackingManager) StopAll(ctx context.Context) {
+	tm.mu.Lock()
+
+	var stopped []*entity.TimeLog
+	var focus *FocusStatus
+	for key, timer := range tm.activeTimers {
+		if timer.IsRunning() {
+			_ = timer.Stop(time.Now())
+			stopped = append(stopped, timer)
+			fmt.Printf("[TimeTrackingManager] Stopped timer for %s\n", key)
+		}
+		if status := tm.endFocusLocked(timer); status != nil {
+			focus = status
+		}
+		delete(tm.activeTimers, key)
+	}
+	tm.pauseAutoTimersLocked(ctx)
+	tm.mu.Unlock()
+
+	for _, timer := range stopped {
+		tm.sendTimeLogToBackend(ctx, timer)
+	}
+	if focus != nil {
+		tm.notifyFocus(*focus)
+	}
+}
+
 func (tm *TimeTrackingManager) GetActiveTimers() []*entity.TimeLog {
 	tm.mu.RLock()
 	defer tm.mu.RUnlock()
@@ -175,8 +393,12 @@ func (tm *TimeTrackingManager) pollLoop(ctx context.Context) {
 	ticker := time.NewTicker(pollInterval)
 	defer ticker.Stop()
 
+	events := tm.sessionTracker.Events()
+
 	for {
 		select {
+		case <-events:
+			tm.syncAutoTracking(ctx)
 		case <-ticker.C:
 			tm.syncAutoTracking(ctx)
 		case <-tm.stopChan:
@@ -192,6 +414,10 @@ func (tm *TimeTrackingManager) syncAutoTracking(ctx context.Context) {
 		return
 	}
 
+	if tm.checkIdle(ctx) {
+		return
+	}
+
 	activeSession, err := tm.sessionTracker.GetActiveSession()
 	if err != nil || activeSession == nil {
 		tm.pauseAutoTimers(ctx)
@@ -213,7 +439,7 @@ func (tm *TimeTrackingManager) syncAutoTracking(ctx context.Context) {
 		tm.currentTaskID = taskID
 
 		if projectID != "" {
-			tm.startAutoTimerLocked(ctx, projectID, taskID)
+			tm.startAutoTimerLocked(ctx, projectID, taskID, activeSession.SessionType())
 		}
 	}
 }
@@ -224,49 +450,62 @@ func (tm *TimeTrackingManager) detectProjectAndTask(ctx context.Context, session
 		return "", ""
 	}
 
-	projects, err := tm.backendClient.ListProjects(ctx, 1, 100)
-	if err != nil {
-		return "", ""
-	}
-
-	var matchedProjectID string
-	for _, p := range projects.Items {
-		if p.FilePath != nil && *p.FilePath == workingDir {
-			matchedProjectID = p.ID
-			break
-		}
-	}
-
-	if matchedProjectID == "" {
+	matched := tm.findSessionProject(ctx, workingDir)
+	if matched == nil {
 		return "", ""
 	}
 
 	var taskID string
-	if tm.vcsProvider != nil {
+	if tm.config.TimeTracking.Git.WatchBranches && tm.vcsProvider != nil && tm.taskResolver != nil {
 		branch, err := tm.vcsProvider.GetCurrentBranch(workingDir)
 		if err == nil && branch != "" {
-			taskID = tm.extractTaskIDFromBranch(branch)
+			taskID = tm.taskResolver.Resolve(ctx, matched, branch)
 		}
 	}
 
-	return matchedProjectID, taskID
+	return matched.ID, taskID
 }
 
-func (tm *TimeTrackingManager) extractTaskIDFromBranch(branch string) string {
-	patterns := []string{
-		`^(?:feature|bugfix|fix|hotfix|chore|refactor)/([A-Z]{3}-\d+-[a-z0-9-]+)`,
-		`([A-Z]{3}-\d+-[a-z0-9-]+)`,
+// findSessionProject returns the project a directory is tracked to. With
+// session tracking that is the project the rules resolve it to, which is
+// not created here; ignored directories have none.
+func (tm *TimeTrackingManager) findSessionProject(ctx context.Context, workingDir string) *dto.ProjectDto {
+	if tm.sessionManager != nil {
+		target := tm.sessionManager.sessionTarget(workingDir)
+		if target.ignored {
+			return nil
+		}
+		project, err := tm.sessionManager.findProject(ctx, target.project)
+		if err != nil {
+			return nil
+		}
+		return project
 	}
 
-	for _, pattern := range patterns {
-		re := regexp.MustCompile(pattern)
-		matches := re.FindStringSubmatch(branch)
-		if len(matches) > 1 {
-			return matches[1]
+	projects, err := tm.backendClient.ListProjects(ctx, 1, 100)
+	if err != nil {
+		return nil
+	}
+
+	// The project whose path is closest above the directory, so a pane in
+	// a monorepo sub-app is tracked to the repository's project unless the
+	// sub-app has a project of its own.
+	var matched *dto.ProjectDto
+	for i, p := range projects.Items {
+		if p.FilePath == nil || *p.FilePath == "" || !withinDir(workingDir, *p.FilePath) {
+			continue
+		}
+		if matched == nil || len(*p.FilePath) > len(*matched.FilePath) {
+			matched = &projects.Items[i]
 		}
 	}
+	return matched
+}
 
-	return ""
+// withinDir reports whether path is dir or below it.
+func withinDir(path, dir string) bool {
+	rel, err := filepath.Rel(filepath.Clean(dir), filepath.Clean(path))
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, "../")
 }
 
 func (tm *TimeTrackingManager) pauseAutoTimers(ctx context.Context) {
@@ -288,7 +527,7 @@ func (tm *TimeTrackingManager) pauseAutoTimersLocked(ctx context.Context) {
 	tm.currentTaskID = ""
 }
 
-func (tm *TimeTrackingManager) startAutoTimerLocked(ctx context.Context, projectID, taskID string) {
+func (tm *TimeTrackingManager) startAutoTimerLocked(ctx context.Context, projectID, taskID, sessionType string) {
 	key := projectID
 	if taskID != "" {
 		key = taskID
@@ -307,7 +546,7 @@ func (tm *TimeTrackingManager) startAutoTimerLocked(ctx context.Context, project
 	if taskID != "" {
 		log.SetTaskID(taskID)
 	}
-	log.SetMetadata("session_type", "tmux")
+	log.SetMetadata("session_type", sessionType)
 	log.SetMetadata("auto_tracked", "true")
 
 	tm.autoTimers[key] = log
@@ -320,14 +559,21 @@ func (tm *TimeTrackingManager) startAutoTimerLocked(ctx context.Context, project
 }
 
 func (tm *TimeTrackingManager) sendTimeLogToBackend(ctx context.Context, log *entity.TimeLog) {
-	startTime := log.StartTime().Format(time.RFC3339)
-	durationSecs := int(log.Duration().Seconds())
+	if _, err := tm.backendClient.CreateTimeLog(ctx, timeLogCreateRequest(log)); err != nil {
+		fmt.Printf("[TimeTrackingManager] Failed to send time log to backend: %v\n", err)
+	}
+}
 
+// timeLogCreateRequest converts a stopped log. The backend keeps whole
+// minutes, so the exact end time and any pause segments travel as metadata.
+func timeLogCreateRequest(log *entity.TimeLog) dto.TimeLogCreateRequest {
 	projectID := log.ProjectID()
 	req := dto.TimeLogCreateRequest{
-		ProjectID:   &projectID,
-		StartTime:   startTime,
-		Duration:    &durationSecs,
+		ProjectID:       &projectID,
+		Date:            log.StartTime().Format(time.RFC3339),
+		DurationMinutes: int(log.Duration().Round(time.Minute) / time.Minute),
+		Source:          log.Source().String(),
+		Metadata:        log.Metadata(),
 	}
 
 	if log.TaskID() != "" {
@@ -336,16 +582,32 @@ func (tm *TimeTrackingManager) sendTimeLogToBackend(ctx context.Context, log *en
 	}
 
 	if log.Description() != "" {
-		desc := log.Description()
-		req.Description = &desc
+		req.Metadata["description"] = log.Description()
 	}
 
 	if log.EndTime() != nil {
-		endTime := log.EndTime().Format(time.RFC3339)
-		req.EndTime = &endTime
+		req.Metadata["end_time"] = log.EndTime().Format(time.RFC3339)
 	}
 
-	if _, err := tm.backendClient.CreateTimeLog(ctx, req); err != nil {
-		fmt.Printf("[TimeTrackingManager] Failed to send time log to backend: %v\n", err)
+	if segments := log.Segments(); len(segments) > 1 {
+		req.Metadata["segments"] = formatSegments(segments)
+	}
+
+	if breaks := log.Breaks(); len(breaks) > 0 {
+		req.Metadata["breaks"] = formatSegments(breaks)
+	}
+
+	return req
+}
+
+// formatSegments writes finished segments as "start/end" RFC 3339
+// intervals separated by commas.
+func formatSegments(segments []entity.TimeSegment) string {
+	parts := make([]string, 0, len(segments))
+	for _, seg := range segments {
+		if seg.End != nil {
+			parts = append(parts, seg.Start.Format(time.RFC3339)+"/"+seg.End.Format(time.RFC3339))
+		}
 	}
+	return strings.Join(parts, ",")
 }
@@ -0,0 +1,73 @@
+package daemon
+
+import (
+	"context"
+	"testing"
+
+	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/fakebackend"
+)
+
+func TestWithinDir(t *testing.T) {
+	tests := []struct {
+		path string
+		dir  string
+		want bool
+	}{
+		{"/home/ada/src/cadence", "/home/ada/src/cadence", true},
+		{"/home/ada/src/cadence/apps/tui", "/home/ada/src/cadence", true},
+		{"/home/ada/src/cadence/", "/home/ada/src/cadence", true},
+		{"/home/ada/src/cadence/apps/../docs", "/home/ada/src/cadence/", true},
+		{"/home/ada/src/cadence-web", "/home/ada/src/cadence", false},
+		{"/home/ada/src", "/home/ada/src/cadence", false},
+		{"/home/ada/src/..cadence", "/home/ada/src", true},
+		{"/srv/cadence", "/home/ada/src/cadence", false},
+		{"relative/path", "/home/ada", false},
+	}
+	for _, tt := range tests {
+		if got := withinDir(tt.path, tt.dir); got != tt.want {
+			t.Errorf("withinDir(%q, %q) = %v, want %v", tt.path, tt.dir, got, tt.want)
+		}
+	}
+}
+
+func TestStopLogsManualTimers(t *testing.T) {
+	backend := fakebackend.New()
+	backend.Seed()
+	tm, client := newFakeTimeTracking(backend.Transport())
+	ctx := context.Background()
+
+	task := firstTask(t, client)
+	other, err := client.CreateProject(ctx, dto.ProjectCreateRequest{Name: "Other"})
+	if err != nil {
+		t.Fatalf("CreateProject: %v", err)
+	}
+
+	if _, err := tm.StartTimer(ctx, other.ID, "", "Standup"); err != nil {
+		t.Fatalf("StartTimer: %v", err)
+	}
+	// Moving the timer to a task moves it to the task's project too
+	timer, err := tm.UpdateTimer(ctx, TimerRef{}, nil, &task.ID)
+	if err != nil {
+		t.Fatalf("UpdateTimer: %v", err)
+	}
+	if timer.TaskID() != task.ID || timer.ProjectID() != task.ProjectID {
+		t.Errorf("timer is on task %s of project %s, want task %s of project %s",
+			timer.TaskID(), timer.ProjectID(), task.ID, task.ProjectID)
+	}
+
+	if err := tm.Stop(); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+	if timers := tm.GetActiveTimers(); len(timers) != 0 {
+		t.Errorf("%d timers still active after Stop", len(timers))
+	}
+
+	logs, err := client.ListTimeLogs(ctx, task.ID)
+	if err != nil {
+		t.Fatalf("ListTimeLogs: %v", err)
+	}
+	if len(logs) != 1 || logs[0].ProjectID == nil || *logs[0].ProjectID != task.ProjectID {
+		t.Fatalf("logged %+v, want one log in project %s", logs, task.ProjectID)
+	}
+}
@@ -15,4 +15,10 @@ var (
 	ErrTimeLogAlreadyStopped = errors.New("time log already stopped")
 	ErrInvalidEndTime        = errors.New("end time must be after start time")
 	ErrInvalidDuration       = errors.New("duration must be non-negative")
+	ErrTimeLogPaused         = errors.New("time log is paused")
+	ErrTimeLogNotPaused      = errors.New("time log is not paused")
+	ErrTimeLogOverlap        = errors.New("time log overlaps an existing log")
+	ErrTimeLogInFuture       = errors.New("time log cannot end in the future")
+	ErrFocusSessionRunning   = errors.New("a focus session is already running")
+	ErrNoFocusSession        = errors.New("no focus session is running")
 )
@@ -25,6 +25,13 @@ func (s TimeLogSource) String() string {
 	return string(s)
 }
 
+// TimeSegment is one uninterrupted stretch of work within a time log. End
+// is nil while the segment is running.
+type TimeSegment struct {
+	Start time.Time
+	End   *time.Time
+}
+
 type TimeLog struct {
 	id          string
 	projectID   string
@@ -34,6 +41,8 @@ type TimeLog struct {
 	endTime     *time.Time
 	duration    time.Duration
 	description string
+	segments    []TimeSegment
+	breaks      []TimeSegment
 	metadata    map[string]string
 	createdAt   time.Time
 	modifiedAt  time.Time
@@ -61,6 +70,7 @@ func NewTimeLog(
 		projectID:  projectID,
 		source:     source,
 		startTime:  startTime,
+		segments:   []TimeSegment{{Start: startTime}},
 		metadata:   make(map[string]string),
 		createdAt:  now,
 		modifiedAt: now,
@@ -84,40 +94,150 @@ func (t *TimeLog) EndTime() *time.Time {
 	return &endCopy
 }
 
+// Duration is the time worked: the sum of the segments, so paused time
+// is not counted.
 func (t *TimeLog) Duration() time.Duration {
-	if t.endTime != nil {
-		return t.endTime.Sub(t.startTime)
-	}
-	if t.duration > 0 {
+	if !t.IsRunning() {
 		return t.duration
 	}
-	return time.Since(t.startTime)
+	return t.workedUntil(time.Now())
 }
 
 func (t *TimeLog) IsRunning() bool {
 	return t.endTime == nil && t.duration == 0
 }
 
+// IsPaused reports whether a running log is between Pause and Resume.
+func (t *TimeLog) IsPaused() bool {
+	if !t.IsRunning() || len(t.segments) == 0 {
+		return false
+	}
+	return t.segments[len(t.segments)-1].End != nil
+}
+
+// Segments returns a copy of the work segments.
+func (t *TimeLog) Segments() []TimeSegment {
+	segments := make([]TimeSegment, len(t.segments))
+	for i, seg := range t.segments {
+		segments[i] = TimeSegment{Start: seg.Start}
+		if seg.End != nil {
+			end := *seg.End
+			segments[i].End = &end
+		}
+	}
+	return segments
+}
+
+// Pause ends the current segment at the given time.
+func (t *TimeLog) Pause(at time.Time) error {
+	if !t.IsRunning() {
+		return ErrTimeLogAlreadyStopped
+	}
+	if t.IsPaused() {
+		return ErrTimeLogPaused
+	}
+
+	current := &t.segments[len(t.segments)-1]
+	if at.Before(current.Start) {
+		return ErrInvalidEndTime
+	}
+	current.End = &at
+	t.modifiedAt = time.Now()
+	return nil
+}
+
+// Resume starts a new segment at the given time.
+func (t *TimeLog) Resume(at time.Time) error {
+	if !t.IsRunning() {
+		return ErrTimeLogAlreadyStopped
+	}
+	if !t.IsPaused() {
+		return ErrTimeLogNotPaused
+	}
+	if at.Before(*t.segments[len(t.segments)-1].End) {
+		return ErrInvalidEndTime
+	}
+
+	t.segments = append(t.segments, TimeSegment{Start: at})
+	t.modifiedAt = time.Now()
+	return nil
+}
+
+// AddBreak records a planned break, such as one between focus phases.
+// Breaks are kept apart from pauses so reports can tell rest from
+// interruptions; they are not counted as worked time.
+func (t *TimeLog) AddBreak(start, end time.Time) error {
+	if end.Before(start) || start.Before(t.startTime) {
+		return ErrInvalidEndTime
+	}
+
+	t.breaks = append(t.breaks, TimeSegment{Start: start, End: &end})
+	t.modifiedAt = time.Now()
+	return nil
+}
+
+// Breaks returns a copy of the recorded breaks.
+func (t *TimeLog) Breaks() []TimeSegment {
+	breaks := make([]TimeSegment, len(t.breaks))
+	for i, b := range t.breaks {
+		end := *b.End
+		breaks[i] = TimeSegment{Start: b.Start, End: &end}
+	}
+	return breaks
+}
+
+func (t *TimeLog) workedUntil(now time.Time) time.Duration {
+	if len(t.segments) == 0 {
+		return now.Sub(t.startTime)
+	}
+
+	var total time.Duration
+	for _, seg := range t.segments {
+		end := now
+		if seg.End != nil {
+			end = *seg.End
+		}
+		total += end.Sub(seg.Start)
+	}
+	return total
+}
+
 func (t *TimeLog) SetTaskID(taskID string) {
 	t.taskID = taskID
 	t.modifiedAt = time.Now()
 }
 
+func (t *TimeLog) SetProjectID(projectID string) {
+	t.projectID = projectID
+	t.modifiedAt = time.Now()
+}
+
 func (t *TimeLog) SetDescription(description string) {
 	t.description = description
 	t.modifiedAt = time.Now()
 }
 
+// Stop ends the log. A paused log ends when it was paused.
 func (t *TimeLog) Stop(endTime time.Time) error {
 	if !t.IsRunning() {
 		return ErrTimeLogAlreadyStopped
 	}
+
+	if t.IsPaused() {
+		endTime = *t.segments[len(t.segments)-1].End
+	} else if len(t.segments) > 0 {
+		current := &t.segments[len(t.segments)-1]
+		if endTime.Before(current.Start) {
+			return ErrInvalidEndTime
+		}
+		current.End = &endTime
+	}
 	if endTime.Before(t.startTime) {
 		return ErrInvalidEndTime
 	}
 
 	t.endTime = &endTime
-	t.duration = endTime.Sub(t.startTime)
+	t.duration = t.workedUntil(endTime)
 	t.modifiedAt = time.Now()
 	return nil
 }
@@ -132,6 +252,10 @@ func (t *TimeLog) SetDuration(duration time.Duration) error {
 		endTime := t.startTime.Add(duration)
 		t.endTime = &endTime
 	}
+	if len(t.segments) == 1 && t.segments[0].End == nil {
+		end := t.startTime.Add(duration)
+		t.segments[0].End = &end
+	}
 	t.modifiedAt = time.Now()
 	return nil
 }
@@ -0,0 +1,15 @@
+package service
+
+import "time"
+
+// IdleDetector defines the interface for finding out when the user last
+// interacted with the machine
+// This abstraction allows for different sources (tmux, X11, Wayland compositors, etc.)
+type IdleDetector interface {
+	// LastActivity returns the time of the most recent user input
+	// A zero time means the source has no activity to report
+	LastActivity() (time.Time, error)
+
+	// IsAvailable checks if the source can be queried on this system
+	IsAvailable() bool
+}
@@ -0,0 +1,20 @@
+package service
+
+// Urgency is how insistently a notification should be shown
+type Urgency string
+
+const (
+	UrgencyLow      Urgency = "low"
+	UrgencyNormal   Urgency = "normal"
+	UrgencyCritical Urgency = "critical"
+)
+
+// Notifier defines the interface for showing a notification to the user
+// This abstraction allows for different outputs (desktop notifications, tmux, terminal bell, etc.)
+type Notifier interface {
+	// Notify shows a notification with a title and a body
+	Notify(title, body string, urgency Urgency) error
+
+	// IsAvailable checks if the notifier can reach the user on this system
+	IsAvailable() bool
+}
@@ -1,6 +1,17 @@
 package service
 
-import "cadence/internal/domain/entity"
+import (
+	"time"
+
+	"cadence/internal/domain/entity"
+)
+
+// SessionEvent tells that the active session, or its working directory,
+// may have changed. Type is tracker-specific, such as "session-changed".
+type SessionEvent struct {
+	Type string
+	Time time.Time
+}
 
 // SessionTracker defines the interface for tracking terminal multiplexer sessions
 // This abstraction allows for different implementations (tmux, zellij, screen, etc.)
@@ -14,4 +25,9 @@ type SessionTracker interface {
 	// IsAvailable checks if the session tracker is available on the system
 	// (e.g., tmux is installed and running)
 	IsAvailable() bool
+
+	// Events returns a new channel of session changes for one consumer.
+	// Bursts of changes may arrive as one event. Trackers that cannot push
+	// changes return nil, and consumers keep polling GetActiveSession.
+	Events() <-chan SessionEvent
 }
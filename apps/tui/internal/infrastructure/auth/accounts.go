@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"cadence/internal/infrastructure/config"
+)
+
+// DefaultAccount is the account whose token lives in the configured token
+// file itself, so logins made before accounts existed keep working.
+const DefaultAccount = "default"
+
+// APITokenAccount names the credentials when an API token is in use. It is
+// reserved so no login can collide with the API token's store.
+const APITokenAccount = "api-token"
+
+var accountNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_-]*$`)
+
+// AccountInfo describes one named login.
+type AccountInfo struct {
+	Name    string    `json:"name"`
+	Email   string    `json:"email,omitempty"`
+	AddedAt time.Time `json:"added_at"`
+}
+
+type accountIndex struct {
+	Active   string        `json:"active"`
+	Accounts []AccountInfo `json:"accounts"`
+}
+
+// Accounts keeps several named logins for one profile, e.g. "work" and
+// "personal". Each account's token has its own TokenStore, derived from the
+// configured token file; an index next to it records which one is active.
+type Accounts struct {
+	cfg       config.AuthConfig
+	tokenFile string
+	indexPath string
+}
+
+func NewAccounts(cfg config.AuthConfig) (*Accounts, error) {
+	tokenFile := cfg.TokenFile
+	if tokenFile == "" {
+		fileStore, err := NewFileTokenStore("")
+		if err != nil {
+			return nil, err
+		}
+		tokenFile = fileStore.filePath
+	}
+
+	return &Accounts{
+		cfg:       cfg,
+		tokenFile: tokenFile,
+		indexPath: strings.TrimSuffix(tokenFile, filepath.Ext(tokenFile)) + ".accounts.json",
+	}, nil
+}
+
+// List returns the known accounts and the name of the active one.
+func (a *Accounts) List() ([]AccountInfo, string, error) {
+	idx, err := a.load()
+	if err != nil {
+		return nil, "", err
+	}
+	return idx.Accounts, idx.Active, nil
+}
+
+func (a *Accounts) Active() (string, error) {
+	idx, err := a.load()
+	if err != nil {
+		return "", err
+	}
+	return idx.Active, nil
+}
+
+// Store returns the token store for the named account.
+func (a *Accounts) Store(name string) (TokenStore, error) {
+	if err := ValidateAccountName(name); err != nil {
+		return nil, err
+	}
+
+	cfg := a.cfg
+	cfg.TokenFile = a.tokenFile
+	if name != DefaultAccount {
+		ext := filepath.Ext(a.tokenFile)
+		cfg.TokenFile = strings.TrimSuffix(a.tokenFile, ext) + "." + name + ext
+	}
+	return NewTokenStore(cfg)
+}
+
+// ActiveStore returns the token store of the active account.
+func (a *Accounts) ActiveStore() (TokenStore, error) {
+	name, err := a.Active()
+	if err != nil {
+		return nil, err
+	}
+	return a.Store(name)
+}
+
+// Add registers name after a successful login and makes it active.
+func (a *Accounts) Add(name string) error {
+	if err := ValidateAccountName(name); err != nil {
+		return err
+	}
+
+	idx, err := a.load()
+	if err != nil {
+		return err
+	}
+
+	if findAccount(idx.Accounts, name) < 0 {
+		idx.Accounts = append(idx.Accounts, AccountInfo{Name: name, AddedAt: time.Now()})
+	}
+	idx.Active = name
+	return a.save(idx)
+}
+
+// Use makes an already signed-in account the active one.
+func (a *Accounts) Use(name string) error {
+	idx, err := a.load()
+	if err != nil {
+		return err
+	}
+
+	if findAccount(idx.Accounts, name) < 0 {
+		return fmt.Errorf("unknown account %q (run `cadence login --account %s` first)", name, name)
+	}
+	idx.Active = name
+	return a.save(idx)
+}
+
+// SetEmail records the email the backend reported for an account, for
+// display in `cadence auth list`.
+func (a *Accounts) SetEmail(name, email string) error {
+	idx, err := a.load()
+	if err != nil {
+		return err
+	}
+
+	i := findAccount(idx.Accounts, name)
+	if i < 0 || idx.Accounts[i].Email == email {
+		return nil
+	}
+	idx.Accounts[i].Email = email
+	return a.save(idx)
+}
+
+// Remove clears the account's token and forgets it. If it was active, the
+// first remaining account becomes active.
+func (a *Accounts) Remove(name string) error {
+	store, err := a.Store(name)
+	if err != nil {
+		return err
+	}
+	if err := store.Clear(); err != nil {
+		return err
+	}
+
+	idx, err := a.load()
+	if err != nil {
+		return err
+	}
+
+	if i := findAccount(idx.Accounts, name); i >= 0 {
+		idx.Accounts = append(idx.Accounts[:i], idx.Accounts[i+1:]...)
+	}
+	if idx.Active == name {
+		idx.Active = DefaultAccount
+		if len(idx.Accounts) > 0 {
+			idx.Active = idx.Accounts[0].Name
+		}
+	}
+	return a.save(idx)
+}
+
+func ValidateAccountName(name string) error {
+	if !accountNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid account name %q: use letters, digits, '-' and '_'", name)
+	}
+	if name == APITokenAccount {
+		return fmt.Errorf("account name %q is reserved", name)
+	}
+	return nil
+}
+
+func (a *Accounts) load() (*accountIndex, error) {
+	idx := &accountIndex{Active: DefaultAccount}
+
+	data, err := os.ReadFile(a.indexPath)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read accounts file: %w", err)
+	}
+	if err == nil {
+		if err := json.Unmarshal(data, idx); err != nil {
+			return nil, fmt.Errorf("failed to parse accounts file: %w", err)
+		}
+	}
+
+	// A token saved before accounts existed shows up as the default account.
+	if findAccount(idx.Accounts, DefaultAccount) < 0 {
+		if store, err := a.Store(DefaultAccount); err == nil && store.Exists() {
+			idx.Accounts = append([]AccountInfo{{Name: DefaultAccount}}, idx.Accounts...)
+		}
+	}
+
+	return idx, nil
+}
+
+func (a *Accounts) save(idx *accountIndex) error {
+	if err := os.MkdirAll(filepath.Dir(a.indexPath), 0700); err != nil {
+		return fmt.Errorf("failed to create auth directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(idx, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal accounts: %w", err)
+	}
+
+	if err := os.WriteFile(a.indexPath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write accounts file: %w", err)
+	}
+	return nil
+}
+
+func findAccount(accounts []AccountInfo, name string) int {
+	for i := range accounts {
+		if accounts[i].Name == name {
+			return i
+		}
+	}
+	return -1
+}
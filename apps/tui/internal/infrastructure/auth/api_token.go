@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"cadence/internal/infrastructure/config"
+)
+
+// APITokenEnvVar supplies a long-lived API token, e.g. from a CI secret.
+const APITokenEnvVar = "CADENCE_API_TOKEN"
+
+// NewAPITokenStore returns the store used by `cadence auth token set`. It
+// sits next to the session token file and uses the same backend.
+func NewAPITokenStore(cfg config.AuthConfig) (TokenStore, error) {
+	accounts, err := NewAccounts(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	ext := filepath.Ext(accounts.tokenFile)
+	cfg.TokenFile = strings.TrimSuffix(accounts.tokenFile, ext) + "." + APITokenAccount + ext
+	return NewTokenStore(cfg)
+}
+
+// APIToken returns the API token to use instead of a login session, or ""
+// if none is configured. CADENCE_API_TOKEN wins over backend.api_token,
+// which wins over a token saved with `cadence auth token set`.
+func APIToken(cfg *config.Config) (string, error) {
+	if token := strings.TrimSpace(os.Getenv(APITokenEnvVar)); token != "" {
+		return token, nil
+	}
+	if token := strings.TrimSpace(cfg.Backend.APIToken); token != "" {
+		return token, nil
+	}
+
+	store, err := NewAPITokenStore(cfg.Auth)
+	if err != nil {
+		return "", err
+	}
+	if !store.Exists() {
+		return "", nil
+	}
+	return store.Load()
+}
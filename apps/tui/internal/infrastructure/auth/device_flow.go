@@ -0,0 +1,166 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+const (
+	deviceClientID  = "cadence-tui"
+	deviceGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+	defaultPollInterval = 5 * time.Second
+)
+
+// DeviceFlow signs in with the OAuth device authorization grant: the user
+// approves a short code on any device with a browser while this process
+// polls the backend. It needs no local browser or callback port, so it works
+// over SSH and on headless machines.
+type DeviceFlow struct {
+	backendURL string
+	tokenStore TokenStore
+	httpClient *http.Client
+}
+
+func NewDeviceFlow(backendURL string, tokenStore TokenStore) *DeviceFlow {
+	return &DeviceFlow{
+		backendURL: backendURL,
+		tokenStore: tokenStore,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+type deviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+type deviceTokenResponse struct {
+	AccessToken      string `json:"access_token"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+func (f *DeviceFlow) Execute(ctx context.Context) error {
+	code, err := f.requestCode(ctx)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("To sign in, open:\n\n  %s\n\nand enter the code: %s\n\n", code.VerificationURI, code.UserCode)
+	if code.VerificationURIComplete != "" {
+		fmt.Printf("Or open this link directly:\n\n  %s\n\n", code.VerificationURIComplete)
+	}
+	fmt.Println("Waiting for approval...")
+
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	expiresIn := time.Duration(code.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = 15 * time.Minute
+	}
+	ctx, cancel := context.WithTimeout(ctx, expiresIn)
+	defer cancel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("device code expired before it was approved")
+		case <-time.After(interval):
+		}
+
+		token, err := f.pollToken(ctx, code.DeviceCode)
+		if err != nil {
+			return err
+		}
+
+		switch token.Error {
+		case "":
+			if token.AccessToken == "" {
+				return fmt.Errorf("backend approved the device but returned no token")
+			}
+			if err := f.tokenStore.Save(token.AccessToken); err != nil {
+				return fmt.Errorf("failed to save token: %w", err)
+			}
+			return nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return fmt.Errorf("login was denied")
+		case "expired_token":
+			return fmt.Errorf("device code expired before it was approved")
+		default:
+			return fmt.Errorf("device login failed: %s %s", token.Error, token.ErrorDescription)
+		}
+	}
+}
+
+func (f *DeviceFlow) requestCode(ctx context.Context) (*deviceCodeResponse, error) {
+	var code deviceCodeResponse
+	status, err := f.post(ctx, "/api/auth/device/code", map[string]string{
+		"client_id": deviceClientID,
+	}, &code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to request device code: %w", err)
+	}
+	if status == http.StatusNotFound {
+		return nil, fmt.Errorf("backend does not support device login; use --no-browser instead")
+	}
+	if status != http.StatusOK || code.DeviceCode == "" {
+		return nil, fmt.Errorf("device code request failed with status %d", status)
+	}
+	return &code, nil
+}
+
+func (f *DeviceFlow) pollToken(ctx context.Context, deviceCode string) (*deviceTokenResponse, error) {
+	var token deviceTokenResponse
+	status, err := f.post(ctx, "/api/auth/device/token", map[string]string{
+		"grant_type":  deviceGrantType,
+		"device_code": deviceCode,
+		"client_id":   deviceClientID,
+	}, &token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to poll for token: %w", err)
+	}
+
+	// Pending and error states come back as 400 with an "error" field.
+	if status != http.StatusOK && token.Error == "" {
+		return nil, fmt.Errorf("token request failed with status %d", status)
+	}
+	return &token, nil
+}
+
+func (f *DeviceFlow) post(ctx context.Context, path string, body interface{}, result interface{}) (int, error) {
+	jsonBody, err := json.Marshal(body)
+	if err != nil {
+		return 0, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, f.backendURL+path, bytes.NewReader(jsonBody))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// Error bodies are decoded too; the caller checks the status.
+	_ = json.NewDecoder(resp.Body).Decode(result)
+	return resp.StatusCode, nil
+}
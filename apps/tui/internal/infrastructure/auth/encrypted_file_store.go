@@ -0,0 +1,190 @@
+package auth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/x/term"
+)
+
+const (
+	// PassphraseEnvVar supplies the passphrase for the encrypted token file
+	// to processes that cannot prompt, such as the daemon.
+	PassphraseEnvVar = "CADENCE_TOKEN_PASSPHRASE"
+
+	encryptedFileVersion = 1
+	pbkdf2Iterations     = 600000
+	saltSize             = 16
+	keySize              = 32
+)
+
+type encryptedTokenFile struct {
+	Version    int    `json:"version"`
+	Iterations int    `json:"iterations"`
+	Salt       []byte `json:"salt"`
+	Nonce      []byte `json:"nonce"`
+	Ciphertext []byte `json:"ciphertext"`
+}
+
+// EncryptedFileTokenStore keeps the token in a file encrypted with
+// AES-256-GCM under a key derived from a passphrase with PBKDF2.
+type EncryptedFileTokenStore struct {
+	filePath       string
+	passphraseFile string
+	passphrase     []byte
+}
+
+// NewEncryptedFileTokenStore reads the passphrase from CADENCE_TOKEN_PASSPHRASE,
+// then passphraseFile, and finally prompts when stdin is a terminal.
+func NewEncryptedFileTokenStore(filePath, passphraseFile string) *EncryptedFileTokenStore {
+	return &EncryptedFileTokenStore{
+		filePath:       filePath,
+		passphraseFile: passphraseFile,
+	}
+}
+
+func (s *EncryptedFileTokenStore) Save(token string) error {
+	passphrase, err := s.getPassphrase()
+	if err != nil {
+		return err
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	gcm, err := newGCM(passphrase, salt, pbkdf2Iterations)
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	data, err := json.Marshal(encryptedTokenFile{
+		Version:    encryptedFileVersion,
+		Iterations: pbkdf2Iterations,
+		Salt:       salt,
+		Nonce:      nonce,
+		Ciphertext: gcm.Seal(nil, nonce, []byte(token), nil),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal token: %w", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.filePath), 0700); err != nil {
+		return fmt.Errorf("failed to create auth directory: %w", err)
+	}
+
+	if err := os.WriteFile(s.filePath, data, 0600); err != nil {
+		return fmt.Errorf("failed to write token file: %w", err)
+	}
+
+	return nil
+}
+
+func (s *EncryptedFileTokenStore) Load() (string, error) {
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read token file: %w", err)
+	}
+
+	var file encryptedTokenFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return "", fmt.Errorf("failed to parse token file: %w", err)
+	}
+	if file.Version != encryptedFileVersion {
+		return "", fmt.Errorf("unsupported token file version %d", file.Version)
+	}
+
+	passphrase, err := s.getPassphrase()
+	if err != nil {
+		return "", err
+	}
+
+	gcm, err := newGCM(passphrase, file.Salt, file.Iterations)
+	if err != nil {
+		return "", err
+	}
+
+	token, err := gcm.Open(nil, file.Nonce, file.Ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt token file (wrong passphrase?)")
+	}
+
+	return string(token), nil
+}
+
+func (s *EncryptedFileTokenStore) Clear() error {
+	if err := os.Remove(s.filePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove token file: %w", err)
+	}
+	return nil
+}
+
+func (s *EncryptedFileTokenStore) Exists() bool {
+	_, err := os.Stat(s.filePath)
+	return err == nil
+}
+
+func (s *EncryptedFileTokenStore) getPassphrase() ([]byte, error) {
+	if s.passphrase != nil {
+		return s.passphrase, nil
+	}
+
+	switch {
+	case os.Getenv(PassphraseEnvVar) != "":
+		s.passphrase = []byte(os.Getenv(PassphraseEnvVar))
+	case s.passphraseFile != "":
+		data, err := os.ReadFile(s.passphraseFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase file: %w", err)
+		}
+		s.passphrase = []byte(strings.TrimRight(string(data), "\r\n"))
+	case term.IsTerminal(os.Stdin.Fd()):
+		fmt.Fprint(os.Stderr, "Token passphrase: ")
+		passphrase, err := term.ReadPassword(os.Stdin.Fd())
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		s.passphrase = passphrase
+	default:
+		return nil, fmt.Errorf("token passphrase required: set %s or auth.passphrase_file", PassphraseEnvVar)
+	}
+
+	if len(s.passphrase) == 0 {
+		return nil, fmt.Errorf("token passphrase is empty")
+	}
+	return s.passphrase, nil
+}
+
+func newGCM(passphrase, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, string(passphrase), salt, iterations, keySize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return gcm, nil
+}
@@ -0,0 +1,92 @@
+//go:build linux
+
+package auth
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// keyPerm grants the possessor and the owning user full access, so a daemon
+// started outside the login session can still read the key.
+const keyPerm = 0x3f3f0000
+
+// KeyringTokenStore keeps the token as a "user" key in the Linux kernel
+// user keyring. Keys live in memory only and are gone after a reboot.
+type KeyringTokenStore struct {
+	key string
+}
+
+func NewKeyringTokenStore(key string) *KeyringTokenStore {
+	return &KeyringTokenStore{key: key}
+}
+
+// Available reports whether the kernel keyring can be used.
+func (s *KeyringTokenStore) Available() bool {
+	_, err := unix.KeyctlGetKeyringID(unix.KEY_SPEC_USER_KEYRING, true)
+	return err == nil
+}
+
+func (s *KeyringTokenStore) Save(token string) error {
+	id, err := unix.AddKey("user", s.key, []byte(token), unix.KEY_SPEC_USER_KEYRING)
+	if err != nil {
+		return fmt.Errorf("failed to add key to keyring: %w", err)
+	}
+
+	if err := unix.KeyctlSetperm(id, keyPerm); err != nil {
+		return fmt.Errorf("failed to set key permissions: %w", err)
+	}
+
+	return nil
+}
+
+func (s *KeyringTokenStore) Load() (string, error) {
+	id, err := s.search()
+	if err != nil {
+		return "", err
+	}
+
+	size, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, nil, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to read key: %w", err)
+	}
+
+	buf := make([]byte, size)
+	n, err := unix.KeyctlBuffer(unix.KEYCTL_READ, id, buf, 0)
+	if err != nil {
+		return "", fmt.Errorf("failed to read key: %w", err)
+	}
+
+	return string(buf[:n]), nil
+}
+
+func (s *KeyringTokenStore) Clear() error {
+	id, err := unix.KeyctlSearch(unix.KEY_SPEC_USER_KEYRING, "user", s.key, 0)
+	if errors.Is(err, unix.ENOKEY) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to search keyring: %w", err)
+	}
+
+	if _, err := unix.KeyctlInt(unix.KEYCTL_UNLINK, id, unix.KEY_SPEC_USER_KEYRING, 0, 0); err != nil {
+		return fmt.Errorf("failed to remove key: %w", err)
+	}
+
+	return nil
+}
+
+func (s *KeyringTokenStore) Exists() bool {
+	_, err := s.search()
+	return err == nil
+}
+
+func (s *KeyringTokenStore) search() (int, error) {
+	id, err := unix.KeyctlSearch(unix.KEY_SPEC_USER_KEYRING, "user", s.key, 0)
+	if err != nil {
+		return 0, fmt.Errorf("token %s not found in keyring: %w", s.key, err)
+	}
+	return id, nil
+}
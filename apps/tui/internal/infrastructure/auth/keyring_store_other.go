@@ -0,0 +1,36 @@
+//go:build !linux
+
+package auth
+
+import "fmt"
+
+// KeyringTokenStore is only implemented on Linux.
+type KeyringTokenStore struct {
+	key string
+}
+
+func NewKeyringTokenStore(key string) *KeyringTokenStore {
+	return &KeyringTokenStore{key: key}
+}
+
+func (s *KeyringTokenStore) Available() bool {
+	return false
+}
+
+func (s *KeyringTokenStore) Save(token string) error {
+	return errKeyringUnsupported
+}
+
+func (s *KeyringTokenStore) Load() (string, error) {
+	return "", errKeyringUnsupported
+}
+
+func (s *KeyringTokenStore) Clear() error {
+	return errKeyringUnsupported
+}
+
+func (s *KeyringTokenStore) Exists() bool {
+	return false
+}
+
+var errKeyringUnsupported = fmt.Errorf("the kernel keyring token store is only supported on Linux")
@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -9,6 +10,7 @@ import (
 	"net"
 	"net/http"
 	"net/url"
+	"os"
 	"os/exec"
 	"strings"
 	"time"
@@ -16,38 +18,142 @@ import (
 
 type OAuthFlow struct {
 	backendURL string
-	tokenStore *TokenStore
+	tokenStore TokenStore
 }
 
-func NewOAuthFlow(backendURL string, tokenStore *TokenStore) *OAuthFlow {
+func NewOAuthFlow(backendURL string, tokenStore TokenStore) *OAuthFlow {
 	return &OAuthFlow{
 		backendURL: backendURL,
 		tokenStore: tokenStore,
 	}
 }
 
+// Execute runs the browser login: it opens the sign-in page with xdg-open
+// and waits for the backend to redirect to a localhost callback.
 func (f *OAuthFlow) Execute() error {
-	listener, err := net.Listen("tcp", "localhost:0")
+	callback, err := f.startCallbackServer()
 	if err != nil {
-		return fmt.Errorf("failed to start callback server: %w", err)
+		return err
 	}
-	defer listener.Close()
+	defer callback.shutdown()
 
-	port := listener.Addr().(*net.TCPAddr).Port
-	callbackURL := fmt.Sprintf("http://localhost:%d/callback", port)
+	proxyURL, err := f.signInURL(callback.url)
+	if err != nil {
+		return err
+	}
 
-	// Make POST request to initiate OAuth flow
-	signInURL, err := f.initiateOAuth(callbackURL)
+	fmt.Printf("Opening browser for authentication...\n")
+	if err := exec.Command("xdg-open", proxyURL).Start(); err != nil {
+		return fmt.Errorf("failed to open browser: %w (please open manually: %s)", err, proxyURL)
+	}
+
+	select {
+	case err := <-callback.result:
+		return err
+	case <-time.After(60 * time.Second):
+		return fmt.Errorf("login timed out after 60 seconds")
+	}
+}
+
+// ExecuteManual is the login for sessions without a local browser. It prints
+// the sign-in URL and accepts either the localhost callback (if the browser
+// can reach it) or the callback URL or token pasted on in.
+func (f *OAuthFlow) ExecuteManual(in io.Reader) error {
+	callback, err := f.startCallbackServer()
+	if err != nil {
+		return err
+	}
+	defer callback.shutdown()
+
+	proxyURL, err := f.signInURL(callback.url)
 	if err != nil {
-		return fmt.Errorf("failed to initiate OAuth: %w", err)
+		return err
 	}
 
-	// Route through the expo authorization proxy so the browser gets the
-	// signed state cookie (the Go HTTP client received it above, but the
-	// browser needs it for the callback validation).
-	proxyURL := fmt.Sprintf("%s/api/auth/expo-authorization-proxy?authorizationURL=%s",
-		f.backendURL, url.QueryEscape(signInURL))
+	fmt.Printf("Open this URL in a browser to sign in:\n\n  %s\n\n", proxyURL)
+	fmt.Println("After signing in, the browser is redirected to a localhost URL that may fail to load.")
+	fmt.Print("Paste that URL (or the session token) here: ")
+
+	pasted := make(chan error, 1)
+	go func() {
+		line, err := bufio.NewReader(in).ReadString('\n')
+		if err != nil && line == "" {
+			pasted <- fmt.Errorf("failed to read input: %w", err)
+			return
+		}
 
+		token := tokenFromPasted(line)
+		if token == "" {
+			pasted <- fmt.Errorf("no session token found in input")
+			return
+		}
+		if err := f.tokenStore.Save(token); err != nil {
+			pasted <- fmt.Errorf("failed to save token: %w", err)
+			return
+		}
+		pasted <- nil
+	}()
+
+	select {
+	case err := <-callback.result:
+		if err == nil {
+			fmt.Println()
+		}
+		return err
+	case err := <-pasted:
+		return err
+	case <-time.After(10 * time.Minute):
+		return fmt.Errorf("login timed out after 10 minutes")
+	}
+}
+
+// HasLocalBrowser reports whether xdg-open can plausibly show a browser:
+// there is a graphical session and we are not on the far end of SSH.
+func HasLocalBrowser() bool {
+	if os.Getenv("SSH_CONNECTION") != "" || os.Getenv("SSH_TTY") != "" {
+		return false
+	}
+	return os.Getenv("DISPLAY") != "" || os.Getenv("WAYLAND_DISPLAY") != ""
+}
+
+// tokenFromPasted accepts a callback URL carrying the "cookie" parameter, a
+// raw Set-Cookie value, or a bare session token.
+func tokenFromPasted(input string) string {
+	input = strings.TrimSpace(input)
+	if input == "" {
+		return ""
+	}
+
+	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
+		parsed, err := url.Parse(input)
+		if err != nil {
+			return ""
+		}
+		return parseSessionToken(parsed.Query().Get("cookie"))
+	}
+
+	if strings.Contains(input, "session_token=") {
+		return parseSessionToken(input)
+	}
+
+	return input
+}
+
+type callbackServer struct {
+	url      string
+	result   chan error
+	shutdown func()
+}
+
+// startCallbackServer listens on a random localhost port for the backend's
+// OAuth redirect and stores the session token it carries.
+func (f *OAuthFlow) startCallbackServer() (*callbackServer, error) {
+	listener, err := net.Listen("tcp", "localhost:0")
+	if err != nil {
+		return nil, fmt.Errorf("failed to start callback server: %w", err)
+	}
+
+	port := listener.Addr().(*net.TCPAddr).Port
 	resultChan := make(chan error, 1)
 
 	mux := http.NewServeMux()
@@ -85,23 +191,30 @@ func (f *OAuthFlow) Execute() error {
 		}
 	}()
 
-	fmt.Printf("Opening browser for authentication...\n")
-	if err := exec.Command("xdg-open", proxyURL).Start(); err != nil {
-		return fmt.Errorf("failed to open browser: %w (please open manually: %s)", err, proxyURL)
-	}
+	return &callbackServer{
+		url:    fmt.Sprintf("http://localhost:%d/callback", port),
+		result: resultChan,
+		shutdown: func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+			defer cancel()
+			server.Shutdown(ctx)
+		},
+	}, nil
+}
 
-	select {
-	case err := <-resultChan:
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		defer cancel()
-		server.Shutdown(ctx)
-		return err
-	case <-time.After(60 * time.Second):
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		defer cancel()
-		server.Shutdown(ctx)
-		return fmt.Errorf("login timed out after 60 seconds")
+// signInURL starts the OAuth flow and returns the URL to open in a browser.
+func (f *OAuthFlow) signInURL(callbackURL string) (string, error) {
+	// Make POST request to initiate OAuth flow
+	signInURL, err := f.initiateOAuth(callbackURL)
+	if err != nil {
+		return "", fmt.Errorf("failed to initiate OAuth: %w", err)
 	}
+
+	// Route through the expo authorization proxy so the browser gets the
+	// signed state cookie (the Go HTTP client received it above, but the
+	// browser needs it for the callback validation).
+	return fmt.Sprintf("%s/api/auth/expo-authorization-proxy?authorizationURL=%s",
+		f.backendURL, url.QueryEscape(signInURL)), nil
 }
 
 func (f *OAuthFlow) initiateOAuth(callbackURL string) (string, error) {
@@ -0,0 +1,264 @@
+package auth
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	secretServiceName     = "org.freedesktop.secrets"
+	secretServicePath     = "/org/freedesktop/secrets"
+	secretServiceIface    = "org.freedesktop.Secret.Service"
+	secretCollectionIface = "org.freedesktop.Secret.Collection"
+	secretItemIface       = "org.freedesktop.Secret.Item"
+	secretPromptIface     = "org.freedesktop.Secret.Prompt"
+	defaultCollectionPath = "/org/freedesktop/secrets/aliases/default"
+
+	// promptTimeout bounds how long we wait for the user to unlock the
+	// keyring in their desktop's prompt.
+	promptTimeout = 2 * time.Minute
+)
+
+// secret mirrors the Secret Service (oayays) struct.
+type secret struct {
+	Session     dbus.ObjectPath
+	Parameters  []byte
+	Value       []byte
+	ContentType string
+}
+
+// SecretServiceTokenStore keeps the token in the freedesktop Secret Service
+// (GNOME Keyring, KWallet, KeePassXC) over the session D-Bus.
+type SecretServiceTokenStore struct {
+	key string
+}
+
+func NewSecretServiceTokenStore(key string) *SecretServiceTokenStore {
+	return &SecretServiceTokenStore{key: key}
+}
+
+// Available reports whether a Secret Service provider is on the session bus.
+func (s *SecretServiceTokenStore) Available() bool {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	var hasOwner bool
+	err = conn.BusObject().Call("org.freedesktop.DBus.NameHasOwner", 0, secretServiceName).Store(&hasOwner)
+	if err == nil && hasOwner {
+		return true
+	}
+
+	// The provider may be D-Bus activatable without running yet.
+	var activatable []string
+	if err := conn.BusObject().Call("org.freedesktop.DBus.ListActivatableNames", 0).Store(&activatable); err != nil {
+		return false
+	}
+	for _, name := range activatable {
+		if name == secretServiceName {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *SecretServiceTokenStore) Save(token string) error {
+	return s.withSession(func(conn *dbus.Conn, session dbus.ObjectPath) error {
+		collection := dbus.ObjectPath(defaultCollectionPath)
+		if err := s.unlock(conn, []dbus.ObjectPath{collection}); err != nil {
+			return err
+		}
+
+		props := map[string]dbus.Variant{
+			secretItemIface + ".Label":      dbus.MakeVariant("Cadence session token (" + s.key + ")"),
+			secretItemIface + ".Attributes": dbus.MakeVariant(s.attributes()),
+		}
+		value := secret{Session: session, Value: []byte(token), ContentType: "text/plain"}
+
+		var item, prompt dbus.ObjectPath
+		err := conn.Object(secretServiceName, collection).
+			Call(secretCollectionIface+".CreateItem", 0, props, value, true).
+			Store(&item, &prompt)
+		if err != nil {
+			return fmt.Errorf("failed to store secret: %w", err)
+		}
+
+		return s.prompt(conn, prompt)
+	})
+}
+
+func (s *SecretServiceTokenStore) Load() (string, error) {
+	var token string
+	err := s.withSession(func(conn *dbus.Conn, session dbus.ObjectPath) error {
+		items, err := s.search(conn)
+		if err != nil {
+			return err
+		}
+		if len(items) == 0 {
+			return fmt.Errorf("token %s not found in secret service", s.key)
+		}
+
+		var value secret
+		if err := conn.Object(secretServiceName, items[0]).
+			Call(secretItemIface+".GetSecret", 0, session).
+			Store(&value); err != nil {
+			return fmt.Errorf("failed to read secret: %w", err)
+		}
+
+		token = string(value.Value)
+		return nil
+	})
+	return token, err
+}
+
+func (s *SecretServiceTokenStore) Clear() error {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return fmt.Errorf("failed to connect to session bus: %w", err)
+	}
+	defer conn.Close()
+
+	items, err := s.search(conn)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range items {
+		var prompt dbus.ObjectPath
+		if err := conn.Object(secretServiceName, item).
+			Call(secretItemIface+".Delete", 0).
+			Store(&prompt); err != nil {
+			return fmt.Errorf("failed to delete secret: %w", err)
+		}
+		if err := s.prompt(conn, prompt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SecretServiceTokenStore) Exists() bool {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return false
+	}
+	defer conn.Close()
+
+	var unlocked, locked []dbus.ObjectPath
+	if err := s.service(conn).
+		Call(secretServiceIface+".SearchItems", 0, s.attributes()).
+		Store(&unlocked, &locked); err != nil {
+		return false
+	}
+	return len(unlocked)+len(locked) > 0
+}
+
+func (s *SecretServiceTokenStore) attributes() map[string]string {
+	return map[string]string{
+		"application": "cadence",
+		"key":         s.key,
+	}
+}
+
+func (s *SecretServiceTokenStore) service(conn *dbus.Conn) dbus.BusObject {
+	return conn.Object(secretServiceName, secretServicePath)
+}
+
+// withSession opens a plain-transfer session for the duration of fn. The
+// secret travels unencrypted over the local session bus, which is only
+// reachable by the user.
+func (s *SecretServiceTokenStore) withSession(fn func(conn *dbus.Conn, session dbus.ObjectPath) error) error {
+	conn, err := dbus.ConnectSessionBus()
+	if err != nil {
+		return fmt.Errorf("failed to connect to session bus: %w", err)
+	}
+	defer conn.Close()
+
+	var output dbus.Variant
+	var session dbus.ObjectPath
+	if err := s.service(conn).
+		Call(secretServiceIface+".OpenSession", 0, "plain", dbus.MakeVariant("")).
+		Store(&output, &session); err != nil {
+		return fmt.Errorf("failed to open secret service session: %w", err)
+	}
+	defer conn.Object(secretServiceName, session).Call("org.freedesktop.Secret.Session.Close", 0)
+
+	return fn(conn, session)
+}
+
+// search returns the matching items, unlocking them if needed.
+func (s *SecretServiceTokenStore) search(conn *dbus.Conn) ([]dbus.ObjectPath, error) {
+	var unlocked, locked []dbus.ObjectPath
+	if err := s.service(conn).
+		Call(secretServiceIface+".SearchItems", 0, s.attributes()).
+		Store(&unlocked, &locked); err != nil {
+		return nil, fmt.Errorf("failed to search secret service: %w", err)
+	}
+
+	if len(locked) > 0 {
+		if err := s.unlock(conn, locked); err != nil {
+			return nil, err
+		}
+		unlocked = append(unlocked, locked...)
+	}
+
+	return unlocked, nil
+}
+
+func (s *SecretServiceTokenStore) unlock(conn *dbus.Conn, objects []dbus.ObjectPath) error {
+	var unlocked []dbus.ObjectPath
+	var prompt dbus.ObjectPath
+	if err := s.service(conn).
+		Call(secretServiceIface+".Unlock", 0, objects).
+		Store(&unlocked, &prompt); err != nil {
+		return fmt.Errorf("failed to unlock secret service: %w", err)
+	}
+	return s.prompt(conn, prompt)
+}
+
+// prompt shows a Secret Service prompt, if one is required, and waits for
+// the user to complete it.
+func (s *SecretServiceTokenStore) prompt(conn *dbus.Conn, prompt dbus.ObjectPath) error {
+	if prompt == "" || prompt == "/" {
+		return nil
+	}
+
+	if err := conn.AddMatchSignal(
+		dbus.WithMatchObjectPath(prompt),
+		dbus.WithMatchInterface(secretPromptIface),
+		dbus.WithMatchMember("Completed"),
+	); err != nil {
+		return fmt.Errorf("failed to watch prompt: %w", err)
+	}
+
+	signals := make(chan *dbus.Signal, 1)
+	conn.Signal(signals)
+	defer conn.RemoveSignal(signals)
+
+	if err := conn.Object(secretServiceName, prompt).Call(secretPromptIface+".Prompt", 0, "").Err; err != nil {
+		return fmt.Errorf("failed to show prompt: %w", err)
+	}
+
+	timeout := time.After(promptTimeout)
+	for {
+		select {
+		case sig := <-signals:
+			if sig.Path != prompt || sig.Name != secretPromptIface+".Completed" {
+				continue
+			}
+			if len(sig.Body) > 0 {
+				if dismissed, ok := sig.Body[0].(bool); ok && dismissed {
+					return fmt.Errorf("secret service prompt was dismissed")
+				}
+			}
+			return nil
+		case <-timeout:
+			return fmt.Errorf("timed out waiting for secret service prompt")
+		}
+	}
+}
@@ -5,28 +5,98 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+
+	"cadence/internal/infrastructure/config"
+)
+
+// Token store backends selectable with auth.store in config.yml.
+const (
+	StoreFile          = "file"
+	StoreSecretService = "secret-service"
+	StoreKeyring       = "keyring"
+	StoreEncryptedFile = "encrypted-file"
+	StoreAuto          = "auto"
 )
 
+// TokenStore persists the backend session token.
+type TokenStore interface {
+	Save(token string) error
+	Load() (string, error)
+	Clear() error
+	Exists() bool
+}
+
+// NewTokenStore returns the backend selected by cfg.Store. StoreAuto picks
+// the Secret Service when it is reachable, then the kernel keyring, and
+// falls back to the plaintext file.
+func NewTokenStore(cfg config.AuthConfig) (TokenStore, error) {
+	fileStore, err := NewFileTokenStore(cfg.TokenFile)
+	if err != nil {
+		return nil, err
+	}
+	key := tokenKey(fileStore.filePath)
+
+	switch cfg.Store {
+	case "", StoreFile:
+		return fileStore, nil
+	case StoreSecretService:
+		return NewSecretServiceTokenStore(key), nil
+	case StoreKeyring:
+		return NewKeyringTokenStore(key), nil
+	case StoreEncryptedFile:
+		return NewEncryptedFileTokenStore(encryptedPath(fileStore.filePath), cfg.PassphraseFile), nil
+	case StoreAuto:
+		if store := NewSecretServiceTokenStore(key); store.Available() {
+			return store, nil
+		}
+		if store := NewKeyringTokenStore(key); store.Available() {
+			return store, nil
+		}
+		return fileStore, nil
+	default:
+		return nil, fmt.Errorf("unknown token store %q", cfg.Store)
+	}
+}
+
+// tokenKey names the token in shared secret stores after its token file,
+// so each profile keeps a separate entry.
+func tokenKey(filePath string) string {
+	name := filepath.Base(filePath)
+	return "cadence:" + strings.TrimSuffix(name, filepath.Ext(name))
+}
+
+func encryptedPath(filePath string) string {
+	return strings.TrimSuffix(filePath, filepath.Ext(filePath)) + ".enc"
+}
+
 type tokenData struct {
 	SessionToken string `json:"session_token"`
 }
 
-type TokenStore struct {
+// FileTokenStore keeps the token in a plaintext JSON file readable only by
+// the owner.
+type FileTokenStore struct {
 	filePath string
 }
 
-func NewTokenStore() (*TokenStore, error) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get home directory: %w", err)
+// NewFileTokenStore stores the token in filePath, or in
+// ~/.local/share/cadence/auth.json when filePath is empty.
+func NewFileTokenStore(filePath string) (*FileTokenStore, error) {
+	if filePath == "" {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get home directory: %w", err)
+		}
+		filePath = filepath.Join(homeDir, ".local", "share", "cadence", "auth.json")
 	}
 
-	return &TokenStore{
-		filePath: filepath.Join(homeDir, ".local", "share", "cadence", "auth.json"),
+	return &FileTokenStore{
+		filePath: filePath,
 	}, nil
 }
 
-func (s *TokenStore) Save(token string) error {
+func (s *FileTokenStore) Save(token string) error {
 	dir := filepath.Dir(s.filePath)
 	if err := os.MkdirAll(dir, 0700); err != nil {
 		return fmt.Errorf("failed to create auth directory: %w", err)
@@ -44,7 +114,7 @@ func (s *TokenStore) Save(token string) error {
 	return nil
 }
 
-func (s *TokenStore) Load() (string, error) {
+func (s *FileTokenStore) Load() (string, error) {
 	data, err := os.ReadFile(s.filePath)
 	if err != nil {
 		return "", fmt.Errorf("failed to read token file: %w", err)
@@ -58,14 +128,14 @@ func (s *TokenStore) Load() (string, error) {
 	return td.SessionToken, nil
 }
 
-func (s *TokenStore) Clear() error {
+func (s *FileTokenStore) Clear() error {
 	if err := os.Remove(s.filePath); err != nil && !os.IsNotExist(err) {
 		return fmt.Errorf("failed to remove token file: %w", err)
 	}
 	return nil
 }
 
-func (s *TokenStore) Exists() bool {
+func (s *FileTokenStore) Exists() bool {
 	_, err := os.Stat(s.filePath)
 	return err == nil
 }
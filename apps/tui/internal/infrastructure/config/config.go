@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"cadence/internal/buildinfo"
 
@@ -14,25 +15,74 @@ const (
 	defaultConfigFileName = "config.yml"
 	defaultConfigDirName  = ".config/cadence"
 	defaultDataDirName    = ".local/share/cadence"
+	defaultSocketName     = "cadenced.sock"
+	defaultTokenFileName  = "auth.json"
 )
 
+// ProfileEnvVar selects a profile when no --profile flag is given.
+const ProfileEnvVar = "CADENCE_PROFILE"
+
 type Config struct {
+	// Profile is the name of the active profile, empty for the base config.
+	Profile string `yaml:"-"`
+
 	Backend         BackendConfig         `yaml:"backend"`
+	Auth            AuthConfig            `yaml:"auth"`
 	Daemon          DaemonConfig          `yaml:"daemon"`
 	TUI             TUIConfig             `yaml:"tui"`
 	Keybindings     KeybindingsConfig     `yaml:"keybindings"`
 	SessionTracking SessionTrackingConfig `yaml:"session_tracking"`
 	TimeTracking    TimeTrackingConfig    `yaml:"time_tracking"`
+	Reminders       RemindersConfig       `yaml:"reminders"`
+	Actions         ActionsConfig         `yaml:"actions"`
+	Hooks           HooksConfig           `yaml:"hooks"`
+
+	DefaultProfile string                   `yaml:"default_profile,omitempty"`
+	Profiles       map[string]ProfileConfig `yaml:"profiles,omitempty"`
 }
 
 type BackendConfig struct {
-	URL     string `yaml:"-"` // Not stored in config, set via environment variable
+	// URL overrides the backend URL set at build time via -ldflags.
+	URL     string `yaml:"url,omitempty"`
 	Timeout int    `yaml:"timeout"`
+	// DisableHTTPCache turns off the on-disk cache of board, task and note
+	// responses used for conditional requests.
+	DisableHTTPCache bool `yaml:"disable_http_cache"`
+	// APIToken is a long-lived token for CI and scripts. When set (here, in
+	// CADENCE_API_TOKEN or with `cadence auth token set`) it is used instead
+	// of the login session.
+	APIToken string `yaml:"api_token,omitempty"`
+	// APITokenScheme is how the API token is sent: api-key (X-API-Key
+	// header, default) or bearer.
+	APITokenScheme string `yaml:"api_token_scheme,omitempty"`
+}
+
+type AuthConfig struct {
+	// TokenFile defaults to auth.json in the data directory.
+	TokenFile string `yaml:"token_file,omitempty"`
+	// Store selects where the token is kept: file (default),
+	// secret-service, keyring, encrypted-file or auto.
+	Store string `yaml:"store,omitempty"`
+	// PassphraseFile holds the passphrase for the encrypted-file store.
+	PassphraseFile string `yaml:"passphrase_file,omitempty"`
+}
+
+// ProfileConfig overrides the connection settings of the base config, so one
+// binary can talk to several backends. Each profile gets its own daemon
+// socket and token file unless they are set explicitly.
+type ProfileConfig struct {
+	BackendURL string `yaml:"backend_url"`
+	Timeout    int    `yaml:"timeout,omitempty"`
+	TokenFile  string `yaml:"token_file,omitempty"`
+	SocketName string `yaml:"socket_name,omitempty"`
 }
 
 type DaemonConfig struct {
 	SocketDir  string `yaml:"socket_dir"`
 	SocketName string `yaml:"socket_name"`
+	// SlowRequestMs is the handling time in milliseconds above which a
+	// request trace is logged and kept for `cadence status --trace`.
+	SlowRequestMs int `yaml:"slow_request_ms"`
 }
 
 type TUIConfig struct {
@@ -106,11 +156,22 @@ type KeybindingsConfig struct {
 }
 
 type SessionTrackingConfig struct {
-	Enabled          bool          `yaml:"enabled"`
-	PollInterval     int           `yaml:"poll_interval"`
-	TrackerType      string        `yaml:"tracker_type"`
-	GeneralBoardName string        `yaml:"general_board_name"`
-	GitSync          GitSyncConfig `yaml:"git_sync"`
+	Enabled      bool `yaml:"enabled"`
+	PollInterval int  `yaml:"poll_interval"`
+	// TrackerType is where sessions are read from: tmux, zellij, or shell
+	// for the prompt hooks of `cadence hook`.
+	TrackerType      string `yaml:"tracker_type"`
+	GeneralBoardName string `yaml:"general_board_name"`
+	// RulesFile maps directories to projects and boards and lists
+	// directories to ignore. It defaults to projects.yml next to this file;
+	// without it the project is the repository and the board the app or
+	// package of a monorepo.
+	RulesFile string `yaml:"rules_file,omitempty"`
+	// Create is what happens when a directory resolves to a project or
+	// board that does not exist: auto (the default) creates it, ask waits
+	// for the user to confirm in the TUI.
+	Create  string        `yaml:"create,omitempty"`
+	GitSync GitSyncConfig `yaml:"git_sync"`
 }
 
 type GitSyncConfig struct {
@@ -126,6 +187,16 @@ type TimeTrackingConfig struct {
 	Git           TimeTrackingGitConfig     `yaml:"git"`
 	Tmux          TimeTrackingTmuxConfig    `yaml:"tmux"`
 	IdleThreshold int                       `yaml:"idle_threshold"`
+	// IdleSources lists where activity is read from: tmux, x11 and
+	// wayland. The most recent activity of any source counts.
+	IdleSources []string `yaml:"idle_sources,omitempty"`
+	// PromptIdle asks in the TUI whether to keep or discard time cut off
+	// by idle detection instead of always discarding it.
+	PromptIdle bool `yaml:"prompt_idle"`
+	// Export holds the rules `cadence time export` applies to time logs.
+	Export TimeExportConfig `yaml:"export"`
+	// Focus sets the default phase lengths of focus sessions.
+	Focus FocusConfig `yaml:"focus"`
 }
 
 type TimeTrackingSourcesConfig struct {
@@ -135,16 +206,163 @@ type TimeTrackingSourcesConfig struct {
 }
 
 type TimeTrackingGitConfig struct {
+	// WatchBranches attributes auto-tracked time to the task named by the
+	// current branch.
 	WatchBranches bool   `yaml:"watch_branches"`
 	BranchPattern string `yaml:"branch_pattern"`
+	// BranchPatterns are tried in order before BranchPattern. Named groups
+	// select the key: slug (a task slug), number (a task number within the
+	// project) or id (a backend task ID); otherwise the last group is used
+	// as a slug.
+	BranchPatterns []string `yaml:"branch_patterns,omitempty"`
+	// Fallback lists rules tried when no pattern finds a task: "number" (a
+	// bare number in the branch) and "title" (the branch name matches a
+	// task title).
+	Fallback []string `yaml:"fallback,omitempty"`
+	// Projects overrides the patterns and fallback per project, keyed by
+	// project name or slug.
+	Projects map[string]BranchRulesConfig `yaml:"projects,omitempty"`
+}
+
+type BranchRulesConfig struct {
+	BranchPatterns []string `yaml:"branch_patterns,omitempty"`
+	Fallback       []string `yaml:"fallback,omitempty"`
 }
 
 type TimeTrackingTmuxConfig struct {
 	TrackActiveOnly bool `yaml:"track_active_only"`
 }
 
+type TimeExportConfig struct {
+	// MergeGap joins consecutive logs of the same task and description that
+	// are at most this many minutes apart; the gap is not billed.
+	MergeGap int `yaml:"merge_gap"`
+	// RoundTo rounds each entry to a multiple of this many minutes. Zero
+	// keeps durations as logged.
+	RoundTo int `yaml:"round_to"`
+	// Rounding is "nearest" (the default), "up" or "down".
+	Rounding string `yaml:"rounding,omitempty"`
+	// Minimum raises shorter entries to this many minutes after rounding.
+	Minimum int `yaml:"minimum"`
+	// Billable marks entries as billable in Toggl and Clockify files.
+	Billable bool `yaml:"billable"`
+}
+
+// FocusConfig holds focus (pomodoro) session defaults, in minutes. Zero
+// values fall back to 25 minutes of work, 5 and 15 minute breaks and a long
+// break every 4 cycles.
+type FocusConfig struct {
+	Work       int `yaml:"work"`
+	ShortBreak int `yaml:"short_break"`
+	LongBreak  int `yaml:"long_break"`
+	// LongBreakEvery is how many work phases come before a long break.
+	LongBreakEvery int `yaml:"long_break_every"`
+	// Cycles ends the session after this many work phases; zero runs until
+	// it is stopped.
+	Cycles int `yaml:"cycles"`
+}
+
+type RemindersConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// CheckInterval is how often, in seconds, due dates, the agenda and
+	// alarm plans are read from the backend.
+	CheckInterval int `yaml:"check_interval"`
+	// Notifiers lists where reminders are shown: dbus (desktop
+	// notifications), notify-send, tmux and bell.
+	Notifiers []string `yaml:"notifiers,omitempty"`
+	// DueBefore lists how long before a task is due to remind, such as
+	// "1d" or "1h". Tasks due on a date without a time are due at midnight.
+	DueBefore []string `yaml:"due_before,omitempty"`
+	// AgendaBefore is how long before a scheduled agenda item to remind.
+	AgendaBefore string `yaml:"agenda_before,omitempty"`
+	// AlarmPlans rings the backend's alarm plans for routines on the
+	// agenda.
+	AlarmPlans bool `yaml:"alarm_plans"`
+	// SnoozeMinutes is how long a snooze lasts when none is given.
+	SnoozeMinutes int `yaml:"snooze_minutes"`
+}
+
+// ActionsConfig holds the automation rules the daemon runs when tasks
+// change, when a timer starts or on a cron schedule.
+type ActionsConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// ScriptsDir is where script actions run, so a rule can name a script
+	// relative to it.
+	ScriptsDir string `yaml:"scripts_dir,omitempty"`
+	// ScriptTimeout is how long, in seconds, a script action may run.
+	ScriptTimeout int          `yaml:"script_timeout"`
+	Rules         []ActionRule `yaml:"rules,omitempty"`
+}
+
+// ActionRule runs its actions when its trigger fires and every condition
+// holds for the task involved.
+type ActionRule struct {
+	Name       string            `yaml:"name"`
+	Disabled   bool              `yaml:"disabled,omitempty"`
+	Trigger    ActionTrigger     `yaml:"trigger"`
+	Conditions []ActionCondition `yaml:"conditions,omitempty"`
+	Actions    []ActionStep      `yaml:"actions"`
+}
+
+// ActionTrigger is either an event or a cron schedule.
+type ActionTrigger struct {
+	// Event is task_created, task_updated, task_moved, task_deleted,
+	// status_changed, priority_changed or timer_started.
+	Event string `yaml:"event,omitempty"`
+	// Schedule is a cron expression such as "0 9 * * 1-5". A scheduled
+	// rule with conditions runs once for every task matching them; without
+	// conditions it runs once.
+	Schedule string `yaml:"schedule,omitempty"`
+}
+
+// ActionCondition compares a task field with a value. Operators are
+// equals (the default), not_equals, contains, matches, in, not_in, empty,
+// not_empty, before, after, gt and lt.
+type ActionCondition struct {
+	Field    string      `yaml:"field"`
+	Operator string      `yaml:"operator,omitempty"`
+	Value    interface{} `yaml:"value,omitempty"`
+}
+
+// ActionStep is one thing a rule does. Which fields apply depends on the
+// type: update_task, move_task, start_timer, stop_timer, create_note,
+// script or notify. Text fields are Go templates over the task.
+type ActionStep struct {
+	Type string `yaml:"type"`
+	// Fields are the task fields update_task sets: title, description,
+	// status, priority and dueDate.
+	Fields map[string]string `yaml:"fields,omitempty"`
+	// Column is the name or ID of the column move_task moves to.
+	Column string `yaml:"column,omitempty"`
+	// Title and Body are the note of create_note and the notification of
+	// notify.
+	Title    string `yaml:"title,omitempty"`
+	Body     string `yaml:"body,omitempty"`
+	NoteType string `yaml:"note_type,omitempty"`
+	// Urgency of a notification: low, normal or critical.
+	Urgency string `yaml:"urgency,omitempty"`
+	// Command is the shell command of a script action. Unlike the other
+	// text it is not a template; scripts read the task from TASK_*
+	// variables, as the shell would run fields pasted into the command.
+	Command string `yaml:"command,omitempty"`
+	// Description is given to the timer start_timer starts.
+	Description string `yaml:"description,omitempty"`
+}
+
+// HooksConfig controls the scripts the daemon runs on its events, from
+// <dir>/<event>.d/ directories such as task_moved.d.
+type HooksConfig struct {
+	Enabled bool   `yaml:"enabled"`
+	Dir     string `yaml:"dir,omitempty"`
+	// Timeout is how long, in seconds, a hook may run before it is killed.
+	Timeout int `yaml:"timeout"`
+	// MaxConcurrent limits how many hooks run at once.
+	MaxConcurrent int `yaml:"max_concurrent"`
+}
+
 type Loader struct {
 	configPath string
+	profile    string
 }
 
 func NewLoader() (*Loader, error) {
@@ -159,9 +377,22 @@ func NewLoader() (*Loader, error) {
 	return &Loader{configPath: configPath}, nil
 }
 
+// SetProfile selects the profile to load. An empty name falls back to
+// CADENCE_PROFILE and then to default_profile in the config file.
+func (l *Loader) SetProfile(name string) {
+	l.profile = name
+}
+
 func (l *Loader) Load() (*Config, error) {
 	if _, err := os.Stat(l.configPath); os.IsNotExist(err) {
-		return l.createDefaultConfig()
+		config, err := l.createDefaultConfig()
+		if err != nil {
+			return nil, err
+		}
+		if err := l.applyProfile(config); err != nil {
+			return nil, err
+		}
+		return config, nil
 	}
 
 	data, err := os.ReadFile(l.configPath)
@@ -174,20 +405,117 @@ func (l *Loader) Load() (*Config, error) {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	if buildinfo.BackendURL == "" {
-		return nil, fmt.Errorf("backend URL not set (binary must be built with -ldflags)")
+	if err := l.applyProfile(&config); err != nil {
+		return nil, err
 	}
-	config.Backend.URL = buildinfo.BackendURL
 
 	if config.Backend.Timeout == 0 {
 		config.Backend.Timeout = 10
 	}
+	if config.Daemon.SlowRequestMs == 0 {
+		config.Daemon.SlowRequestMs = 500
+	}
 
 	applyKeybindingDefaults(&config)
 
 	return &config, nil
 }
 
+// applyProfile overlays the selected profile and fills in the backend URL,
+// socket name and token file.
+func (l *Loader) applyProfile(cfg *Config) error {
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return fmt.Errorf("failed to get home directory: %w", err)
+	}
+	dataDir := filepath.Join(homeDir, defaultDataDirName)
+
+	name := l.profile
+	if name == "" {
+		name = os.Getenv(ProfileEnvVar)
+	}
+	if name == "" {
+		name = cfg.DefaultProfile
+	}
+
+	if cfg.Daemon.SocketDir == "" {
+		cfg.Daemon.SocketDir = dataDir
+	}
+
+	if name != "" {
+		profile, ok := cfg.Profiles[name]
+		if !ok {
+			return fmt.Errorf("unknown profile %q in %s", name, l.configPath)
+		}
+
+		cfg.Profile = name
+		if profile.BackendURL != "" {
+			cfg.Backend.URL = profile.BackendURL
+		}
+		if profile.Timeout != 0 {
+			cfg.Backend.Timeout = profile.Timeout
+		}
+
+		cfg.Daemon.SocketName = profile.SocketName
+		if cfg.Daemon.SocketName == "" {
+			cfg.Daemon.SocketName = fmt.Sprintf("cadenced-%s.sock", name)
+		}
+
+		cfg.Auth.TokenFile = profile.TokenFile
+		if cfg.Auth.TokenFile == "" {
+			cfg.Auth.TokenFile = filepath.Join(dataDir, fmt.Sprintf("auth-%s.json", name))
+		}
+	}
+
+	if cfg.Daemon.SocketName == "" {
+		cfg.Daemon.SocketName = defaultSocketName
+	}
+	if cfg.Auth.TokenFile == "" {
+		cfg.Auth.TokenFile = filepath.Join(dataDir, defaultTokenFileName)
+	}
+	cfg.Auth.TokenFile = expandHome(cfg.Auth.TokenFile, homeDir)
+	cfg.Auth.PassphraseFile = expandHome(cfg.Auth.PassphraseFile, homeDir)
+	cfg.Daemon.SocketDir = expandHome(cfg.Daemon.SocketDir, homeDir)
+	cfg.Actions.ScriptsDir = expandHome(cfg.Actions.ScriptsDir, homeDir)
+	if cfg.Hooks.Dir == "" {
+		cfg.Hooks.Dir = filepath.Join(filepath.Dir(l.configPath), "hooks")
+	}
+	cfg.Hooks.Dir = expandHome(cfg.Hooks.Dir, homeDir)
+	if cfg.SessionTracking.TrackerType == "" {
+		cfg.SessionTracking.TrackerType = "tmux"
+	}
+	if cfg.SessionTracking.RulesFile == "" {
+		cfg.SessionTracking.RulesFile = filepath.Join(filepath.Dir(l.configPath), "projects.yml")
+	}
+	cfg.SessionTracking.RulesFile = expandHome(cfg.SessionTracking.RulesFile, homeDir)
+	switch cfg.SessionTracking.Create {
+	case "":
+		cfg.SessionTracking.Create = "auto"
+	case "auto", "ask":
+	default:
+		return fmt.Errorf("unknown session_tracking.create %q in %s: use auto or ask", cfg.SessionTracking.Create, l.configPath)
+	}
+
+	if cfg.Backend.URL == "" {
+		cfg.Backend.URL = buildinfo.BackendURL
+	}
+	if cfg.Backend.URL == "" {
+		return fmt.Errorf("backend URL not set: add backend.url or a profile to %s", l.configPath)
+	}
+
+	return nil
+}
+
+func expandHome(path, homeDir string) string {
+	if path == "~" {
+		return homeDir
+	}
+	if strings.HasPrefix(path, "~/") {
+		return filepath.Join(homeDir, path[2:])
+	}
+	return path
+}
+
 func applyKeybindingDefaults(cfg *Config) {
 	kb := &cfg.Keybindings
 	if len(kb.Up) == 0 {
@@ -248,18 +576,16 @@ func (l *Loader) createDefaultConfig() (*Config, error) {
 
 	dataDir := filepath.Join(homeDir, defaultDataDirName)
 
-	if buildinfo.BackendURL == "" {
-		return nil, fmt.Errorf("backend URL not set (binary must be built with -ldflags)")
-	}
-
+	// The backend URL is left out so the build-time one is resolved on
+	// every load instead of frozen into the file.
 	config := &Config{
 		Backend: BackendConfig{
-			URL:     buildinfo.BackendURL,
 			Timeout: 10,
 		},
 		Daemon: DaemonConfig{
-			SocketDir:  dataDir,
-			SocketName: "cadenced.sock",
+			SocketDir:     dataDir,
+			SocketName:    "cadenced.sock",
+			SlowRequestMs: 500,
 		},
 		TUI: TUIConfig{
 			Styles: StylesConfig{
@@ -295,14 +621,29 @@ func (l *Loader) createDefaultConfig() (*Config, error) {
 		},
 		SessionTracking: SessionTrackingConfig{
 			Enabled: true, PollInterval: 5, TrackerType: "tmux",
-			GeneralBoardName: "General Tasks",
+			GeneralBoardName: "General Tasks", Create: "auto",
 			GitSync: GitSyncConfig{Enabled: true, AutoSyncBranches: true, WatchForChanges: true},
 		},
 		TimeTracking: TimeTrackingConfig{
 			Enabled: true, AutoTrack: true, IdleThreshold: 300,
+			IdleSources: []string{"tmux"}, PromptIdle: true,
 			Sources: TimeTrackingSourcesConfig{Manual: true, Git: true, Tmux: true},
-			Git:     TimeTrackingGitConfig{WatchBranches: true, BranchPattern: `^(feature|bugfix)/([A-Z]+-[0-9]+)`},
+			Git:     TimeTrackingGitConfig{WatchBranches: true, BranchPattern: `^(feature|bugfix)/(?P<slug>[A-Za-z]+-[0-9]+)`},
 			Tmux:    TimeTrackingTmuxConfig{TrackActiveOnly: true},
+			Export:  TimeExportConfig{MergeGap: 1, Rounding: "nearest"},
+			Focus:   FocusConfig{Work: 25, ShortBreak: 5, LongBreak: 15, LongBreakEvery: 4},
+		},
+		Reminders: RemindersConfig{
+			Enabled: true, CheckInterval: 60,
+			Notifiers: []string{"dbus", "tmux"},
+			DueBefore: []string{"1d", "1h"}, AgendaBefore: "10m",
+			AlarmPlans: true, SnoozeMinutes: 10,
+		},
+		Actions: ActionsConfig{
+			Enabled: true, ScriptsDir: "~/.config/cadence/scripts", ScriptTimeout: 30,
+		},
+		Hooks: HooksConfig{
+			Enabled: false, Dir: "~/.config/cadence/hooks", Timeout: 10, MaxConcurrent: 4,
 		},
 	}
 
@@ -0,0 +1,57 @@
+package external
+
+import (
+	"fmt"
+	"os"
+
+	"cadence/internal/domain/service"
+)
+
+// BellNotifier implements Notifier by ringing the terminal bell. The daemon
+// has no terminal of its own, so the bell goes to the terminals of attached
+// tmux clients, which pass it on as an alert or visual bell
+type BellNotifier struct{}
+
+// NewBellNotifier creates a new BellNotifier
+func NewBellNotifier() *BellNotifier {
+	return &BellNotifier{}
+}
+
+// IsAvailable checks if any tmux client terminal can be reached
+func (b *BellNotifier) IsAvailable() bool {
+	ttys, err := tmuxClients("#{?client_control_mode,,#{client_tty}}")
+	return err == nil && len(ttys) > 0
+}
+
+// Notify writes BEL to every attached client's terminal; the title and body
+// cannot be shown
+func (b *BellNotifier) Notify(title, body string, urgency service.Urgency) error {
+	ttys, err := tmuxClients("#{?client_control_mode,,#{client_tty}}")
+	if err != nil {
+		return err
+	}
+
+	var lastErr error
+	rung := 0
+	for _, tty := range ttys {
+		f, err := os.OpenFile(tty, os.O_WRONLY, 0)
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		if _, err := f.WriteString("\a"); err != nil {
+			lastErr = err
+		} else {
+			rung++
+		}
+		f.Close()
+	}
+
+	if rung == 0 {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no terminals to ring")
+		}
+		return lastErr
+	}
+	return nil
+}
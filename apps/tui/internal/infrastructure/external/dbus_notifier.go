@@ -0,0 +1,67 @@
+package external
+
+import (
+	"fmt"
+
+	"github.com/godbus/dbus/v5"
+
+	"cadence/internal/domain/service"
+)
+
+const (
+	notificationsService = "org.freedesktop.Notifications"
+	notificationsPath    = "/org/freedesktop/Notifications"
+	notificationsNotify  = "org.freedesktop.Notifications.Notify"
+	notificationsPing    = "org.freedesktop.DBus.Peer.Ping"
+
+	appName = "cadence"
+)
+
+// DBusNotifier implements Notifier with freedesktop desktop notifications
+// over the session D-Bus, which every major desktop and most standalone
+// notification daemons (dunst, mako) implement
+type DBusNotifier struct{}
+
+// NewDBusNotifier creates a new DBusNotifier
+func NewDBusNotifier() *DBusNotifier {
+	return &DBusNotifier{}
+}
+
+// IsAvailable checks if a notification server answers on the session bus
+func (d *DBusNotifier) IsAvailable() bool {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return false
+	}
+	return conn.Object(notificationsService, notificationsPath).Call(notificationsPing, 0).Err == nil
+}
+
+// Notify sends the notification to the notification server
+func (d *DBusNotifier) Notify(title, body string, urgency service.Urgency) error {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return fmt.Errorf("failed to connect to session bus: %w", err)
+	}
+
+	hints := map[string]dbus.Variant{
+		"urgency": dbus.MakeVariant(dbusUrgency(urgency)),
+	}
+	// Critical notifications stay until dismissed; the others use the
+	// server's default timeout (-1).
+	call := conn.Object(notificationsService, notificationsPath).Call(notificationsNotify, 0,
+		appName, uint32(0), "", title, body, []string{}, hints, int32(-1))
+	if call.Err != nil {
+		return fmt.Errorf("failed to send notification: %w", call.Err)
+	}
+	return nil
+}
+
+func dbusUrgency(u service.Urgency) byte {
+	switch u {
+	case service.UrgencyLow:
+		return 0
+	case service.UrgencyCritical:
+		return 2
+	}
+	return 1
+}
@@ -0,0 +1,58 @@
+package external
+
+import (
+	"fmt"
+	"time"
+
+	"cadence/internal/domain/service"
+)
+
+// MultiIdleDetector combines several IdleDetectors and reports the most
+// recent activity of any of them, so typing in a browser keeps tmux-based
+// tracking alive
+type MultiIdleDetector struct {
+	detectors []service.IdleDetector
+}
+
+// NewMultiIdleDetector creates a MultiIdleDetector over the given detectors
+func NewMultiIdleDetector(detectors ...service.IdleDetector) *MultiIdleDetector {
+	return &MultiIdleDetector{detectors: detectors}
+}
+
+// IsAvailable checks if any of the detectors is available
+func (m *MultiIdleDetector) IsAvailable() bool {
+	for _, d := range m.detectors {
+		if d.IsAvailable() {
+			return true
+		}
+	}
+	return false
+}
+
+// LastActivity returns the latest activity reported by any detector. It
+// fails only if every detector fails
+func (m *MultiIdleDetector) LastActivity() (time.Time, error) {
+	var latest time.Time
+	var lastErr error
+	succeeded := false
+
+	for _, d := range m.detectors {
+		activity, err := d.LastActivity()
+		if err != nil {
+			lastErr = err
+			continue
+		}
+		succeeded = true
+		if activity.After(latest) {
+			latest = activity
+		}
+	}
+
+	if !succeeded {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no idle detectors configured")
+		}
+		return time.Time{}, lastErr
+	}
+	return latest, nil
+}
@@ -0,0 +1,54 @@
+package external
+
+import (
+	"fmt"
+
+	"cadence/internal/domain/service"
+)
+
+// MultiNotifier sends every notification through all of its notifiers that
+// are available, so a reminder shows on the desktop and in tmux alike
+type MultiNotifier struct {
+	notifiers []service.Notifier
+}
+
+// NewMultiNotifier creates a MultiNotifier over the given notifiers
+func NewMultiNotifier(notifiers ...service.Notifier) *MultiNotifier {
+	return &MultiNotifier{notifiers: notifiers}
+}
+
+// IsAvailable checks if any of the notifiers is available
+func (m *MultiNotifier) IsAvailable() bool {
+	for _, n := range m.notifiers {
+		if n.IsAvailable() {
+			return true
+		}
+	}
+	return false
+}
+
+// Notify sends through every available notifier. It fails only if none of
+// them delivered the notification
+func (m *MultiNotifier) Notify(title, body string, urgency service.Urgency) error {
+	var lastErr error
+	delivered := false
+
+	for _, n := range m.notifiers {
+		if !n.IsAvailable() {
+			continue
+		}
+		if err := n.Notify(title, body, urgency); err != nil {
+			lastErr = err
+			continue
+		}
+		delivered = true
+	}
+
+	if !delivered {
+		if lastErr == nil {
+			lastErr = fmt.Errorf("no notifier available")
+		}
+		return lastErr
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"cadence/internal/domain/service"
+)
+
+// NotifySendNotifier implements Notifier by running notify-send, for
+// systems where the daemon cannot reach the session bus directly
+type NotifySendNotifier struct{}
+
+// NewNotifySendNotifier creates a new NotifySendNotifier
+func NewNotifySendNotifier() *NotifySendNotifier {
+	return &NotifySendNotifier{}
+}
+
+// IsAvailable checks if notify-send is installed
+func (n *NotifySendNotifier) IsAvailable() bool {
+	_, err := exec.LookPath("notify-send")
+	return err == nil
+}
+
+// Notify runs notify-send with the title, body and urgency
+func (n *NotifySendNotifier) Notify(title, body string, urgency service.Urgency) error {
+	cmd := exec.Command("notify-send", "--app-name="+appName, "--urgency="+string(urgency), "--", title, body)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("notify-send failed: %w: %s", err, strings.TrimSpace(out.String()))
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TmuxIdleDetector implements IdleDetector using tmux activity timestamps
+type TmuxIdleDetector struct{}
+
+// NewTmuxIdleDetector creates a new TmuxIdleDetector
+func NewTmuxIdleDetector() *TmuxIdleDetector {
+	return &TmuxIdleDetector{}
+}
+
+// IsAvailable checks if a tmux server is running
+func (t *TmuxIdleDetector) IsAvailable() bool {
+	return exec.Command("tmux", "list-sessions").Run() == nil
+}
+
+// LastActivity returns the latest client_activity of any attached client,
+// falling back to session_activity when no client is attached. Control
+// mode clients, such as the session tracker's, are not counted.
+func (t *TmuxIdleDetector) LastActivity() (time.Time, error) {
+	latest, err := t.latestTimestamp("list-clients", "#{?client_control_mode,,#{client_activity}}")
+	if err != nil {
+		return time.Time{}, err
+	}
+	if !latest.IsZero() {
+		return latest, nil
+	}
+
+	return t.latestTimestamp("list-sessions", "#{session_activity}")
+}
+
+func (t *TmuxIdleDetector) latestTimestamp(command, format string) (time.Time, error) {
+	cmd := exec.Command("tmux", command, "-F", format)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to run tmux %s: %w", command, err)
+	}
+
+	var latest int64
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		seconds, err := strconv.ParseInt(strings.TrimSpace(line), 10, 64)
+		if err != nil {
+			continue
+		}
+		if seconds > latest {
+			latest = seconds
+		}
+	}
+
+	if latest == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(latest, 0), nil
+}
@@ -0,0 +1,74 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"cadence/internal/domain/service"
+)
+
+// tmuxMessageDuration is how long, in milliseconds, the message stays in
+// the status line
+const tmuxMessageDuration = "5000"
+
+// TmuxNotifier implements Notifier with tmux display-message, showing the
+// notification in the status line of every attached client
+type TmuxNotifier struct{}
+
+// NewTmuxNotifier creates a new TmuxNotifier
+func NewTmuxNotifier() *TmuxNotifier {
+	return &TmuxNotifier{}
+}
+
+// IsAvailable checks if a tmux server is running
+func (t *TmuxNotifier) IsAvailable() bool {
+	return exec.Command("tmux", "list-sessions").Run() == nil
+}
+
+// Notify displays the message on every attached client
+func (t *TmuxNotifier) Notify(title, body string, urgency service.Urgency) error {
+	clients, err := tmuxClients("#{?client_control_mode,,#{client_name}}")
+	if err != nil {
+		return err
+	}
+	if len(clients) == 0 {
+		return fmt.Errorf("no tmux clients attached")
+	}
+
+	message := title
+	if body != "" {
+		message += ": " + body
+	}
+	// display-message expands formats, so a literal # must be doubled.
+	message = strings.ReplaceAll(message, "#", "##")
+
+	var lastErr error
+	for _, client := range clients {
+		if err := exec.Command("tmux", "display-message", "-c", client, "-d", tmuxMessageDuration, message).Run(); err != nil {
+			lastErr = fmt.Errorf("failed to display tmux message on %s: %w", client, err)
+		}
+	}
+	return lastErr
+}
+
+// tmuxClients lists one formatted line per attached tmux client
+func tmuxClients(format string) ([]string, error) {
+	cmd := exec.Command("tmux", "list-clients", "-F", format)
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("failed to list tmux clients: %w", err)
+	}
+
+	var clients []string
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			clients = append(clients, line)
+		}
+	}
+	return clients, nil
+}
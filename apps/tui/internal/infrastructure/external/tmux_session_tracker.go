@@ -1,17 +1,83 @@
 package external
 
 import (
+	"bufio"
 	"bytes"
 	"fmt"
-	"cadence/internal/domain/entity"
+	"io"
 	"os/exec"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
+
+	"cadence/internal/domain/entity"
+	"cadence/internal/domain/service"
 )
 
 const sessionTypeTmux = "tmux"
 
-// TmuxSessionTracker implements SessionTracker for tmux
-type TmuxSessionTracker struct{}
+const (
+	// tmuxActiveCacheAge is how long GetActiveSession answers from its last
+	// result while control mode is connected, so consumers reacting to the
+	// same event share one tmux call.
+	tmuxActiveCacheAge = time.Second
+	// tmuxControlRetryMax bounds the wait before reconnecting control mode,
+	// such as when no tmux server is running yet.
+	tmuxControlRetryMax = time.Minute
+)
+
+// tmuxControlEvents are the control mode notifications after which the
+// active session, window, pane or directory may differ.
+var tmuxControlEvents = map[string]bool{
+	"%session-changed":        true,
+	"%client-session-changed": true,
+	"%session-window-changed": true,
+	"%window-pane-changed":    true,
+	"%sessions-changed":       true,
+	"%client-detached":        true,
+	"%subscription-changed":   true,
+}
+
+// TmuxSessionTracker implements SessionTracker for tmux. Once Events is
+// called it keeps a read-only control mode client (tmux -C) attached and
+// turns its notifications into session events.
+type TmuxSessionTracker struct {
+	mu          sync.Mutex
+	subscribers []chan service.SessionEvent
+	controlOnce sync.Once
+	// controlSession is the session the control client is attached to;
+	// empty while disconnected.
+	controlSession string
+	// control writes commands to the control client while it is connected
+	control  io.Writer
+	cached   *tmuxActiveSession
+	cachedAt time.Time
+}
+
+// tmuxActiveSession is a cached GetActiveSession result. Callers each get
+// their own Session, as they set metadata on it.
+type tmuxActiveSession struct {
+	name       string
+	workingDir string
+	metadata   map[string]string
+}
+
+func (a *tmuxActiveSession) session() (*entity.Session, error) {
+	if a == nil {
+		return nil, nil
+	}
+	session, err := entity.NewSession(a.name, a.workingDir, sessionTypeTmux)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range a.metadata {
+		if value != "" {
+			session.SetMetadata(key, value)
+		}
+	}
+	return session, nil
+}
 
 // NewTmuxSessionTracker creates a new TmuxSessionTracker
 func NewTmuxSessionTracker() *TmuxSessionTracker {
@@ -78,48 +144,228 @@ func (t *TmuxSessionTracker) ListSessions() ([]*entity.Session, error) {
 	return sessions, nil
 }
 
-// GetActiveSession returns the currently active/focused tmux session
-// When running from the daemon, we query tmux for attached sessions
+// tmuxFieldSeparator splits formatted fields; tmux turns tabs and other
+// control characters in its output into underscores.
+const tmuxFieldSeparator = "|;|"
+
+// tmuxClientFormat describes an attached client and the window and pane
+// it shows, with the pane's directory last.
+var tmuxClientFormat = strings.Join([]string{
+	"#{client_control_mode}", "#{client_activity}", "#{client_name}", "#{client_tty}",
+	"#{session_name}", "#{session_path}",
+	"#{window_id}", "#{window_index}", "#{window_name}",
+	"#{pane_id}", "#{pane_index}", "#{pane_current_command}", "#{pane_current_path}",
+}, tmuxFieldSeparator)
+
+// GetActiveSession returns the session of the client used last, with the
+// directory of the pane that client shows. With several clients attached,
+// the one with the latest input wins; control mode clients are ignored.
+// The client, window and pane are in the session's metadata.
 func (t *TmuxSessionTracker) GetActiveSession() (*entity.Session, error) {
-	// Query tmux for sessions with their attachment status and working directory
-	// Format: session_name:attached:pane_current_path
-	cmd := exec.Command("tmux", "list-sessions", "-F", "#{session_name}:#{session_attached}:#{pane_current_path}")
+	t.mu.Lock()
+	controlSession := t.controlSession
+	if controlSession != "" && time.Since(t.cachedAt) < tmuxActiveCacheAge {
+		cached := t.cached
+		t.mu.Unlock()
+		return cached.session()
+	}
+	t.mu.Unlock()
+
+	cmd := exec.Command("tmux", "list-clients", "-F", tmuxClientFormat)
 	var out bytes.Buffer
 	cmd.Stdout = &out
 	cmd.Stderr = &out
 
 	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("failed to list tmux sessions: %w", err)
+		return nil, fmt.Errorf("failed to list tmux clients: %w", err)
 	}
 
-	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	var active *tmuxActiveSession
+	var latest int64 = -1
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		fields := strings.SplitN(line, tmuxFieldSeparator, 13)
+		if len(fields) != 13 || fields[0] == "1" {
+			continue
+		}
 
-	// Look for an attached session (attached=1)
-	for _, line := range lines {
-		if line == "" {
+		activity, _ := strconv.ParseInt(fields[1], 10, 64)
+		if activity <= latest || fields[4] == "" || fields[12] == "" {
 			continue
 		}
+		latest = activity
+		active = &tmuxActiveSession{
+			name:       fields[4],
+			workingDir: fields[12],
+			metadata: map[string]string{
+				"client":       fields[2],
+				"client_tty":   fields[3],
+				"session_path": fields[5],
+				"window_id":    fields[6],
+				"window_index": fields[7],
+				"window_name":  fields[8],
+				"pane_id":      fields[9],
+				"pane_index":   fields[10],
+				"pane_command": fields[11],
+				"pane_path":    fields[12],
+			},
+		}
+	}
+
+	if controlSession != "" {
+		t.mu.Lock()
+		t.cached, t.cachedAt = active, time.Now()
+		t.mu.Unlock()
+		if active != nil && active.name != controlSession {
+			t.follow(active.name)
+		}
+	}
+
+	// nil when no client is attached
+	return active.session()
+}
 
-		parts := strings.SplitN(line, ":", 3)
-		if len(parts) != 3 {
+// Events returns a channel of tmux session changes. The first call starts
+// the control mode client; while it is disconnected no events arrive and
+// consumers rely on polling.
+func (t *TmuxSessionTracker) Events() <-chan service.SessionEvent {
+	ch := make(chan service.SessionEvent, 1)
+
+	t.mu.Lock()
+	t.subscribers = append(t.subscribers, ch)
+	t.mu.Unlock()
+
+	t.controlOnce.Do(func() {
+		go t.controlLoop()
+	})
+	return ch
+}
+
+// controlLoop keeps a control mode client connected, reconnecting with
+// backoff when tmux is not running or the client is detached.
+func (t *TmuxSessionTracker) controlLoop() {
+	retry := time.Second
+	for {
+		start := time.Now()
+		err := t.runControlClient()
+
+		t.mu.Lock()
+		t.controlSession = ""
+		t.cached, t.cachedAt = nil, time.Time{}
+		t.mu.Unlock()
+
+		if time.Since(start) > tmuxControlRetryMax {
+			retry = time.Second
+		}
+		if err != nil && retry == time.Second {
+			fmt.Printf("[SessionTracker] tmux control mode unavailable, polling: %v\n", err)
+		}
+		time.Sleep(retry)
+		retry = min(retry*2, tmuxControlRetryMax)
+	}
+}
+
+// runControlClient attaches a read-only control client without pane
+// output and reads its notifications until tmux closes it.
+func (t *TmuxSessionTracker) runControlClient() error {
+	cmd := exec.Command("tmux", "-C", "attach-session", "-r")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	defer cmd.Wait()
+	// Closing stdin detaches the client if the daemon stops reading
+	defer stdin.Close()
+
+	t.mu.Lock()
+	t.control = stdin
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		t.control = nil
+		t.mu.Unlock()
+	}()
+
+	// Pane output is not needed, and a subscription reports directory
+	// changes in the attached session's panes (quoted, as # starts a
+	// comment). Both need tmux 3.2; older versions answer with %error,
+	// which is ignored.
+	io.WriteString(stdin, "refresh-client -f no-output\n")
+	io.WriteString(stdin, "refresh-client -B 'cadence-cwd:%*:#{pane_current_path}'\n")
+
+	connected := false
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !strings.HasPrefix(line, "%") {
 			continue
 		}
+		fields := strings.Fields(line)
 
-		sessionName := parts[0]
-		attached := parts[1]
-		workingDir := parts[2]
-
-		// Check if this session is attached
-		if attached == "1" {
-			session, err := entity.NewSession(sessionName, workingDir, sessionTypeTmux)
-			if err != nil {
-				// Skip invalid sessions
-				continue
+		switch fields[0] {
+		case "%exit":
+			return nil
+		case "%session-changed":
+			// Sent for this client: on attach and when its session is
+			// renamed or destroyed. Format: %session-changed $id name
+			if len(fields) >= 3 {
+				t.mu.Lock()
+				t.controlSession = strings.Join(fields[2:], " ")
+				t.mu.Unlock()
 			}
-			return session, nil
+			if !connected {
+				connected = true
+				fmt.Println("[SessionTracker] Following tmux through control mode")
+			}
+		}
+
+		if tmuxControlEvents[fields[0]] {
+			t.publish(service.SessionEvent{Type: strings.TrimPrefix(fields[0], "%"), Time: time.Now()})
 		}
 	}
 
-	// No attached session found
-	return nil, nil
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if !connected {
+		return fmt.Errorf("tmux -C exited: %s", strings.TrimSpace(stderr.String()))
+	}
+	return nil
+}
+
+// follow moves the control client to the active session, so the
+// directory subscription and pane notifications cover the panes in use.
+func (t *TmuxSessionTracker) follow(session string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.control == nil {
+		return
+	}
+	target := "'=" + strings.ReplaceAll(session, "'", `'\''`) + "'"
+	io.WriteString(t.control, "switch-client -t "+target+"\n")
+}
+
+// publish drops the cached active session and hands the event to every
+// subscriber that is not still holding an earlier one.
+func (t *TmuxSessionTracker) publish(event service.SessionEvent) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cached, t.cachedAt = nil, time.Time{}
+	for _, ch := range t.subscribers {
+		select {
+		case ch <- event:
+		default:
+		}
+	}
 }
@@ -0,0 +1,73 @@
+package external
+
+import (
+	"os/exec"
+	"testing"
+	"time"
+
+	"cadence/internal/domain/service"
+)
+
+// startTmux starts a tmux server of the test's own, with a detached
+// session, and returns a function that runs tmux commands on it.
+func startTmux(t *testing.T) func(args ...string) {
+	t.Helper()
+	if _, err := exec.LookPath("tmux"); err != nil {
+		t.Skip("tmux is not installed")
+	}
+	t.Setenv("TMUX_TMPDIR", t.TempDir())
+	t.Setenv("TMUX", "")
+
+	tmux := func(args ...string) {
+		t.Helper()
+		if out, err := exec.Command("tmux", args...).CombinedOutput(); err != nil {
+			t.Fatalf("tmux %v: %v: %s", args, err, out)
+		}
+	}
+	tmux("new-session", "-d", "-s", "one", "-c", t.TempDir(), "sleep 600")
+	t.Cleanup(func() {
+		exec.Command("tmux", "kill-server").Run()
+	})
+	return tmux
+}
+
+// waitForEvent reads events until one of the given type arrives.
+func waitForEvent(t *testing.T, events <-chan service.SessionEvent, eventType string) {
+	t.Helper()
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case event := <-events:
+			if event.Type == eventType {
+				return
+			}
+		case <-timeout:
+			t.Fatalf("no %s event from control mode", eventType)
+		}
+	}
+}
+
+func TestTmuxControlModeEvents(t *testing.T) {
+	tmux := startTmux(t)
+
+	tracker := NewTmuxSessionTracker()
+	events := tracker.Events()
+	waitForEvent(t, events, "session-changed")
+
+	// The control client is attached, but it is not someone working there
+	session, err := tracker.GetActiveSession()
+	if err != nil {
+		t.Fatalf("GetActiveSession: %v", err)
+	}
+	if session != nil {
+		t.Errorf("active session = %s with only the control client attached, want none", session.Name())
+	}
+
+	tmux("new-session", "-d", "-s", "two", "sleep 600")
+	waitForEvent(t, events, "sessions-changed")
+
+	sessions, err := tracker.ListSessions()
+	if err != nil || len(sessions) != 2 {
+		t.Errorf("ListSessions = %d sessions, %v, want both", len(sessions), err)
+	}
+}
@@ -0,0 +1,62 @@
+package external
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/godbus/dbus/v5"
+)
+
+const (
+	mutterIdleMonitorService = "org.gnome.Mutter.IdleMonitor"
+	mutterIdleMonitorPath    = "/org/gnome/Mutter/IdleMonitor/Core"
+	mutterGetIdletime        = "org.gnome.Mutter.IdleMonitor.GetIdletime"
+
+	screenSaverService        = "org.freedesktop.ScreenSaver"
+	screenSaverPath           = "/org/freedesktop/ScreenSaver"
+	screenSaverGetSessionIdle = "org.freedesktop.ScreenSaver.GetSessionIdleTime"
+)
+
+// WaylandIdleDetector implements IdleDetector for Wayland sessions over the
+// session D-Bus. Wayland has no common idle query, so it asks GNOME's Mutter
+// idle monitor and falls back to the freedesktop screensaver interface
+// implemented by KDE
+type WaylandIdleDetector struct{}
+
+// NewWaylandIdleDetector creates a new WaylandIdleDetector
+func NewWaylandIdleDetector() *WaylandIdleDetector {
+	return &WaylandIdleDetector{}
+}
+
+// IsAvailable checks if a Wayland session exposes one of the idle interfaces
+func (w *WaylandIdleDetector) IsAvailable() bool {
+	if os.Getenv("WAYLAND_DISPLAY") == "" {
+		return false
+	}
+	_, err := w.LastActivity()
+	return err == nil
+}
+
+// LastActivity returns now minus the compositor's idle time
+func (w *WaylandIdleDetector) LastActivity() (time.Time, error) {
+	conn, err := dbus.SessionBus()
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to connect to session bus: %w", err)
+	}
+
+	var idleMs uint64
+	err = conn.Object(mutterIdleMonitorService, mutterIdleMonitorPath).
+		Call(mutterGetIdletime, 0).Store(&idleMs)
+	if err == nil {
+		return time.Now().Add(-time.Duration(idleMs) * time.Millisecond), nil
+	}
+
+	// GetSessionIdleTime reports seconds.
+	var idleSecs uint32
+	if err := conn.Object(screenSaverService, screenSaverPath).
+		Call(screenSaverGetSessionIdle, 0).Store(&idleSecs); err != nil {
+		return time.Time{}, fmt.Errorf("no idle monitor on the session bus: %w", err)
+	}
+	return time.Now().Add(-time.Duration(idleSecs) * time.Second), nil
+}
@@ -0,0 +1,47 @@
+package external
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// X11IdleDetector implements IdleDetector for X11 sessions using xprintidle,
+// which reports the milliseconds since the last keyboard or mouse input
+type X11IdleDetector struct{}
+
+// NewX11IdleDetector creates a new X11IdleDetector
+func NewX11IdleDetector() *X11IdleDetector {
+	return &X11IdleDetector{}
+}
+
+// IsAvailable checks if an X display is set and xprintidle is installed
+func (x *X11IdleDetector) IsAvailable() bool {
+	if os.Getenv("DISPLAY") == "" {
+		return false
+	}
+	_, err := exec.LookPath("xprintidle")
+	return err == nil
+}
+
+// LastActivity returns now minus the X server's idle time
+func (x *X11IdleDetector) LastActivity() (time.Time, error) {
+	cmd := exec.Command("xprintidle")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to run xprintidle: %w", err)
+	}
+
+	idleMs, err := strconv.ParseInt(strings.TrimSpace(out.String()), 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unexpected xprintidle output %q", out.String())
+	}
+
+	return time.Now().Add(-time.Duration(idleMs) * time.Millisecond), nil
+}
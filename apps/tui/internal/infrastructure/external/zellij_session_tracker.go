@@ -0,0 +1,235 @@
+package external
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"cadence/internal/domain/entity"
+	"cadence/internal/domain/service"
+)
+
+const sessionTypeZellij = "zellij"
+
+var (
+	ansiPattern      = regexp.MustCompile(`\x1b\[[0-9;]*m`)
+	kdlAttrPattern   = regexp.MustCompile(`(\w+)=("(?:[^"\\]|\\.)*"|\S+)`)
+	kdlGlobalPattern = regexp.MustCompile(`^cwd\s+"((?:[^"\\]|\\.)*)"`)
+)
+
+// ZellijSessionTracker implements SessionTracker for zellij
+type ZellijSessionTracker struct{}
+
+// NewZellijSessionTracker creates a new ZellijSessionTracker
+func NewZellijSessionTracker() *ZellijSessionTracker {
+	return &ZellijSessionTracker{}
+}
+
+// zellijSession is one line of `zellij list-sessions`.
+type zellijSession struct {
+	name    string
+	current bool
+}
+
+// IsAvailable checks if zellij is installed and has a running session
+func (z *ZellijSessionTracker) IsAvailable() bool {
+	if _, err := exec.LookPath("zellij"); err != nil {
+		return false
+	}
+
+	sessions, err := z.sessions()
+	return err == nil && len(sessions) > 0
+}
+
+// ListSessions returns all running zellij sessions. Exited sessions that
+// zellij keeps around for resurrection are left out.
+func (z *ZellijSessionTracker) ListSessions() ([]*entity.Session, error) {
+	running, err := z.sessions()
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*entity.Session, 0, len(running))
+	for _, s := range running {
+		session, err := z.session(s.name)
+		if err != nil {
+			// Skip sessions whose layout cannot be read
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// GetActiveSession returns the session a client is attached to, with the
+// working directory of its focused pane. The session the daemon runs in
+// wins; otherwise the first session with a client, or the only session.
+func (z *ZellijSessionTracker) GetActiveSession() (*entity.Session, error) {
+	running, err := z.sessions()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range running {
+		if s.current {
+			return z.session(s.name)
+		}
+	}
+	for _, s := range running {
+		if z.hasClients(s.name) {
+			return z.session(s.name)
+		}
+	}
+	if len(running) == 1 {
+		return z.session(running[0].name)
+	}
+
+	// No attached session found
+	return nil, nil
+}
+
+// Events returns nil: zellij has no event stream outside plugins, so
+// sessions are polled.
+func (z *ZellijSessionTracker) Events() <-chan service.SessionEvent {
+	return nil
+}
+
+// sessions lists the running sessions. Output is read without colours so
+// it parses the same across zellij versions, with or without
+// --no-formatting.
+func (z *ZellijSessionTracker) sessions() ([]zellijSession, error) {
+	cmd := exec.Command("zellij", "list-sessions")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		// zellij exits non-zero when there are no sessions
+		if strings.Contains(out.String(), "No active zellij sessions") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to list zellij sessions: %w", err)
+	}
+
+	var sessions []zellijSession
+	scanner := bufio.NewScanner(strings.NewReader(ansiPattern.ReplaceAllString(out.String(), "")))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		fields := strings.Fields(line)
+		if len(fields) == 0 || strings.Contains(line, "EXITED") {
+			continue
+		}
+		sessions = append(sessions, zellijSession{
+			name:    fields[0],
+			current: strings.HasSuffix(line, "(current)"),
+		})
+	}
+
+	return sessions, nil
+}
+
+// hasClients reports whether a client is attached to a session. Zellij
+// before 0.40 has no list-clients and always reports false.
+func (z *ZellijSessionTracker) hasClients(name string) bool {
+	out, err := exec.Command("zellij", "--session", name, "action", "list-clients").Output()
+	if err != nil {
+		return false
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	// The first line is the CLIENT_ID ZELLIJ_PANE_ID RUNNING_COMMAND header
+	return len(lines) > 1
+}
+
+// session builds a Session for a zellij session, with the working
+// directory of its focused pane.
+func (z *ZellijSessionTracker) session(name string) (*entity.Session, error) {
+	out, err := exec.Command("zellij", "--session", name, "action", "dump-layout").Output()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read layout of zellij session %s: %w", name, err)
+	}
+
+	return entity.NewSession(name, focusedPaneDir(string(out)), sessionTypeZellij)
+}
+
+// focusedPaneDir finds the working directory of the focused pane in the
+// focused tab of a dumped KDL layout. Pane cwds may be relative to the
+// layout's cwd; panes without one use the tab's or the layout's.
+func focusedPaneDir(layout string) string {
+	var (
+		globalDir  string
+		tabDir     string
+		firstDir   string
+		focusedDir string
+		depth      int
+		inTab      bool
+		tabDepth   int
+	)
+
+	scanner := bufio.NewScanner(strings.NewReader(layout))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		switch {
+		case depth == 1 && kdlGlobalPattern.MatchString(line):
+			globalDir = unquoteKDL(kdlGlobalPattern.FindStringSubmatch(line)[1])
+		case depth == 1 && (strings.HasPrefix(line, "tab ") || line == "tab {"):
+			attrs := kdlAttrs(line)
+			if attrs["focus"] == "true" {
+				inTab, tabDepth = true, depth
+				tabDir = attrs["cwd"]
+			}
+		case inTab && strings.HasPrefix(line, "pane"):
+			attrs := kdlAttrs(line)
+			if dir := attrs["cwd"]; dir != "" {
+				if firstDir == "" {
+					firstDir = dir
+				}
+				if attrs["focus"] == "true" {
+					focusedDir = dir
+				}
+			}
+		}
+
+		depth += strings.Count(line, "{") - strings.Count(line, "}")
+		if inTab && depth <= tabDepth {
+			break
+		}
+	}
+
+	dir := focusedDir
+	if dir == "" {
+		dir = firstDir
+	}
+	for _, base := range []string{tabDir, globalDir} {
+		if dir == "" {
+			dir = base
+		} else if base != "" && !filepath.IsAbs(dir) {
+			dir = filepath.Join(base, dir)
+		}
+	}
+	return dir
+}
+
+// kdlAttrs reads the key=value properties of a KDL node line.
+func kdlAttrs(line string) map[string]string {
+	attrs := make(map[string]string)
+	for _, m := range kdlAttrPattern.FindAllStringSubmatch(line, -1) {
+		attrs[m[1]] = unquoteKDL(m[2])
+	}
+	return attrs
+}
+
+func unquoteKDL(s string) string {
+	if len(s) >= 2 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = s[1 : len(s)-1]
+		s = strings.ReplaceAll(s, `\"`, `"`)
+		s = strings.ReplaceAll(s, `\\`, `\`)
+	}
+	return s
+}
@@ -0,0 +1,102 @@
+package external
+
+import "testing"
+
+func TestFocusedPaneDir(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout string
+		want   string
+	}{
+		{
+			name: "focused pane relative to the layout cwd",
+			layout: `layout {
+    cwd "/home/ada/src"
+    tab name="one" {
+        pane cwd="dotfiles"
+    }
+    tab name="two" focus=true {
+        pane split_direction="vertical" {
+            pane cwd="cadence"
+            pane cwd="cadence/apps/tui" focus=true
+        }
+    }
+}`,
+			want: "/home/ada/src/cadence/apps/tui",
+		},
+		{
+			name: "first pane without a focused one",
+			layout: `layout {
+    cwd "/home/ada"
+    tab focus=true {
+        pane cwd="notes"
+        pane cwd="src"
+    }
+}`,
+			want: "/home/ada/notes",
+		},
+		{
+			name: "tab cwd joins the layout cwd",
+			layout: `layout {
+    cwd "/home/ada"
+    tab name="web" cwd="src/web" focus=true {
+        pane cwd="api" focus=true
+    }
+}`,
+			want: "/home/ada/src/web/api",
+		},
+		{
+			name: "absolute pane cwd",
+			layout: `layout {
+    cwd "/home/ada"
+    tab focus=true {
+        pane cwd="/srv/site" focus=true
+    }
+}`,
+			want: "/srv/site",
+		},
+		{
+			name: "panes without a cwd use the tab's",
+			layout: `layout {
+    cwd "/home/ada"
+    tab cwd="/tmp/scratch" focus=true {
+        pane focus=true
+    }
+}`,
+			want: "/tmp/scratch",
+		},
+		{
+			name: "only the focused tab counts",
+			layout: `layout {
+    tab name="one" focus=true {
+        pane cwd="/home/ada/one"
+    }
+    tab name="two" {
+        pane cwd="/home/ada/two" focus=true
+    }
+}`,
+			want: "/home/ada/one",
+		},
+		{
+			name: "quoted paths with spaces",
+			layout: `layout {
+    cwd "/home/ada/My Projects"
+    tab focus=true {
+        pane cwd="big \"idea\"" focus=true
+    }
+}`,
+			want: `/home/ada/My Projects/big "idea"`,
+		},
+		{
+			name:   "no tabs",
+			layout: "layout {\n}",
+			want:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := focusedPaneDir(tt.layout); got != tt.want {
+			t.Errorf("%s: focusedPaneDir = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,394 @@
+package fakebackend
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"cadence/internal/application/dto"
+)
+
+const dateLayout = "2006-01-02"
+
+func (b *Backend) handleCreateAgendaItem(w http.ResponseWriter, r *http.Request) {
+	var req dto.AgendaItemCreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	ag, err := b.resolveAgendaLocked(r.PathValue("agendaId"))
+	if err != nil {
+		writeError(w, http.StatusNotFound, "%v", err)
+		return
+	}
+	if req.TaskID != nil {
+		if _, ok := b.tasks[*req.TaskID]; !ok {
+			writeError(w, http.StatusNotFound, "task not found")
+			return
+		}
+	}
+
+	position := 0
+	for _, item := range b.agendaItems {
+		if item.AgendaID == ag.id {
+			position++
+		}
+	}
+
+	ts := now()
+	item := &dto.AgendaItemDto{
+		ID:            b.newID(),
+		AgendaID:      ag.id,
+		TaskID:        req.TaskID,
+		RoutineTaskID: req.RoutineTaskID,
+		StartAt:       req.StartAt,
+		Duration:      req.Duration,
+		Status:        dto.AgendaItemStatusPending,
+		Position:      position,
+		Notes:         req.Notes,
+		CreatedAt:     ts,
+		UpdatedAt:     ts,
+	}
+	b.agendaItems[item.ID] = item
+
+	writeJSON(w, http.StatusCreated, item)
+}
+
+func (b *Backend) handleUpdateAgendaItem(w http.ResponseWriter, r *http.Request) {
+	var req dto.AgendaItemUpdateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	item, ok := b.agendaItems[r.PathValue("itemId")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "agenda item not found")
+		return
+	}
+	if req.StartAt != nil {
+		item.StartAt = req.StartAt
+	}
+	if req.Duration != nil {
+		item.Duration = req.Duration
+	}
+	if req.Notes != nil {
+		item.Notes = req.Notes
+	}
+	if req.Status != nil {
+		item.Status = *req.Status
+	}
+	item.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+func (b *Backend) handleCompleteAgendaItem(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	item, ok := b.agendaItems[r.PathValue("itemId")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "agenda item not found")
+		return
+	}
+	item.Status = dto.AgendaItemStatusCompleted
+	item.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, item)
+}
+
+// resolveAgendaLocked accepts either an agenda ID or a YYYY-MM-DD date, in
+// which case the agenda for that date is created on demand like the real
+// backend does.
+func (b *Backend) resolveAgendaLocked(idOrDate string) (*agenda, error) {
+	if ag, ok := b.agendas[idOrDate]; ok {
+		return ag, nil
+	}
+	if _, err := time.Parse(dateLayout, idOrDate); err != nil {
+		return nil, fmt.Errorf("agenda not found")
+	}
+	for _, ag := range b.agendas {
+		if ag.date == idOrDate {
+			return ag, nil
+		}
+	}
+	ag := &agenda{id: b.newID(), date: idOrDate}
+	b.agendas[ag.id] = ag
+	return ag, nil
+}
+
+func (b *Backend) handleGetAgendaView(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	loc, err := time.LoadLocation(q.Get("timezone"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid timezone")
+		return
+	}
+	anchor, err := time.ParseInLocation(dateLayout, q.Get("anchorDate"), loc)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "anchorDate must be YYYY-MM-DD")
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	byDate := b.itemsByDateLocked()
+	today := time.Now().In(loc).Format(dateLayout)
+
+	var view dto.AgendaViewDto
+	switch q.Get("mode") {
+	case dto.AgendaViewModeDay:
+		view = buildDayView(anchor, today, byDate)
+	case dto.AgendaViewModeWeek:
+		view = buildWeekView(anchor, today, byDate)
+	case dto.AgendaViewModeMonth:
+		view = buildMonthView(anchor, today, byDate)
+	default:
+		writeError(w, http.StatusBadRequest, "mode must be one of day, week, month")
+		return
+	}
+
+	view.Timezone = loc.String()
+	view.AnchorDate = anchor.Format(dateLayout)
+	view.UnfinishedItems = []dto.AgendaItemEnrichedDto{}
+	for date, items := range byDate {
+		if date >= view.AnchorDate {
+			continue
+		}
+		for _, item := range items {
+			if item.Status == dto.AgendaItemStatusUnfinished {
+				view.UnfinishedItems = append(view.UnfinishedItems, item)
+			}
+		}
+	}
+
+	writeJSON(w, http.StatusOK, view)
+}
+
+// itemsByDateLocked groups enriched agenda items by their agenda date,
+// ordered by position.
+func (b *Backend) itemsByDateLocked() map[string][]dto.AgendaItemEnrichedDto {
+	byDate := make(map[string][]dto.AgendaItemEnrichedDto)
+	for _, item := range sortedValues(b.agendaItems, b.order) {
+		ag, ok := b.agendas[item.AgendaID]
+		if !ok {
+			continue
+		}
+		byDate[ag.date] = append(byDate[ag.date], b.enrichLocked(item))
+	}
+	for _, items := range byDate {
+		sort.SliceStable(items, func(i, j int) bool {
+			return items[i].Position < items[j].Position
+		})
+	}
+	return byDate
+}
+
+func (b *Backend) enrichLocked(item dto.AgendaItemDto) dto.AgendaItemEnrichedDto {
+	enriched := dto.AgendaItemEnrichedDto{AgendaItemDto: item}
+	if item.TaskID == nil {
+		return enriched
+	}
+	task, ok := b.tasks[*item.TaskID]
+	if !ok {
+		return enriched
+	}
+
+	info := &dto.TaskInfoDto{
+		ID:          task.ID,
+		Title:       task.Title,
+		Description: task.Description,
+		TaskType:    task.TaskType,
+		Priority:    task.Priority,
+		ColumnID:    task.ColumnID,
+		BoardID:     task.BoardID,
+		ProjectID:   task.ProjectID,
+		GoalID:      task.GoalID,
+	}
+	if col, ok := b.columns[task.ColumnID]; ok {
+		info.ColumnName = col.Name
+	}
+	if board, ok := b.boards[task.BoardID]; ok {
+		info.BoardName = board.Name
+	}
+	if project, ok := b.projects[task.ProjectID]; ok {
+		info.ProjectName = project.Name
+	}
+	enriched.Task = info
+	return enriched
+}
+
+func navigation(anchor, prev, next time.Time, loc *time.Location) dto.AgendaViewNavigationDto {
+	return dto.AgendaViewNavigationDto{
+		AnchorDate:         anchor.Format(dateLayout),
+		PreviousAnchorDate: prev.Format(dateLayout),
+		NextAnchorDate:     next.Format(dateLayout),
+		TodayAnchorDate:    time.Now().In(loc).Format(dateLayout),
+	}
+}
+
+func hourSlots() []dto.AgendaHourSlotDto {
+	slots := make([]dto.AgendaHourSlotDto, 24)
+	for h := range slots {
+		slots[h] = dto.AgendaHourSlotDto{Hour: h, Label: fmt.Sprintf("%02d:00", h)}
+	}
+	return slots
+}
+
+// itemStart returns the item's start time in the anchor's location.
+func itemStart(item dto.AgendaItemEnrichedDto, loc *time.Location) (time.Time, bool) {
+	if item.StartAt == nil {
+		return time.Time{}, false
+	}
+	t, err := time.Parse(time.RFC3339, *item.StartAt)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return t.In(loc), true
+}
+
+func buildDayView(anchor time.Time, today string, byDate map[string][]dto.AgendaItemEnrichedDto) dto.AgendaViewDto {
+	dateKey := anchor.Format(dateLayout)
+	items := byDate[dateKey]
+
+	hours := hourSlots()
+	allDay := []dto.AgendaItemEnrichedDto{}
+	for _, item := range items {
+		if start, ok := itemStart(item, anchor.Location()); ok {
+			hours[start.Hour()].Items = append(hours[start.Hour()].Items, item)
+		} else {
+			allDay = append(allDay, item)
+		}
+	}
+
+	return dto.AgendaViewDto{
+		Mode:         dto.AgendaViewModeDay,
+		Label:        anchor.Format("Monday, January 2, 2006"),
+		DateKey:      dateKey,
+		IsToday:      dateKey == today,
+		Navigation:   navigation(anchor, anchor.AddDate(0, 0, -1), anchor.AddDate(0, 0, 1), anchor.Location()),
+		Hours:        hours,
+		AllDayItems:  allDay,
+		SpecialItems: &dto.AgendaDaySpecialItemsDto{},
+		IsEmpty:      len(items) == 0,
+	}
+}
+
+// weekStart returns the Monday on or before t.
+func weekStart(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return t.AddDate(0, 0, -offset)
+}
+
+func buildWeekView(anchor time.Time, today string, byDate map[string][]dto.AgendaItemEnrichedDto) dto.AgendaViewDto {
+	start := weekStart(anchor)
+	end := start.AddDate(0, 0, 6)
+
+	hours := hourSlots()
+	days := make([]dto.AgendaViewDayDto, 7)
+	for i := range days {
+		date := start.AddDate(0, 0, i)
+		dateKey := date.Format(dateLayout)
+		day := dto.AgendaViewDayDto{
+			DateKey:     dateKey,
+			Label:       date.Format("Monday, Jan 2"),
+			ShortLabel:  date.Format("Mon"),
+			IsToday:     dateKey == today,
+			AllDayItems: []dto.AgendaItemEnrichedDto{},
+			TimedItems:  []dto.AgendaWeekTimedItemDto{},
+		}
+		for _, item := range byDate[dateKey] {
+			itemStartAt, ok := itemStart(item, anchor.Location())
+			if !ok {
+				day.AllDayItems = append(day.AllDayItems, item)
+				continue
+			}
+			duration := 30
+			if item.Duration != nil && *item.Duration > 0 {
+				duration = *item.Duration
+			}
+			day.TimedItems = append(day.TimedItems, dto.AgendaWeekTimedItemDto{
+				Item:            item,
+				StartMinute:     itemStartAt.Hour()*60 + itemStartAt.Minute(),
+				DurationMinutes: duration,
+				OverlapCount:    1,
+			})
+		}
+		sort.SliceStable(day.TimedItems, func(a, b int) bool {
+			return day.TimedItems[a].StartMinute < day.TimedItems[b].StartMinute
+		})
+		days[i] = day
+	}
+
+	return dto.AgendaViewDto{
+		Mode:       dto.AgendaViewModeWeek,
+		Label:      fmt.Sprintf("%s – %s", start.Format("Jan 2"), end.Format("Jan 2, 2006")),
+		RangeStart: start.Format(dateLayout),
+		RangeEnd:   end.Format(dateLayout),
+		Navigation: navigation(anchor, anchor.AddDate(0, 0, -7), anchor.AddDate(0, 0, 7), anchor.Location()),
+		Hours:      hours,
+		Days:       days,
+	}
+}
+
+func buildMonthView(anchor time.Time, today string, byDate map[string][]dto.AgendaItemEnrichedDto) dto.AgendaViewDto {
+	const maxItemsPerDay = 3
+
+	monthStart := time.Date(anchor.Year(), anchor.Month(), 1, 0, 0, 0, 0, anchor.Location())
+	monthEnd := monthStart.AddDate(0, 1, -1)
+
+	var days []dto.AgendaViewDayDto
+	for date := weekStart(monthStart); !date.After(monthEnd) || date.Weekday() != time.Monday; date = date.AddDate(0, 0, 1) {
+		dateKey := date.Format(dateLayout)
+		items := byDate[dateKey]
+		overflow := 0
+		if len(items) > maxItemsPerDay {
+			overflow = len(items) - maxItemsPerDay
+			items = items[:maxItemsPerDay]
+		}
+		days = append(days, dto.AgendaViewDayDto{
+			DateKey:        dateKey,
+			Label:          date.Format("Monday, Jan 2"),
+			IsToday:        dateKey == today,
+			IsCurrentMonth: date.Month() == anchor.Month(),
+			Items:          append([]dto.AgendaItemEnrichedDto{}, items...),
+			OverflowCount:  overflow,
+		})
+	}
+
+	return dto.AgendaViewDto{
+		Mode:          dto.AgendaViewModeMonth,
+		Label:         anchor.Format("January 2006"),
+		MonthStart:    monthStart.Format(dateLayout),
+		MonthEnd:      monthEnd.Format(dateLayout),
+		Navigation:    navigation(anchor, anchor.AddDate(0, -1, 0), anchor.AddDate(0, 1, 0), anchor.Location()),
+		WeekdayLabels: []string{"Mon", "Tue", "Wed", "Thu", "Fri", "Sat", "Sun"},
+		Days:          days,
+	}
+}
+
+// handleListAlarmPlans lists the alarm plans of a routine task. Like the
+// real backend it returns nothing without a routineTaskId.
+func (b *Backend) handleListAlarmPlans(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	routineTaskID := r.URL.Query().Get("routineTaskId")
+	plans := []dto.AlarmPlanDto{}
+	for _, plan := range b.alarmPlans {
+		if routineTaskID != "" && plan.RoutineTaskID == routineTaskID {
+			plans = append(plans, plan)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, plans)
+}
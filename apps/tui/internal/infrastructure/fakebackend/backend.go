@@ -0,0 +1,291 @@
+// Package fakebackend implements an in-memory stand-in for the Cadence REST
+// API. It covers the endpoints used by httpclient.BackendClient so the
+// daemon and TUI can run without the NestJS backend or Postgres.
+package fakebackend
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"cadence/internal/application/dto"
+)
+
+// BaseURL is the URL the daemon uses when talking to the fake backend. It is
+// never resolved; requests are routed in-process by Transport.
+const BaseURL = "http://fake-backend.invalid"
+
+// EnvVar enables the fake backend for cadenced (and for daemons started by
+// the TUI, which inherit the environment) when set to a non-empty value.
+const EnvVar = "CADENCE_FAKE_BACKEND"
+
+func EnabledFromEnv() bool {
+	return os.Getenv(EnvVar) != ""
+}
+
+type agenda struct {
+	id   string
+	date string
+}
+
+// Backend holds the in-memory state and serves the REST API.
+type Backend struct {
+	mu  sync.Mutex
+	mux *http.ServeMux
+
+	projects    map[string]*dto.ProjectDto
+	boards      map[string]*dto.BoardDto
+	columns     map[string]*dto.ColumnDto
+	tasks       map[string]*dto.TaskDto
+	notes       map[string]*dto.NoteDto
+	timeLogs    []dto.TimeLogRecordDto
+	agendas     map[string]*agenda
+	agendaItems map[string]*dto.AgendaItemDto
+	alarmPlans  []dto.AlarmPlanDto
+
+	// order records insertion order by ID so listings are stable.
+	order       map[string]int
+	taskCounter int
+}
+
+func New() *Backend {
+	b := &Backend{
+		mux:         http.NewServeMux(),
+		projects:    make(map[string]*dto.ProjectDto),
+		boards:      make(map[string]*dto.BoardDto),
+		columns:     make(map[string]*dto.ColumnDto),
+		tasks:       make(map[string]*dto.TaskDto),
+		notes:       make(map[string]*dto.NoteDto),
+		agendas:     make(map[string]*agenda),
+		agendaItems: make(map[string]*dto.AgendaItemDto),
+		order:       make(map[string]int),
+	}
+	b.routes()
+	return b
+}
+
+func (b *Backend) routes() {
+	b.mux.HandleFunc("GET /api/auth/get-session", b.handleGetSession)
+
+	b.mux.HandleFunc("GET /projects", b.handleListProjects)
+	b.mux.HandleFunc("POST /projects", b.handleCreateProject)
+	b.mux.HandleFunc("GET /projects/{id}", b.handleGetProject)
+
+	b.mux.HandleFunc("GET /boards", b.handleListBoards)
+	b.mux.HandleFunc("POST /boards", b.handleCreateBoard)
+	b.mux.HandleFunc("GET /boards/{id}", b.handleGetBoard)
+	b.mux.HandleFunc("PUT /boards/{id}", b.handleUpdateBoard)
+	b.mux.HandleFunc("DELETE /boards/{id}", b.handleDeleteBoard)
+	b.mux.HandleFunc("POST /boards/{id}/columns", b.handleCreateColumn)
+	b.mux.HandleFunc("DELETE /columns/{id}", b.handleDeleteColumn)
+
+	b.mux.HandleFunc("GET /tasks", b.handleListTasks)
+	b.mux.HandleFunc("POST /tasks", b.handleCreateTask)
+	b.mux.HandleFunc("POST /tasks/quick", b.handleQuickCreateTask)
+	b.mux.HandleFunc("GET /tasks/{id}", b.handleGetTask)
+	b.mux.HandleFunc("PATCH /tasks/{id}", b.handleUpdateTask)
+	b.mux.HandleFunc("DELETE /tasks/{id}", b.handleDeleteTask)
+	b.mux.HandleFunc("POST /tasks/{id}/move", b.handleMoveTask)
+
+	b.mux.HandleFunc("GET /notes", b.handleListNotes)
+	b.mux.HandleFunc("POST /notes", b.handleCreateNote)
+	b.mux.HandleFunc("GET /notes/{id}", b.handleGetNote)
+	b.mux.HandleFunc("PUT /notes/{id}", b.handleUpdateNote)
+	b.mux.HandleFunc("DELETE /notes/{id}", b.handleDeleteNote)
+
+	b.mux.HandleFunc("POST /time-logs", b.handleCreateTimeLog)
+	b.mux.HandleFunc("GET /time-logs/task/{taskId}", b.handleListTaskTimeLogs)
+	b.mux.HandleFunc("GET /time-logs/summary", b.handleTimeSummary)
+	b.mux.HandleFunc("GET /time-logs/summary/{projectId}", b.handleTimeSummary)
+	b.mux.HandleFunc("GET /time-logs/daily/{date}", b.handleDailyTimeLogs)
+
+	b.mux.HandleFunc("GET /agenda-views", b.handleGetAgendaView)
+	b.mux.HandleFunc("POST /agendas/{agendaId}/items", b.handleCreateAgendaItem)
+	b.mux.HandleFunc("PUT /agendas/{agendaId}/items/{itemId}", b.handleUpdateAgendaItem)
+	b.mux.HandleFunc("PUT /agendas/{agendaId}/items/{itemId}/complete", b.handleCompleteAgendaItem)
+
+	b.mux.HandleFunc("GET /alarm-plans", b.handleListAlarmPlans)
+}
+
+// ServeHTTP routes the request. Successful GET responses carry an ETag,
+// honour If-None-Match and are gzipped when the client accepts it, like the
+// real backend.
+func (b *Backend) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		b.mux.ServeHTTP(w, r)
+		return
+	}
+
+	rec := httptest.NewRecorder()
+	b.mux.ServeHTTP(rec, r)
+
+	for k, v := range rec.Header() {
+		w.Header()[k] = v
+	}
+	if rec.Code != http.StatusOK {
+		w.WriteHeader(rec.Code)
+		_, _ = w.Write(rec.Body.Bytes())
+		return
+	}
+
+	sum := sha256.Sum256(rec.Body.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:8]) + `"`
+	w.Header().Set("ETag", etag)
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write(rec.Body.Bytes())
+		return
+	}
+
+	w.Header().Set("Content-Encoding", "gzip")
+	w.WriteHeader(http.StatusOK)
+	gz := gzip.NewWriter(w)
+	_, _ = gz.Write(rec.Body.Bytes())
+	_ = gz.Close()
+}
+
+// Transport returns a RoundTripper that serves requests directly from the
+// backend, without opening a socket.
+func (b *Backend) Transport() http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		b.ServeHTTP(rec, req)
+		resp := rec.Result()
+		resp.Request = req
+		return resp, nil
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Seed adds a demo project with a board, the default columns and a few
+// tasks so a freshly started fake backend has something to show.
+func (b *Backend) Seed() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	project := b.createProjectLocked(dto.ProjectCreateRequest{Name: "Demo"})
+	board := b.createBoardLocked(dto.BoardCreateRequest{Name: "default", ProjectID: project.ID})
+
+	var columnIDs []string
+	for _, col := range b.boardColumnsLocked(board.ID) {
+		columnIDs = append(columnIDs, col.ID)
+	}
+
+	high := dto.TaskPriorityHigh
+	b.createTaskLocked(dto.TaskCreateRequest{Title: "Try the fake backend", ColumnID: columnIDs[0], Priority: &high})
+	b.createTaskLocked(dto.TaskCreateRequest{Title: "Move me to Done", ColumnID: columnIDs[1]})
+	b.createTaskLocked(dto.TaskCreateRequest{Title: "Already finished", ColumnID: columnIDs[2]})
+}
+
+func now() string {
+	return time.Now().UTC().Format("2006-01-02T15:04:05.000Z")
+}
+
+func slugify(name string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(name)) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9':
+			sb.WriteRune(r)
+		case sb.Len() > 0 && !strings.HasSuffix(sb.String(), "-"):
+			sb.WriteRune('-')
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if v != nil {
+		_ = json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...interface{}) {
+	writeJSON(w, status, map[string]interface{}{
+		"statusCode": status,
+		"message":    fmt.Sprintf(format, args...),
+	})
+}
+
+func decodeBody(w http.ResponseWriter, r *http.Request, target interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(target); err != nil {
+		writeError(w, http.StatusBadRequest, "invalid request body: %v", err)
+		return false
+	}
+	return true
+}
+
+func pageParams(r *http.Request) (int, int) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	return page, limit
+}
+
+func paginate[T any](items []T, page, limit int) dto.PaginatedResponse[T] {
+	start := (page - 1) * limit
+	if start > len(items) {
+		start = len(items)
+	}
+	end := start + limit
+	if end > len(items) {
+		end = len(items)
+	}
+	return dto.PaginatedResponse[T]{
+		Items: append([]T{}, items[start:end]...),
+		Total: len(items),
+		Page:  page,
+		Limit: limit,
+	}
+}
+
+// sortedValues returns copies of the map values in insertion order.
+func sortedValues[T any](m map[string]*T, order map[string]int) []T {
+	ids := make([]string, 0, len(m))
+	for id := range m {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return order[ids[i]] < order[ids[j]]
+	})
+	result := make([]T, len(ids))
+	for i, id := range ids {
+		result[i] = *m[id]
+	}
+	return result
+}
+
+func (b *Backend) newID() string {
+	id := uuid.New().String()
+	b.order[id] = len(b.order)
+	return id
+}
@@ -0,0 +1,769 @@
+package fakebackend
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"cadence/internal/application/dto"
+)
+
+var defaultColumns = []string{"To Do", "In Progress", "Done"}
+
+// handleGetSession reports a fixed demo user; the fake backend accepts any
+// token, or none.
+func (b *Backend) handleGetSession(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, dto.AuthSessionDto{
+		Session: dto.AuthSessionInfoDto{
+			ID:        "fake-session",
+			UserID:    "fake-user",
+			ExpiresAt: time.Now().UTC().Add(7 * 24 * time.Hour).Format(time.RFC3339),
+			CreatedAt: now(),
+		},
+		User: dto.AuthUserDto{
+			ID:            "fake-user",
+			Name:          "Demo User",
+			Email:         "demo@example.com",
+			EmailVerified: true,
+		},
+	})
+}
+
+func (b *Backend) handleListProjects(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	page, limit := pageParams(r)
+	writeJSON(w, http.StatusOK, paginate(sortedValues(b.projects, b.order), page, limit))
+}
+
+func (b *Backend) handleGetProject(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	project, ok := b.projects[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "project not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, project)
+}
+
+func (b *Backend) handleCreateProject(w http.ResponseWriter, r *http.Request) {
+	var req dto.ProjectCreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		writeError(w, http.StatusBadRequest, "name must not be empty")
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	writeJSON(w, http.StatusCreated, b.createProjectLocked(req))
+}
+
+func (b *Backend) createProjectLocked(req dto.ProjectCreateRequest) *dto.ProjectDto {
+	color := "#6366F1"
+	if req.Color != nil {
+		color = *req.Color
+	}
+	ts := now()
+	project := &dto.ProjectDto{
+		ID:          b.newID(),
+		Name:        req.Name,
+		Slug:        slugify(req.Name),
+		Description: req.Description,
+		Color:       color,
+		Status:      "ACTIVE",
+		CreatedAt:   ts,
+		UpdatedAt:   ts,
+	}
+	b.projects[project.ID] = project
+	return project
+}
+
+func (b *Backend) handleListBoards(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	projectID := r.URL.Query().Get("projectId")
+	search := strings.ToLower(r.URL.Query().Get("search"))
+
+	var boards []dto.BoardDto
+	for _, board := range sortedValues(b.boards, b.order) {
+		if projectID != "" && board.ProjectID != projectID {
+			continue
+		}
+		if search != "" && !strings.Contains(strings.ToLower(board.Name), search) {
+			continue
+		}
+		boards = append(boards, board)
+	}
+
+	page, limit := pageParams(r)
+	writeJSON(w, http.StatusOK, paginate(boards, page, limit))
+}
+
+func (b *Backend) handleGetBoard(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	board, ok := b.boards[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "board not found")
+		return
+	}
+
+	detail := dto.BoardDetailDto{BoardDto: *board}
+	if project, ok := b.projects[board.ProjectID]; ok {
+		detail.ProjectName = project.Name
+	}
+	for _, col := range b.boardColumnsLocked(board.ID) {
+		tasks := b.columnTasksLocked(col.ID)
+		detail.Columns = append(detail.Columns, dto.BoardColumnDto{
+			ID:        col.ID,
+			Name:      col.Name,
+			Position:  col.Position,
+			WipLimit:  col.WipLimit,
+			Tasks:     tasks,
+			TaskCount: len(tasks),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, detail)
+}
+
+func (b *Backend) handleCreateBoard(w http.ResponseWriter, r *http.Request) {
+	var req dto.BoardCreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.projects[req.ProjectID]; !ok {
+		writeError(w, http.StatusNotFound, "project not found")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, b.createBoardLocked(req))
+}
+
+func (b *Backend) createBoardLocked(req dto.BoardCreateRequest) *dto.BoardDto {
+	color := "#6366F1"
+	if req.Color != nil {
+		color = *req.Color
+	}
+	ts := now()
+	board := &dto.BoardDto{
+		ID:          b.newID(),
+		Name:        req.Name,
+		Slug:        slugify(req.Name),
+		Description: req.Description,
+		Color:       color,
+		ProjectID:   req.ProjectID,
+		CreatedAt:   ts,
+		UpdatedAt:   ts,
+	}
+	b.boards[board.ID] = board
+
+	for i, name := range defaultColumns {
+		id := b.newID()
+		b.columns[id] = &dto.ColumnDto{
+			ID:        id,
+			Name:      name,
+			Position:  i,
+			BoardID:   board.ID,
+			CreatedAt: ts,
+			UpdatedAt: ts,
+		}
+	}
+
+	return board
+}
+
+func (b *Backend) handleUpdateBoard(w http.ResponseWriter, r *http.Request) {
+	var req dto.BoardUpdateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	board, ok := b.boards[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "board not found")
+		return
+	}
+	if req.Name != nil {
+		board.Name = *req.Name
+		board.Slug = slugify(*req.Name)
+	}
+	if req.Description != nil {
+		board.Description = req.Description
+	}
+	if req.Color != nil {
+		board.Color = *req.Color
+	}
+	board.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, board)
+}
+
+func (b *Backend) handleDeleteBoard(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := b.boards[id]; !ok {
+		writeError(w, http.StatusNotFound, "board not found")
+		return
+	}
+	for _, col := range b.boardColumnsLocked(id) {
+		b.deleteColumnLocked(col.ID)
+	}
+	delete(b.boards, id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Backend) boardColumnsLocked(boardID string) []dto.ColumnDto {
+	var columns []dto.ColumnDto
+	for _, col := range b.columns {
+		if col.BoardID == boardID {
+			columns = append(columns, *col)
+		}
+	}
+	sort.Slice(columns, func(i, j int) bool {
+		return columns[i].Position < columns[j].Position
+	})
+	return columns
+}
+
+func (b *Backend) handleCreateColumn(w http.ResponseWriter, r *http.Request) {
+	var req dto.ColumnCreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	boardID := r.PathValue("id")
+	if _, ok := b.boards[boardID]; !ok {
+		writeError(w, http.StatusNotFound, "board not found")
+		return
+	}
+
+	ts := now()
+	col := &dto.ColumnDto{
+		ID:        b.newID(),
+		Name:      req.Name,
+		Position:  len(b.boardColumnsLocked(boardID)),
+		BoardID:   boardID,
+		WipLimit:  req.WipLimit,
+		CreatedAt: ts,
+		UpdatedAt: ts,
+	}
+	b.columns[col.ID] = col
+
+	writeJSON(w, http.StatusCreated, col)
+}
+
+func (b *Backend) handleDeleteColumn(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := b.columns[id]; !ok {
+		writeError(w, http.StatusNotFound, "column not found")
+		return
+	}
+	b.deleteColumnLocked(id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Backend) deleteColumnLocked(id string) {
+	for taskID, task := range b.tasks {
+		if task.ColumnID == id {
+			delete(b.tasks, taskID)
+		}
+	}
+	delete(b.columns, id)
+}
+
+func (b *Backend) handleListTasks(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	boardID := r.URL.Query().Get("boardId")
+	columnID := r.URL.Query().Get("columnId")
+
+	var tasks []dto.TaskDto
+	for _, task := range sortedValues(b.tasks, b.order) {
+		if boardID != "" && task.BoardID != boardID {
+			continue
+		}
+		if columnID != "" && task.ColumnID != columnID {
+			continue
+		}
+		tasks = append(tasks, task)
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Position < tasks[j].Position
+	})
+
+	page, limit := pageParams(r)
+	writeJSON(w, http.StatusOK, paginate(tasks, page, limit))
+}
+
+func (b *Backend) columnTasksLocked(columnID string) []dto.TaskDto {
+	var tasks []dto.TaskDto
+	for _, task := range sortedValues(b.tasks, b.order) {
+		if task.ColumnID == columnID {
+			tasks = append(tasks, task)
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].Position < tasks[j].Position
+	})
+	return tasks
+}
+
+func (b *Backend) handleGetTask(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	task, ok := b.tasks[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "task not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (b *Backend) handleCreateTask(w http.ResponseWriter, r *http.Request) {
+	var req dto.TaskCreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	b.createTask(w, req)
+}
+
+func (b *Backend) handleQuickCreateTask(w http.ResponseWriter, r *http.Request) {
+	var req dto.TaskQuickCreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	b.createTask(w, dto.TaskCreateRequest{Title: req.Title, ColumnID: req.ColumnID})
+}
+
+func (b *Backend) createTask(w http.ResponseWriter, req dto.TaskCreateRequest) {
+	if strings.TrimSpace(req.Title) == "" {
+		writeError(w, http.StatusBadRequest, "title must not be empty")
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if _, ok := b.columns[req.ColumnID]; !ok {
+		writeError(w, http.StatusNotFound, "column not found")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, b.createTaskLocked(req))
+}
+
+func (b *Backend) createTaskLocked(req dto.TaskCreateRequest) *dto.TaskDto {
+	col := b.columns[req.ColumnID]
+	board := b.boards[col.BoardID]
+	project := b.projects[board.ProjectID]
+
+	b.taskCounter++
+	prefix := strings.ToUpper(strings.ReplaceAll(project.Slug, "-", ""))
+	if len(prefix) > 3 {
+		prefix = prefix[:3]
+	}
+
+	taskType := dto.TaskTypeTask
+	if req.TaskType != nil {
+		taskType = *req.TaskType
+	}
+
+	ts := now()
+	task := &dto.TaskDto{
+		ID:          b.newID(),
+		Slug:        fmt.Sprintf("%s-%d-%s", prefix, b.taskCounter, slugify(req.Title)),
+		Title:       req.Title,
+		Description: req.Description,
+		TaskType:    taskType,
+		Status:      dto.TaskStatusTodo,
+		Priority:    req.Priority,
+		ColumnID:    col.ID,
+		BoardID:     board.ID,
+		ProjectID:   project.ID,
+		Position:    len(b.columnTasksLocked(col.ID)),
+		DueDate:     req.DueDate,
+		ParentID:    req.ParentID,
+		CreatedAt:   ts,
+		UpdatedAt:   ts,
+	}
+	b.tasks[task.ID] = task
+	return task
+}
+
+func (b *Backend) handleUpdateTask(w http.ResponseWriter, r *http.Request) {
+	var req dto.TaskUpdateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	task, ok := b.tasks[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "task not found")
+		return
+	}
+	if req.Title != nil {
+		task.Title = *req.Title
+	}
+	if req.Description != nil {
+		task.Description = req.Description
+	}
+	if req.Priority != nil {
+		task.Priority = req.Priority
+	}
+	if req.Status != nil {
+		task.Status = *req.Status
+		if task.Status == dto.TaskStatusDone {
+			ts := now()
+			task.CompletedAt = &ts
+		} else {
+			task.CompletedAt = nil
+		}
+	}
+	if req.DueDate != nil {
+		task.DueDate = req.DueDate
+	}
+	if req.EstimatedMinutes != nil {
+		task.EstimatedMinutes = req.EstimatedMinutes
+	}
+	task.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (b *Backend) handleDeleteTask(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := b.tasks[id]; !ok {
+		writeError(w, http.StatusNotFound, "task not found")
+		return
+	}
+	delete(b.tasks, id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Backend) handleMoveTask(w http.ResponseWriter, r *http.Request) {
+	var req dto.TaskMoveRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	task, ok := b.tasks[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "task not found")
+		return
+	}
+	col, ok := b.columns[req.TargetColumnID]
+	if !ok {
+		writeError(w, http.StatusNotFound, "column not found")
+		return
+	}
+	if col.BoardID != task.BoardID {
+		writeError(w, http.StatusBadRequest, "target column belongs to a different board")
+		return
+	}
+
+	if task.ColumnID != col.ID {
+		task.Position = len(b.columnTasksLocked(col.ID))
+		task.ColumnID = col.ID
+	}
+	task.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, task)
+}
+
+func (b *Backend) handleListNotes(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	noteType := r.URL.Query().Get("type")
+
+	notes := []dto.NoteDto{}
+	for _, note := range sortedValues(b.notes, b.order) {
+		if noteType != "" && note.Type != noteType {
+			continue
+		}
+		notes = append(notes, note)
+	}
+
+	writeJSON(w, http.StatusOK, notes)
+}
+
+func (b *Backend) handleGetNote(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	note, ok := b.notes[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "note not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, note)
+}
+
+func (b *Backend) handleCreateNote(w http.ResponseWriter, r *http.Request) {
+	var req dto.NoteCreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	noteType := req.Type
+	if noteType == "" {
+		noteType = dto.NoteTypeGeneral
+	}
+
+	ts := now()
+	note := &dto.NoteDto{
+		ID:        b.newID(),
+		Type:      noteType,
+		Title:     req.Title,
+		Tags:      req.Tags,
+		CreatedAt: ts,
+		UpdatedAt: ts,
+	}
+	setNoteContent(note, req.Content)
+	b.notes[note.ID] = note
+
+	writeJSON(w, http.StatusCreated, note)
+}
+
+func (b *Backend) handleUpdateNote(w http.ResponseWriter, r *http.Request) {
+	var req dto.NoteUpdateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	note, ok := b.notes[r.PathValue("id")]
+	if !ok {
+		writeError(w, http.StatusNotFound, "note not found")
+		return
+	}
+	if req.Title != nil {
+		note.Title = *req.Title
+	}
+	if req.Content != nil {
+		setNoteContent(note, *req.Content)
+	}
+	if req.Tags != nil {
+		note.Tags = req.Tags
+	}
+	note.UpdatedAt = now()
+
+	writeJSON(w, http.StatusOK, note)
+}
+
+func setNoteContent(note *dto.NoteDto, content string) {
+	note.Content = content
+	words := len(strings.Fields(content))
+	note.WordCount = &words
+	preview := content
+	if len(preview) > 120 {
+		preview = preview[:120]
+	}
+	note.Preview = &preview
+}
+
+func (b *Backend) handleDeleteNote(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	id := r.PathValue("id")
+	if _, ok := b.notes[id]; !ok {
+		writeError(w, http.StatusNotFound, "note not found")
+		return
+	}
+	delete(b.notes, id)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (b *Backend) handleCreateTimeLog(w http.ResponseWriter, r *http.Request) {
+	var req dto.TimeLogCreateRequest
+	if !decodeBody(w, r, &req) {
+		return
+	}
+	if _, err := time.Parse(time.RFC3339, req.Date); err != nil {
+		writeError(w, http.StatusBadRequest, "date must be an ISO 8601 timestamp")
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if req.TaskID != nil {
+		if _, ok := b.tasks[*req.TaskID]; !ok {
+			writeError(w, http.StatusNotFound, "task not found")
+			return
+		}
+	}
+
+	metadata := make(map[string]interface{}, len(req.Metadata))
+	for k, v := range req.Metadata {
+		metadata[k] = v
+	}
+
+	ts := now()
+	record := dto.TimeLogRecordDto{
+		ID:              b.newID(),
+		ProjectID:       req.ProjectID,
+		TaskID:          req.TaskID,
+		Date:            req.Date,
+		DurationMinutes: req.DurationMinutes,
+		Source:          req.Source,
+		Metadata:        metadata,
+		CreatedAt:       ts,
+		UpdatedAt:       ts,
+	}
+	b.timeLogs = append(b.timeLogs, record)
+
+	writeJSON(w, http.StatusCreated, timeLogResponse(record))
+}
+
+// timeLogResponse maps a stored record the way the backend's TimeLogMapper
+// does: no end time or description, duration in minutes.
+func timeLogResponse(record dto.TimeLogRecordDto) dto.TimeLogDto {
+	duration := record.DurationMinutes
+	return dto.TimeLogDto{
+		ID:        record.ID,
+		TaskID:    record.TaskID,
+		ProjectID: record.ProjectID,
+		StartTime: record.Date,
+		Duration:  &duration,
+		CreatedAt: record.CreatedAt,
+		UpdatedAt: record.UpdatedAt,
+	}
+}
+
+func (b *Backend) handleListTaskTimeLogs(w http.ResponseWriter, r *http.Request) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	taskID := r.PathValue("taskId")
+	logs := []dto.TimeLogDto{}
+	for _, record := range b.timeLogs {
+		if record.TaskID != nil && *record.TaskID == taskID {
+			logs = append(logs, timeLogResponse(record))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, logs)
+}
+
+func (b *Backend) handleTimeSummary(w http.ResponseWriter, r *http.Request) {
+	projectID := r.PathValue("projectId")
+
+	var start, end time.Time
+	if s, e := r.URL.Query().Get("start_date"), r.URL.Query().Get("end_date"); s != "" && e != "" {
+		var err error
+		if start, err = time.Parse(time.RFC3339, s); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid start_date")
+			return
+		}
+		if end, err = time.Parse(time.RFC3339, e); err != nil {
+			writeError(w, http.StatusBadRequest, "invalid end_date")
+			return
+		}
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	totals := make(map[string]int)
+	var order []string
+	for _, record := range b.timeLogs {
+		id := "unknown"
+		if record.ProjectID != nil {
+			id = *record.ProjectID
+		}
+		if projectID != "" && id != projectID {
+			continue
+		}
+		if !start.IsZero() {
+			date, err := time.Parse(time.RFC3339, record.Date)
+			if err != nil || date.Before(start) || date.After(end) {
+				continue
+			}
+		}
+		if _, ok := totals[id]; !ok {
+			order = append(order, id)
+		}
+		totals[id] += record.DurationMinutes
+	}
+
+	summary := make([]dto.TimeSummaryDto, 0, len(order))
+	for _, id := range order {
+		summary = append(summary, dto.TimeSummaryDto{ProjectID: id, TotalMinutes: totals[id]})
+	}
+	writeJSON(w, http.StatusOK, summary)
+}
+
+func (b *Backend) handleDailyTimeLogs(w http.ResponseWriter, r *http.Request) {
+	day, err := time.ParseInLocation("2006-01-02", r.PathValue("date"), time.Local)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid date")
+		return
+	}
+	next := day.AddDate(0, 0, 1)
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	logs := []dto.TimeLogRecordDto{}
+	dates := make(map[string]time.Time)
+	for _, record := range b.timeLogs {
+		date, err := time.Parse(time.RFC3339, record.Date)
+		if err != nil || date.Before(day) || !date.Before(next) {
+			continue
+		}
+		logs = append(logs, record)
+		dates[record.ID] = date
+	}
+	sort.SliceStable(logs, func(i, j int) bool { return dates[logs[i].ID].Before(dates[logs[j].ID]) })
+
+	writeJSON(w, http.StatusOK, logs)
+}
@@ -0,0 +1,179 @@
+package httpclient
+
+import (
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/andybalholm/brotli"
+)
+
+const defaultCacheMaxEntries = 256
+
+// CacheEntry is a cached GET response body with the validators needed to
+// revalidate it.
+type CacheEntry struct {
+	ETag         string    `json:"etag,omitempty"`
+	LastModified string    `json:"last_modified,omitempty"`
+	Body         []byte    `json:"body"`
+	StoredAt     time.Time `json:"stored_at"`
+}
+
+// ResponseCache is a small on-disk cache of GET responses, one JSON file per
+// entry. Entries are always revalidated with If-None-Match or
+// If-Modified-Since, so a stale entry costs a 304 instead of a full payload.
+type ResponseCache struct {
+	dir        string
+	maxEntries int
+	mu         sync.Mutex
+}
+
+func NewResponseCache(dir string) (*ResponseCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	return &ResponseCache{
+		dir:        dir,
+		maxEntries: defaultCacheMaxEntries,
+	}, nil
+}
+
+// DefaultCacheDir returns ~/.cache/cadence/http (or the platform equivalent).
+func DefaultCacheDir() (string, error) {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to get cache directory: %w", err)
+	}
+	return filepath.Join(cacheDir, "cadence", "http"), nil
+}
+
+// Key derives the cache key for a request URL. The auth token is part of the
+// key so responses are never shared between accounts.
+func (c *ResponseCache) Key(fullURL, authToken string) string {
+	sum := sha256.Sum256([]byte(authToken + "\n" + fullURL))
+	return hex.EncodeToString(sum[:])
+}
+
+func (c *ResponseCache) Get(key string) (*CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	data, err := os.ReadFile(c.entryPath(key))
+	if err != nil {
+		return nil, false
+	}
+
+	var entry CacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (c *ResponseCache) Put(key string, entry *CacheEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal cache entry: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err := os.WriteFile(c.entryPath(key), data, 0600); err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+
+	c.pruneLocked()
+	return nil
+}
+
+// Touch marks an entry as recently used after a successful revalidation.
+func (c *ResponseCache) Touch(key string) {
+	now := time.Now()
+	_ = os.Chtimes(c.entryPath(key), now, now)
+}
+
+func (c *ResponseCache) Clear() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entries, err := os.ReadDir(c.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read cache directory: %w", err)
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".json") {
+			_ = os.Remove(filepath.Join(c.dir, e.Name()))
+		}
+	}
+	return nil
+}
+
+func (c *ResponseCache) entryPath(key string) string {
+	return filepath.Join(c.dir, key+".json")
+}
+
+// pruneLocked removes the least recently used entries above maxEntries.
+func (c *ResponseCache) pruneLocked() {
+	entries, err := os.ReadDir(c.dir)
+	if err != nil || len(entries) <= c.maxEntries {
+		return
+	}
+
+	type file struct {
+		name    string
+		modTime time.Time
+	}
+	var files []file
+	for _, e := range entries {
+		info, err := e.Info()
+		if err != nil || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		files = append(files, file{name: e.Name(), modTime: info.ModTime()})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].modTime.Before(files[j].modTime)
+	})
+	for i := 0; i < len(files)-c.maxEntries; i++ {
+		_ = os.Remove(filepath.Join(c.dir, files[i].name))
+	}
+}
+
+// acceptEncoding is sent on every request. Setting it explicitly disables
+// net/http's transparent gzip handling, so readBody decodes the response.
+const acceptEncoding = "gzip, br"
+
+// readBody reads the response body, undoing any Content-Encoding.
+func readBody(resp *http.Response) ([]byte, error) {
+	var reader io.Reader = resp.Body
+
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "", "identity":
+	case "gzip":
+		gz, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("invalid gzip response: %w", err)
+		}
+		defer gz.Close()
+		reader = gz
+	case "br":
+		reader = brotli.NewReader(resp.Body)
+	default:
+		return nil, fmt.Errorf("unsupported content encoding %q", resp.Header.Get("Content-Encoding"))
+	}
+
+	return io.ReadAll(reader)
+}
@@ -11,12 +11,29 @@ import (
 	"time"
 
 	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/tracing"
 )
 
+// Schemes for sending API tokens, selected with backend.api_token_scheme.
+const (
+	// AuthSchemeAPIKey sends the token in the X-API-Key header, as the
+	// backend's API key plugin expects.
+	AuthSchemeAPIKey = "api-key"
+	// AuthSchemeBearer sends the token as "Authorization: Bearer", like a
+	// session token.
+	AuthSchemeBearer = "bearer"
+)
+
+const headerAPIKey = "X-API-Key"
+
 type BackendClient struct {
 	baseURL    string
 	httpClient *http.Client
 	authToken  string
+	authScheme string
+	cache      *ResponseCache
+
+	onUnauthorized func()
 }
 
 func NewBackendClient(baseURL string, timeout time.Duration) *BackendClient {
@@ -26,8 +43,64 @@ func NewBackendClient(baseURL string, timeout time.Duration) *BackendClient {
 	}
 }
 
+// SetAuthToken sets the session token from a login.
 func (c *BackendClient) SetAuthToken(token string) {
 	c.authToken = token
+	c.authScheme = AuthSchemeBearer
+}
+
+// SetAPIToken sets a long-lived API token, sent with the given scheme.
+func (c *BackendClient) SetAPIToken(token, scheme string) error {
+	switch scheme {
+	case "":
+		scheme = AuthSchemeAPIKey
+	case AuthSchemeAPIKey, AuthSchemeBearer:
+	default:
+		return fmt.Errorf("unknown API token scheme %q (use %s or %s)", scheme, AuthSchemeAPIKey, AuthSchemeBearer)
+	}
+
+	c.authToken = token
+	c.authScheme = scheme
+	return nil
+}
+
+// SetUnauthorizedHandler registers fn to be called whenever the backend
+// rejects the auth token with 401.
+func (c *BackendClient) SetUnauthorizedHandler(fn func()) {
+	c.onUnauthorized = fn
+}
+
+// SetCache enables conditional requests for board, task and note listings.
+func (c *BackendClient) SetCache(cache *ResponseCache) {
+	c.cache = cache
+}
+
+// ClearCache drops every cached response, e.g. after switching accounts.
+func (c *BackendClient) ClearCache() error {
+	if c.cache == nil {
+		return nil
+	}
+	return c.cache.Clear()
+}
+
+// SetTransport replaces the HTTP transport, e.g. with a RecordingTransport,
+// a ReplayTransport or an in-process fake backend.
+func (c *BackendClient) SetTransport(rt http.RoundTripper) {
+	c.httpClient.Transport = rt
+}
+
+// GetSession returns the signed-in user and session expiry for the current
+// token, or UnauthorizedError when the token is missing or no longer valid.
+func (c *BackendClient) GetSession(ctx context.Context) (*dto.AuthSessionDto, error) {
+	var result *dto.AuthSessionDto
+	if err := c.doGet(ctx, "/api/auth/get-session", nil, &result); err != nil {
+		return nil, err
+	}
+	// better-auth answers 200 with a null body when there is no session.
+	if result == nil || result.User.ID == "" {
+		return nil, &UnauthorizedError{Message: "no active session"}
+	}
+	return result, nil
 }
 
 func (c *BackendClient) ListProjects(ctx context.Context, page, limit int) (*dto.PaginatedResponse[dto.ProjectDto], error) {
@@ -59,7 +132,7 @@ func (c *BackendClient) CreateProject(ctx context.Context, req dto.ProjectCreate
 
 func (c *BackendClient) GetBoard(ctx context.Context, id string) (*dto.BoardDetailDto, error) {
 	var result dto.BoardDetailDto
-	if err := c.doGet(ctx, fmt.Sprintf("/boards/%s", id), nil, &result); err != nil {
+	if err := c.doCachedGet(ctx, fmt.Sprintf("/boards/%s", id), nil, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -113,7 +186,7 @@ func (c *BackendClient) ListTasks(ctx context.Context, boardID, columnID string,
 	q.Set("page", fmt.Sprintf("%d", page))
 	q.Set("limit", fmt.Sprintf("%d", limit))
 	var result dto.PaginatedResponse[dto.TaskDto]
-	if err := c.doGet(ctx, "/tasks", q, &result); err != nil {
+	if err := c.doCachedGet(ctx, "/tasks", q, &result); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -184,7 +257,7 @@ func (c *BackendClient) ListNotes(ctx context.Context, projectID, noteType strin
 		q.Set("type", noteType)
 	}
 	var result []dto.NoteDto
-	if err := c.doGet(ctx, "/notes", q, &result); err != nil {
+	if err := c.doCachedGet(ctx, "/notes", q, &result); err != nil {
 		return nil, err
 	}
 	return result, nil
@@ -234,16 +307,47 @@ func (c *BackendClient) ListTimeLogs(ctx context.Context, taskID string) ([]dto.
 	return result, nil
 }
 
-func (c *BackendClient) GetAgendaView(ctx context.Context, mode, anchorDate, timezone string) (json.RawMessage, error) {
+// GetTimeSummary returns the total minutes per project, optionally only
+// for projectID. The backend applies the range only when both ends are set.
+func (c *BackendClient) GetTimeSummary(ctx context.Context, projectID string, start, end time.Time) ([]dto.TimeSummaryDto, error) {
+	q := url.Values{}
+	if !start.IsZero() && !end.IsZero() {
+		q.Set("start_date", start.UTC().Format(time.RFC3339))
+		q.Set("end_date", end.UTC().Format(time.RFC3339))
+	}
+
+	path := "/time-logs/summary"
+	if projectID != "" {
+		path += "/" + url.PathEscape(projectID)
+	}
+
+	var result []dto.TimeSummaryDto
+	if err := c.doGet(ctx, path, q, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetDailyTimeLogs returns the logs started on date (YYYY-MM-DD). The
+// backend decides the day's bounds in its own time zone.
+func (c *BackendClient) GetDailyTimeLogs(ctx context.Context, date string) ([]dto.TimeLogRecordDto, error) {
+	var result []dto.TimeLogRecordDto
+	if err := c.doGet(ctx, "/time-logs/daily/"+url.PathEscape(date), nil, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *BackendClient) GetAgendaView(ctx context.Context, mode, anchorDate, timezone string) (*dto.AgendaViewDto, error) {
 	q := url.Values{}
 	q.Set("mode", mode)
 	q.Set("anchorDate", anchorDate)
 	q.Set("timezone", timezone)
-	var result json.RawMessage
+	var result dto.AgendaViewDto
 	if err := c.doGet(ctx, "/agenda-views", q, &result); err != nil {
 		return nil, err
 	}
-	return result, nil
+	return &result, nil
 }
 
 func (c *BackendClient) CreateAgendaItem(ctx context.Context, agendaID string, req dto.AgendaItemCreateRequest) (*dto.AgendaItemDto, error) {
@@ -270,6 +374,17 @@ func (c *BackendClient) CompleteAgendaItem(ctx context.Context, agendaID, itemID
 	return &result, nil
 }
 
+// ListAlarmPlans returns the alarm plans of a routine task.
+func (c *BackendClient) ListAlarmPlans(ctx context.Context, routineTaskID string) ([]dto.AlarmPlanDto, error) {
+	q := url.Values{}
+	q.Set("routineTaskId", routineTaskID)
+	var result []dto.AlarmPlanDto
+	if err := c.doGet(ctx, "/alarm-plans", q, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 func (c *BackendClient) doGet(ctx context.Context, path string, query url.Values, result interface{}) error {
 	return c.doRequest(ctx, http.MethodGet, path, query, nil, result)
 }
@@ -291,6 +406,16 @@ func (c *BackendClient) doDelete(ctx context.Context, path string) error {
 }
 
 func (c *BackendClient) doRequest(ctx context.Context, method, path string, query url.Values, body, result interface{}) error {
+	return c.send(ctx, method, path, query, body, result, false)
+}
+
+// doCachedGet is doGet backed by the response cache: a cached entry is
+// revalidated with its ETag or Last-Modified and reused on 304.
+func (c *BackendClient) doCachedGet(ctx context.Context, path string, query url.Values, result interface{}) error {
+	return c.send(ctx, http.MethodGet, path, query, nil, result, true)
+}
+
+func (c *BackendClient) send(ctx context.Context, method, path string, query url.Values, body, result interface{}, cacheable bool) error {
 	fullURL, err := url.JoinPath(c.baseURL, path)
 	if err != nil {
 		return &ConnectionError{Err: fmt.Errorf("invalid URL path %s: %w", path, err)}
@@ -323,27 +448,89 @@ func (c *BackendClient) doRequest(ctx context.Context, method, path string, quer
 		req.Header.Set("Content-Type", "application/json")
 	}
 	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Accept-Encoding", acceptEncoding)
 	if c.authToken != "" {
-		req.Header.Set("Authorization", "Bearer "+c.authToken)
+		if c.authScheme == AuthSchemeAPIKey {
+			req.Header.Set(headerAPIKey, c.authToken)
+		} else {
+			req.Header.Set("Authorization", "Bearer "+c.authToken)
+		}
 	}
+	if requestID := tracing.RequestIDFromContext(ctx); requestID != "" {
+		req.Header.Set(tracing.HeaderRequestID, requestID)
+	}
+
+	var cacheKey string
+	var cached *CacheEntry
+	if cacheable && c.cache != nil {
+		cacheKey = c.cache.Key(fullURL, c.authToken)
+		if entry, ok := c.cache.Get(cacheKey); ok {
+			cached = entry
+			if entry.ETag != "" {
+				req.Header.Set("If-None-Match", entry.ETag)
+			}
+			if entry.LastModified != "" {
+				req.Header.Set("If-Modified-Since", entry.LastModified)
+			}
+		}
+	}
+
+	trace := tracing.FromContext(ctx)
+	spanName := "backend " + method + " " + path
 
+	backendStart := time.Now()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
+		trace.AddSpan(spanName, time.Since(backendStart), err)
 		return &ConnectionError{Err: err}
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
+	respBody, err := readBody(resp)
 	if err != nil {
+		trace.AddSpan(spanName, time.Since(backendStart), err)
 		return &ConnectionError{Err: fmt.Errorf("failed to read response body: %w", err)}
 	}
 
+	if resp.StatusCode == http.StatusNotModified {
+		if cached == nil {
+			notModifiedErr := &ServerError{StatusCode: resp.StatusCode, Message: "not modified, but no cached response"}
+			trace.AddSpan(spanName, time.Since(backendStart), notModifiedErr)
+			return notModifiedErr
+		}
+		c.cache.Touch(cacheKey)
+		respBody = cached.Body
+		spanName += " (304)"
+	}
+
 	if resp.StatusCode >= 400 {
-		return c.handleErrorResponse(resp.StatusCode, respBody, path)
+		apiErr := c.handleErrorResponse(resp.StatusCode, respBody, path)
+		trace.AddSpan(spanName, time.Since(backendStart), apiErr)
+		if resp.StatusCode == http.StatusUnauthorized && c.onUnauthorized != nil {
+			c.onUnauthorized()
+		}
+		return apiErr
+	}
+	trace.AddSpan(spanName, time.Since(backendStart), nil)
+
+	if cacheKey != "" && resp.StatusCode == http.StatusOK {
+		etag := resp.Header.Get("ETag")
+		lastModified := resp.Header.Get("Last-Modified")
+		if etag != "" || lastModified != "" {
+			_ = c.cache.Put(cacheKey, &CacheEntry{
+				ETag:         etag,
+				LastModified: lastModified,
+				Body:         respBody,
+				StoredAt:     time.Now(),
+			})
+		}
 	}
 
 	if result != nil && len(respBody) > 0 {
-		if err := json.Unmarshal(respBody, result); err != nil {
+		decodeStart := time.Now()
+		err := json.Unmarshal(respBody, result)
+		trace.AddSpan("decode "+path, time.Since(decodeStart), err)
+		if err != nil {
 			return fmt.Errorf("failed to unmarshal response: %w", err)
 		}
 	}
@@ -0,0 +1,220 @@
+package httpclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+)
+
+// Interaction is a single recorded request/response pair.
+type Interaction struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`
+	RequestBody  string      `json:"request_body,omitempty"`
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header,omitempty"`
+	ResponseBody string      `json:"response_body,omitempty"`
+}
+
+// Cassette is the on-disk format shared by RecordingTransport and
+// ReplayTransport.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// LoadCassette reads a cassette file written by RecordingTransport.
+func LoadCassette(path string) (*Cassette, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cassette: %w", err)
+	}
+
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("failed to parse cassette: %w", err)
+	}
+
+	return &cassette, nil
+}
+
+// Save writes the cassette to path as indented JSON. It is written to a
+// temporary file first, so an existing cassette is never left half written.
+func (c *Cassette) Save(path string) error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal cassette: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("failed to write cassette: %w", err)
+	}
+
+	return nil
+}
+
+// credentialHeaders are response headers that can carry session cookies or
+// tokens, such as the bearer plugin's Set-Auth-Token; cassettes leave them
+// out.
+var credentialHeaders = []string{
+	"Set-Cookie",
+	"Set-Cookie2",
+	"Set-Auth-Token",
+	"Authorization",
+	"Proxy-Authorization",
+	"Authentication-Info",
+	"Proxy-Authentication-Info",
+	headerAPIKey,
+}
+
+// RecordingTransport forwards requests to the next transport and records
+// every exchange, writing the cassette file once on Close. Request headers
+// and the credentialHeaders of responses are not recorded, so auth tokens
+// never end up on disk. Compression and
+// conditional headers are dropped before forwarding so every recorded body
+// is plain JSON that can be replayed without a warm response cache.
+type RecordingTransport struct {
+	next     http.RoundTripper
+	path     string
+	cassette Cassette
+	mu       sync.Mutex
+}
+
+func NewRecordingTransport(next http.RoundTripper, path string) *RecordingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &RecordingTransport{
+		next: next,
+		path: path,
+	}
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	req = req.Clone(req.Context())
+	req.Header.Del("Accept-Encoding")
+	req.Header.Del("If-None-Match")
+	req.Header.Del("If-Modified-Since")
+
+	resp, err := t.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := drainBody(&resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	header := resp.Header.Clone()
+	for _, name := range credentialHeaders {
+		header.Del(name)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.cassette.Interactions = append(t.cassette.Interactions, Interaction{
+		Method:       req.Method,
+		URL:          req.URL.String(),
+		RequestBody:  string(reqBody),
+		StatusCode:   resp.StatusCode,
+		Header:       header,
+		ResponseBody: string(respBody),
+	})
+
+	return resp, nil
+}
+
+// Close writes the recorded interactions to the cassette file.
+func (t *RecordingTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.cassette.Save(t.path)
+}
+
+// ReplayTransport answers requests from a cassette without touching the
+// network. Interactions are matched on method, URL and request body and
+// each recorded interaction is served at most once, in recording order.
+type ReplayTransport struct {
+	interactions []Interaction
+	used         []bool
+	mu           sync.Mutex
+}
+
+func NewReplayTransport(cassette *Cassette) *ReplayTransport {
+	return &ReplayTransport{
+		interactions: cassette.Interactions,
+		used:         make([]bool, len(cassette.Interactions)),
+	}
+}
+
+func (t *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqBody, err := drainBody(&req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request body: %w", err)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	reqURL := req.URL.String()
+	for i, interaction := range t.interactions {
+		if t.used[i] || interaction.Method != req.Method || interaction.URL != reqURL {
+			continue
+		}
+		if interaction.RequestBody != string(reqBody) {
+			continue
+		}
+
+		t.used[i] = true
+		header := interaction.Header.Clone()
+		if header == nil {
+			header = make(http.Header)
+		}
+		return &http.Response{
+			StatusCode:    interaction.StatusCode,
+			Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          io.NopCloser(bytes.NewReader([]byte(interaction.ResponseBody))),
+			ContentLength: int64(len(interaction.ResponseBody)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf("no recorded interaction for %s %s", req.Method, reqURL)
+}
+
+// drainBody reads body fully and replaces it with an equivalent reader so
+// the caller can still consume it.
+func drainBody(body *io.ReadCloser) ([]byte, error) {
+	if *body == nil || *body == http.NoBody {
+		return nil, nil
+	}
+
+	data, err := io.ReadAll(*body)
+	(*body).Close()
+	if err != nil {
+		return nil, err
+	}
+
+	*body = io.NopCloser(bytes.NewReader(data))
+	return data, nil
+}
@@ -0,0 +1,126 @@
+package httpclient
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReplayTransportMatching(t *testing.T) {
+	cassette := &Cassette{Interactions: []Interaction{
+		{Method: "GET", URL: "http://backend/projects?page=1", StatusCode: 200, ResponseBody: `"projects 1"`},
+		{Method: "GET", URL: "http://backend/projects?page=1", StatusCode: 200, ResponseBody: `"projects 2"`},
+		{Method: "POST", URL: "http://backend/time-logs", RequestBody: `{"duration_minutes":30}`, StatusCode: 201, ResponseBody: `"30"`},
+		{Method: "POST", URL: "http://backend/time-logs", RequestBody: `{"duration_minutes":45}`, StatusCode: 201, ResponseBody: `"45"`},
+		{Method: "DELETE", URL: "http://backend/tasks/1", StatusCode: 204},
+	}}
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		want   string
+		status int
+	}{
+		{"first of identical requests", "GET", "http://backend/projects?page=1", "", `"projects 1"`, 200},
+		{"then the next one", "GET", "http://backend/projects?page=1", "", `"projects 2"`, 200},
+		{"used up", "GET", "http://backend/projects?page=1", "", "", 0},
+		{"other query", "GET", "http://backend/projects?page=2", "", "", 0},
+		{"body picks the interaction", "POST", "http://backend/time-logs", `{"duration_minutes":45}`, `"45"`, 201},
+		{"out of order", "POST", "http://backend/time-logs", `{"duration_minutes":30}`, `"30"`, 201},
+		{"unrecorded body", "POST", "http://backend/time-logs", `{"duration_minutes":60}`, "", 0},
+		{"method must match", "GET", "http://backend/tasks/1", "", "", 0},
+		{"no body", "DELETE", "http://backend/tasks/1", "", "", 204},
+	}
+
+	replay := NewReplayTransport(cassette)
+	for _, tt := range tests {
+		var body io.Reader
+		if tt.body != "" {
+			body = strings.NewReader(tt.body)
+		}
+		req, err := http.NewRequest(tt.method, tt.url, body)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := replay.RoundTrip(req)
+		if tt.status == 0 {
+			if err == nil {
+				t.Errorf("%s: got %d, want no recorded interaction", tt.name, resp.StatusCode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		data, _ := io.ReadAll(resp.Body)
+		if resp.StatusCode != tt.status || string(data) != tt.want {
+			t.Errorf("%s: got %d %s, want %d %s", tt.name, resp.StatusCode, data, tt.status, tt.want)
+		}
+	}
+}
+
+func TestRecordingTransportSavesOnClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cassette.json")
+	backend := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		data, _ := io.ReadAll(req.Body)
+		header := http.Header{"Content-Type": {"application/json"}}
+		header.Add("Set-Cookie", "session=cookie-secret; HttpOnly")
+		header.Set("Set-Auth-Token", "token-secret")
+		return &http.Response{
+			StatusCode: 200,
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(`{"echo":` + string(data) + `}`)),
+		}, nil
+	})
+
+	recorder := NewRecordingTransport(backend, path)
+	req, _ := http.NewRequest("POST", "http://backend/notes", strings.NewReader(`"hi"`))
+	req.Header.Set("Authorization", "Bearer secret")
+	resp, err := recorder.RoundTrip(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := io.ReadAll(resp.Body); string(data) != `{"echo":"hi"}` {
+		t.Errorf("caller got %s, want the backend's response", data)
+	}
+	if resp.Header.Get("Set-Cookie") == "" {
+		t.Error("the caller lost the response's cookie")
+	}
+
+	if _, err := LoadCassette(path); err == nil {
+		t.Error("cassette written before Close")
+	}
+	if err := recorder.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cassette, err := LoadCassette(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cassette.Interactions) != 1 {
+		t.Fatalf("recorded %d interactions, want 1", len(cassette.Interactions))
+	}
+	got := cassette.Interactions[0]
+	if got.Method != "POST" || got.URL != "http://backend/notes" || got.RequestBody != `"hi"` ||
+		got.StatusCode != 200 || got.ResponseBody != `{"echo":"hi"}` ||
+		got.Header.Get("Content-Type") != "application/json" {
+		t.Errorf("recorded %+v", got)
+	}
+	if data, _ := os.ReadFile(path); strings.Contains(string(data), "secret") {
+		t.Errorf("credentials were recorded: %s", data)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
@@ -0,0 +1,61 @@
+package tracing
+
+import (
+	"sync"
+	"time"
+)
+
+// Recorder keeps the most recent slow or failed traces in a fixed-size ring.
+type Recorder struct {
+	threshold time.Duration
+	capacity  int
+	traces    []*Trace
+	next      int
+	mu        sync.Mutex
+}
+
+func NewRecorder(threshold time.Duration, capacity int) *Recorder {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Recorder{
+		threshold: threshold,
+		capacity:  capacity,
+	}
+}
+
+// Record stores t if it exceeded the slow threshold or failed, and reports
+// whether it was kept.
+func (r *Recorder) Record(t *Trace) bool {
+	if t.Duration < r.threshold && t.Error == "" {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.traces) < r.capacity {
+		r.traces = append(r.traces, t)
+	} else {
+		r.traces[r.next] = t
+	}
+	r.next = (r.next + 1) % r.capacity
+	return true
+}
+
+// Recent returns the kept traces, oldest first.
+func (r *Recorder) Recent() []*Trace {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	result := make([]*Trace, 0, len(r.traces))
+	if len(r.traces) < r.capacity {
+		return append(result, r.traces...)
+	}
+	result = append(result, r.traces[r.next:]...)
+	return append(result, r.traces[:r.next]...)
+}
+
+func (r *Recorder) Threshold() time.Duration {
+	return r.threshold
+}
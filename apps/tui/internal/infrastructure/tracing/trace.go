@@ -0,0 +1,127 @@
+// Package tracing carries correlation IDs and timing spans for a single user
+// action from the TUI, through the daemon, to the backend.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// HeaderRequestID is the HTTP header used to forward the correlation ID to
+// the backend.
+const HeaderRequestID = "X-Request-ID"
+
+type contextKey int
+
+const (
+	requestIDKey contextKey = iota
+	traceKey
+)
+
+// NewRequestID returns a short random correlation ID.
+func NewRequestID() string {
+	return strings.ReplaceAll(uuid.New().String(), "-", "")[:16]
+}
+
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+func RequestIDFromContext(ctx context.Context) string {
+	if id, ok := ctx.Value(requestIDKey).(string); ok {
+		return id
+	}
+	return ""
+}
+
+// NewActionContext returns a context carrying a fresh correlation ID. The TUI
+// calls it once per user action so every request the action makes shares
+// the same ID.
+func NewActionContext() context.Context {
+	return WithRequestID(context.Background(), NewRequestID())
+}
+
+// Span is a named, timed step of a request.
+type Span struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Error    string        `json:"error,omitempty"`
+}
+
+// Trace collects the spans recorded while the daemon handles one request.
+type Trace struct {
+	RequestID string        `json:"request_id"`
+	Type      string        `json:"type"`
+	StartedAt time.Time     `json:"started_at"`
+	Duration  time.Duration `json:"duration"`
+	Error     string        `json:"error,omitempty"`
+	Spans     []Span        `json:"spans"`
+
+	mu sync.Mutex
+}
+
+func NewTrace(requestID, reqType string, startedAt time.Time) *Trace {
+	return &Trace{
+		RequestID: requestID,
+		Type:      reqType,
+		StartedAt: startedAt,
+	}
+}
+
+// AddSpan records a completed span. It is safe to call on a nil Trace.
+func (t *Trace) AddSpan(name string, d time.Duration, err error) {
+	if t == nil {
+		return
+	}
+	span := Span{Name: name, Duration: d}
+	if err != nil {
+		span.Error = err.Error()
+	}
+
+	t.mu.Lock()
+	t.Spans = append(t.Spans, span)
+	t.mu.Unlock()
+}
+
+// Finish stamps the total duration and outcome of the request.
+func (t *Trace) Finish(end time.Time, errMsg string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Duration = end.Sub(t.StartedAt)
+	t.Error = errMsg
+}
+
+// String renders the trace on a single line for the daemon log.
+func (t *Trace) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	parts := make([]string, 0, len(t.Spans))
+	for _, span := range t.Spans {
+		parts = append(parts, fmt.Sprintf("%s=%s", span.Name, span.Duration.Round(time.Microsecond)))
+	}
+
+	s := fmt.Sprintf("%s %s %s [%s]", t.RequestID, t.Type, t.Duration.Round(time.Microsecond), strings.Join(parts, " "))
+	if t.Error != "" {
+		s += " error: " + t.Error
+	}
+	return s
+}
+
+func WithTrace(ctx context.Context, t *Trace) context.Context {
+	ctx = context.WithValue(ctx, traceKey, t)
+	return WithRequestID(ctx, t.RequestID)
+}
+
+// FromContext returns the trace stored in ctx, or nil.
+func FromContext(ctx context.Context) *Trace {
+	if t, ok := ctx.Value(traceKey).(*Trace); ok {
+		return t
+	}
+	return nil
+}
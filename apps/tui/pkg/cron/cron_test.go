@@ -0,0 +1,102 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseErrors(t *testing.T) {
+	for _, spec := range []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"5-1 * * * *",
+		"*/0 * * * *",
+		"* * * foo *",
+		"@sometimes",
+	} {
+		if _, err := Parse(spec); err == nil {
+			t.Errorf("Parse(%q) succeeded, want an error", spec)
+		}
+	}
+}
+
+func TestMatches(t *testing.T) {
+	// 2026-10-19 is a Monday
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		spec string
+		t    time.Time
+		want bool
+	}{
+		{"* * * * *", at(10, 19, 3, 17), true},
+		{"0 9 * * 1-5", at(10, 19, 9, 0), true},
+		{"0 9 * * 1-5", at(10, 18, 9, 0), false},
+		{"0 9 * * 1-5", at(10, 19, 9, 1), false},
+		{"0 9 * * mon-fri", at(10, 23, 9, 0), true},
+		{"*/15 * * * *", at(10, 19, 9, 45), true},
+		{"*/15 * * * *", at(10, 19, 9, 50), false},
+		{"5/15 * * * *", at(10, 19, 9, 50), true},
+		{"0 8,12,18 * * *", at(10, 19, 12, 0), true},
+		{"0 0 * * 7", at(10, 18, 0, 0), true},
+		{"0 0 * * sun", at(10, 18, 0, 0), true},
+		{"0 0 1 jan *", at(1, 1, 0, 0), true},
+		{"@daily", at(10, 19, 0, 0), true},
+		{"@hourly", at(10, 19, 7, 0), true},
+		{"@weekly", at(10, 19, 0, 0), false},
+		// Both day fields restricted: either one matching is enough
+		{"0 0 1 * 1", at(10, 19, 0, 0), true},
+		{"0 0 1 * 1", at(10, 1, 0, 0), true},
+		{"0 0 1 * 1", at(10, 2, 0, 0), false},
+		// Only the day of month restricted
+		{"0 0 1 * *", at(10, 19, 0, 0), false},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.spec)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if got := s.Matches(tt.t); got != tt.want {
+			t.Errorf("Parse(%q).Matches(%v) = %v, want %v", tt.spec, tt.t, got, tt.want)
+		}
+	}
+}
+
+func TestNext(t *testing.T) {
+	at := func(year int, month time.Month, day, hour, minute int) time.Time {
+		return time.Date(year, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"* * * * *", at(2026, 10, 19, 9, 0).Add(30 * time.Second), at(2026, 10, 19, 9, 1)},
+		{"0 9 * * 1-5", at(2026, 10, 19, 9, 0), at(2026, 10, 20, 9, 0)},
+		{"0 9 * * 1-5", at(2026, 10, 23, 10, 0), at(2026, 10, 26, 9, 0)},
+		{"30 17 * * *", at(2026, 10, 19, 8, 0), at(2026, 10, 19, 17, 30)},
+		{"0 0 1 * *", at(2026, 12, 15, 0, 0), at(2027, 1, 1, 0, 0)},
+		{"0 0 29 2 *", at(2026, 3, 1, 0, 0), at(2028, 2, 29, 0, 0)},
+		{"0 0 30 2 *", at(2026, 3, 1, 0, 0), time.Time{}},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.spec)
+		if err != nil {
+			t.Errorf("Parse(%q) error: %v", tt.spec, err)
+			continue
+		}
+		if got := s.Next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("Parse(%q).Next(%v) = %v, want %v", tt.spec, tt.from, got, tt.want)
+		}
+	}
+}
@@ -1,19 +1,35 @@
 package output
 
 import (
+	"encoding/csv"
 	"encoding/json"
 	"fmt"
 	"io"
+	"strings"
 )
 
 // Format represents the output format type
 type Format string
 
 const (
-	FormatText Format = "text"
-	FormatJSON Format = "json"
+	FormatText     Format = "text"
+	FormatJSON     Format = "json"
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "markdown"
 )
 
+// Table is tabular data for the text, CSV and Markdown formats
+type Table struct {
+	Headers []string
+	Rows    [][]string
+}
+
+// Tabular is implemented by data that can be shown as a table. JSON output
+// still encodes the value itself.
+type Tabular interface {
+	Table() Table
+}
+
 // Formatter handles output formatting for different formats
 type Formatter struct {
 	format Format
@@ -35,6 +51,10 @@ func (f *Formatter) Print(data interface{}) error {
 		return f.printJSON(data)
 	case FormatText:
 		return f.printText(data)
+	case FormatCSV:
+		return f.printTable(data, f.writeCSV)
+	case FormatMarkdown:
+		return f.printTable(data, f.writeMarkdown)
 	default:
 		return fmt.Errorf("unsupported output format: %s", f.format)
 	}
@@ -50,6 +70,10 @@ func (f *Formatter) printJSON(data interface{}) error {
 // printText outputs data as plain text
 func (f *Formatter) printText(data interface{}) error {
 	switch v := data.(type) {
+	case Tabular:
+		t := v.Table()
+		NewPrinter(f.writer).Table(t.Headers, t.Rows)
+		return nil
 	case string:
 		_, err := fmt.Fprintln(f.writer, v)
 		return err
@@ -62,6 +86,58 @@ func (f *Formatter) printText(data interface{}) error {
 	}
 }
 
+// printTable outputs tabular data with write, or plain text lines as is
+func (f *Formatter) printTable(data interface{}, write func(Table) error) error {
+	switch v := data.(type) {
+	case Tabular:
+		return write(v.Table())
+	case string:
+		_, err := fmt.Fprintln(f.writer, v)
+		return err
+	default:
+		return fmt.Errorf("%s output is not supported here", f.format)
+	}
+}
+
+// writeCSV outputs a table as CSV with a header row
+func (f *Formatter) writeCSV(t Table) error {
+	w := csv.NewWriter(f.writer)
+	if err := w.Write(t.Headers); err != nil {
+		return err
+	}
+	if err := w.WriteAll(t.Rows); err != nil {
+		return err
+	}
+	return w.Error()
+}
+
+// writeMarkdown outputs a table as a GitHub-flavored Markdown table
+func (f *Formatter) writeMarkdown(t Table) error {
+	escape := func(s string) string {
+		return strings.ReplaceAll(strings.ReplaceAll(s, "|", `\|`), "\n", " ")
+	}
+
+	cells := make([]string, len(t.Headers))
+	for i, h := range t.Headers {
+		cells[i] = escape(h)
+	}
+	lines := []string{"| " + strings.Join(cells, " | ") + " |"}
+	lines = append(lines, "|"+strings.Repeat(" --- |", len(t.Headers)))
+
+	for _, row := range t.Rows {
+		cells := make([]string, len(t.Headers))
+		for i := range t.Headers {
+			if i < len(row) {
+				cells[i] = escape(row[i])
+			}
+		}
+		lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+	}
+
+	_, err := fmt.Fprintln(f.writer, strings.Join(lines, "\n"))
+	return err
+}
+
 // ParseFormat converts a string to a Format
 func ParseFormat(s string) (Format, error) {
 	switch s {
@@ -69,7 +145,11 @@ func ParseFormat(s string) (Format, error) {
 		return FormatText, nil
 	case "json":
 		return FormatJSON, nil
+	case "csv":
+		return FormatCSV, nil
+	case "markdown", "md":
+		return FormatMarkdown, nil
 	default:
-		return FormatText, fmt.Errorf("invalid format '%s': must be one of: text, json", s)
+		return FormatText, fmt.Errorf("invalid format '%s': must be one of: text, json, csv, markdown", s)
 	}
 }
@@ -0,0 +1,228 @@
+// Package timeparse reads the loose durations and dates people type on the
+// command line, such as "1h30m", "90 min", "yesterday 14:00" or "2h ago".
+package timeparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationWords = []struct{ word, unit string }{
+		{"hours", "h"}, {"hour", "h"}, {"hrs", "h"}, {"hr", "h"},
+		{"minutes", "m"}, {"minute", "m"}, {"mins", "m"}, {"min", "m"},
+		{"seconds", "s"}, {"second", "s"}, {"secs", "s"}, {"sec", "s"},
+	}
+
+	dayWeekPattern  = regexp.MustCompile(`(\d+(?:\.\d+)?)\s*(weeks|week|wks|wk|w|days|day|d)\b`)
+	clockPattern    = regexp.MustCompile(`^(\d{1,2})(?::(\d{2}))?(am|pm)?$`)
+	hhmmPattern     = regexp.MustCompile(`^(\d+):(\d{2})$`)
+	plainNumPattern = regexp.MustCompile(`^\d+(?:\.\d+)?$`)
+
+	dateLayouts = []string{
+		time.RFC3339,
+		"2006-01-02T15:04",
+		"2006-01-02 15:04",
+		"2006-01-02",
+	}
+)
+
+// ParseDuration accepts Go durations ("1h30m", "1.5h") and the forms people
+// tend to type: "90m", "90 min", "1 hour 30 minutes", "1:30", "half an hour",
+// "2 days". A bare number is taken as minutes.
+func ParseDuration(s string) (time.Duration, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+	if in == "" {
+		return 0, fmt.Errorf("empty duration")
+	}
+
+	switch in {
+	case "half an hour", "half hour":
+		return 30 * time.Minute, nil
+	case "an hour", "a hour":
+		return time.Hour, nil
+	}
+
+	if m := hhmmPattern.FindStringSubmatch(in); m != nil {
+		h, _ := strconv.Atoi(m[1])
+		min, _ := strconv.Atoi(m[2])
+		return time.Duration(h)*time.Hour + time.Duration(min)*time.Minute, nil
+	}
+
+	if plainNumPattern.MatchString(in) {
+		n, _ := strconv.ParseFloat(in, 64)
+		return time.Duration(n * float64(time.Minute)), nil
+	}
+
+	// time.ParseDuration stops at hours, so days and weeks become hours.
+	norm := dayWeekPattern.ReplaceAllStringFunc(in, func(m string) string {
+		parts := dayWeekPattern.FindStringSubmatch(m)
+		n, _ := strconv.ParseFloat(parts[1], 64)
+		hours := n * 24
+		if strings.HasPrefix(parts[2], "w") {
+			hours *= 7
+		}
+		return strconv.FormatFloat(hours, 'f', -1, 64) + "h "
+	})
+	norm = strings.ReplaceAll(norm, " and ", " ")
+	norm = strings.ReplaceAll(norm, ",", " ")
+	for _, w := range durationWords {
+		norm = strings.ReplaceAll(norm, w.word, w.unit)
+	}
+	norm = strings.Join(strings.Fields(norm), "")
+
+	d, err := time.ParseDuration(norm)
+	if err != nil {
+		return 0, fmt.Errorf("invalid duration %q (try 1h30m, 90m or 1:30)", s)
+	}
+	if d < 0 {
+		return 0, fmt.Errorf("duration %q is negative", s)
+	}
+	return d, nil
+}
+
+// ParseTime reads an absolute or relative point in time, relative to now:
+//
+//	now, 2h ago, 14:00, 2pm, today 9:30, yesterday 14:00, monday 10am,
+//	last friday, 2026-02-15, 2026-02-15 14:00, RFC 3339
+//
+// A day without a time of day means its start; a time without a day means
+// today.
+func ParseTime(s string, now time.Time) (time.Time, error) {
+	in := strings.ToLower(strings.TrimSpace(s))
+	if in == "" || in == "now" {
+		return now, nil
+	}
+
+	if rest, ok := strings.CutSuffix(in, " ago"); ok {
+		d, err := ParseDuration(rest)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(-d), nil
+	}
+
+	// Layouts are matched before lowercasing, which would break their T and Z.
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, strings.TrimSpace(s), now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	fields := strings.Fields(in)
+	day, rest, hasDay := parseDay(fields, now)
+
+	clock := strings.Join(rest, "")
+	clock = strings.TrimPrefix(clock, "at")
+	if clock == "" {
+		if !hasDay {
+			return time.Time{}, fmt.Errorf("invalid time %q", s)
+		}
+		return day, nil
+	}
+
+	hour, minute, err := parseClock(clock)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time %q (try \"yesterday 14:00\" or \"2h ago\")", s)
+	}
+	// Set the clock rather than adding hours to midnight, which is off by
+	// the shift on days a DST change happens.
+	y, m, d := day.Date()
+	return time.Date(y, m, d, hour, minute, 0, 0, now.Location()), nil
+}
+
+// StartOfDay returns midnight of t's day in t's location.
+func StartOfDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
+
+// parseDay consumes a leading day word and returns the day's start, the
+// remaining fields and whether a day was given. Without one, today is used.
+func parseDay(fields []string, now time.Time) (time.Time, []string, bool) {
+	today := StartOfDay(now)
+	if len(fields) == 0 {
+		return today, fields, false
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", fields[0], now.Location()); err == nil {
+		return t, fields[1:], true
+	}
+
+	switch fields[0] {
+	case "today":
+		return today, fields[1:], true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), fields[1:], true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), fields[1:], true
+	}
+
+	last := false
+	rest := fields
+	if rest[0] == "last" && len(rest) > 1 {
+		last = true
+		rest = rest[1:]
+	}
+	if wd, ok := parseWeekday(rest[0]); ok {
+		back := (int(now.Weekday()) - int(wd) + 7) % 7
+		if last && back == 0 {
+			back = 7
+		}
+		return today.AddDate(0, 0, -back), rest[1:], true
+	}
+
+	return today, fields, false
+}
+
+func parseWeekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+func parseClock(s string) (int, int, error) {
+	switch s {
+	case "noon":
+		return 12, 0, nil
+	case "midnight":
+		return 0, 0, nil
+	}
+
+	m := clockPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, fmt.Errorf("invalid clock time %q", s)
+	}
+
+	hour, _ := strconv.Atoi(m[1])
+	minute := 0
+	if m[2] != "" {
+		minute, _ = strconv.Atoi(m[2])
+	}
+	if m[3] != "" && (hour == 0 || hour > 12) {
+		return 0, 0, fmt.Errorf("invalid clock time %q", s)
+	}
+
+	switch m[3] {
+	case "am":
+		if hour == 12 {
+			hour = 0
+		}
+	case "pm":
+		if hour < 12 {
+			hour += 12
+		}
+	}
+
+	if hour > 23 || minute > 59 {
+		return 0, 0, fmt.Errorf("invalid clock time %q", s)
+	}
+	return hour, minute, nil
+}
@@ -0,0 +1,141 @@
+package timeparse
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"1h30m", 90 * time.Minute},
+		{"1.5h", 90 * time.Minute},
+		{"90m", 90 * time.Minute},
+		{"90 min", 90 * time.Minute},
+		{"1 hour 30 minutes", 90 * time.Minute},
+		{"1h and 15 minutes", 75 * time.Minute},
+		{"1:30", 90 * time.Minute},
+		{"half an hour", 30 * time.Minute},
+		{"an hour", time.Hour},
+		{"45", 45 * time.Minute},
+		{"2 days", 48 * time.Hour},
+		{"1 week", 7 * 24 * time.Hour},
+		{"  2H  ", 2 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := ParseDuration(tt.in)
+		if err != nil {
+			t.Errorf("ParseDuration(%q) error: %v", tt.in, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ParseDuration(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseDurationErrors(t *testing.T) {
+	for _, in := range []string{"", "   ", "soon", "-5m", "1 fortnight"} {
+		if d, err := ParseDuration(in); err == nil {
+			t.Errorf("ParseDuration(%q) = %v, want an error", in, d)
+		}
+	}
+}
+
+func TestParseTime(t *testing.T) {
+	// A Monday afternoon
+	now := time.Date(2026, 10, 19, 15, 4, 0, 0, time.UTC)
+	at := func(month time.Month, day, hour, minute int) time.Time {
+		return time.Date(2026, month, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{"", now},
+		{"now", now},
+		{"2h ago", at(10, 19, 13, 4)},
+		{"90 min ago", at(10, 19, 13, 34)},
+		{"14:00", at(10, 19, 14, 0)},
+		{"2pm", at(10, 19, 14, 0)},
+		{"12am", at(10, 19, 0, 0)},
+		{"noon", at(10, 19, 12, 0)},
+		{"at 9:30", at(10, 19, 9, 30)},
+		{"today 9:30", at(10, 19, 9, 30)},
+		{"yesterday 14:00", at(10, 18, 14, 0)},
+		{"tomorrow", at(10, 20, 0, 0)},
+		{"monday 10am", at(10, 19, 10, 0)},
+		{"last monday", at(10, 12, 0, 0)},
+		{"friday", at(10, 16, 0, 0)},
+		{"Fri 5pm", at(10, 16, 17, 0)},
+		{"2026-02-15", at(2, 15, 0, 0)},
+		{"2026-02-15 14:00", at(2, 15, 14, 0)},
+		{"2026-02-15T14:00", at(2, 15, 14, 0)},
+		{"2026-02-15T14:00:00Z", at(2, 15, 14, 0)},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.in, now)
+		if err != nil {
+			t.Errorf("ParseTime(%q) error: %v", tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("ParseTime(%q) = %v, want %v", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestParseTimeErrors(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 4, 0, 0, time.UTC)
+	for _, in := range []string{"13pm", "0am", "25:00", "9:75", "someday", "yesterday soonish"} {
+		if got, err := ParseTime(in, now); err == nil {
+			t.Errorf("ParseTime(%q) = %v, want an error", in, got)
+		}
+	}
+}
+
+func TestParseTimeAcrossDST(t *testing.T) {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skipf("no time zone data: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		in   string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "clock on the day DST starts",
+			in:   "14:00",
+			now:  time.Date(2026, 3, 8, 20, 0, 0, 0, loc),
+			want: time.Date(2026, 3, 8, 14, 0, 0, 0, loc),
+		},
+		{
+			name: "yesterday across the start of DST",
+			in:   "yesterday 9am",
+			now:  time.Date(2026, 3, 9, 12, 0, 0, 0, loc),
+			want: time.Date(2026, 3, 8, 9, 0, 0, 0, loc),
+		},
+		{
+			name: "clock on the day DST ends",
+			in:   "today 18:30",
+			now:  time.Date(2026, 11, 1, 20, 0, 0, 0, loc),
+			want: time.Date(2026, 11, 1, 18, 30, 0, 0, loc),
+		},
+	}
+	for _, tt := range tests {
+		got, err := ParseTime(tt.in, tt.now)
+		if err != nil {
+			t.Errorf("%s: ParseTime(%q) error: %v", tt.name, tt.in, err)
+			continue
+		}
+		if !got.Equal(tt.want) {
+			t.Errorf("%s: ParseTime(%q) = %v, want %v", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,265 @@
+package agenda
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"cadence/internal/application/dto"
+)
+
+// Week and month views are drawn as grids: a column per day for a week and
+// a calendar of day cells for a month. The cursor still walks m.items in
+// order; the grid only decides where each item is drawn.
+
+var (
+	dayHeaderStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888888")).
+			Bold(true)
+
+	todayHeaderStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#A8DADC")).
+				Bold(true).
+				Underline(true)
+
+	otherMonthStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#444444"))
+
+	selectedCellStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color("#1A1A1A")).
+				Background(lipgloss.Color("#A8DADC")).
+				Bold(true)
+)
+
+// maxUnfinishedLines bounds the unfinished items listed under a grid.
+const maxUnfinishedLines = 3
+
+func (m Model) renderGrid(maxHeight int) string {
+	width := m.width - 8
+	if width < 7*8+6 {
+		width = 7*8 + 6
+	}
+
+	sections := make(map[string]agendaSection)
+	var unfinished *agendaSection
+	for i, section := range m.sections {
+		if section.dateKey != "" {
+			sections[section.dateKey] = section
+		} else {
+			unfinished = &m.sections[i]
+		}
+	}
+
+	var below []string
+	if unfinished != nil {
+		below = append(below, "", sectionStyle.Render(unfinished.title))
+		below = append(below, m.cellLines(*unfinished, width, maxUnfinishedLines, true)...)
+	}
+
+	gridHeight := maxHeight - len(below)
+	if gridHeight < 2 {
+		gridHeight = 2
+	}
+
+	var grid string
+	if m.view.Mode == dto.AgendaViewModeMonth {
+		grid = m.renderMonthGrid(sections, width, gridHeight)
+	} else {
+		grid = m.renderWeekGrid(sections, width, gridHeight)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Left, append([]string{grid}, below...)...)
+}
+
+// renderWeekGrid draws a column per day with its all-day items first, then
+// its timed items in order.
+func (m Model) renderWeekGrid(sections map[string]agendaSection, width, height int) string {
+	colWidth := gridColumnWidth(width)
+	cell := lipgloss.NewStyle().Width(colWidth)
+
+	columns := make([]string, 0, len(m.view.Days))
+	for _, day := range m.view.Days {
+		label := day.ShortLabel
+		if label == "" {
+			label = day.Label
+		}
+		header := dayHeaderStyle
+		if day.IsToday {
+			header = todayHeaderStyle
+		}
+
+		lines := []string{header.Render(truncate(label, colWidth))}
+		lines = append(lines, m.cellLines(sections[day.DateKey], colWidth, height-1, true)...)
+		for i := range lines {
+			lines[i] = cell.Render(lines[i])
+		}
+		columns = append(columns, strings.Join(lines, "\n"))
+	}
+
+	return joinColumns(columns)
+}
+
+// renderMonthGrid draws a calendar: a row of weekday names, then a row of
+// day cells per week. Days outside the month are dimmed and left empty.
+func (m Model) renderMonthGrid(sections map[string]agendaSection, width, height int) string {
+	colWidth := gridColumnWidth(width)
+	cell := lipgloss.NewStyle().Width(colWidth)
+
+	days := m.view.Days
+	weeks := (len(days) + 6) / 7
+	if weeks == 0 {
+		return ""
+	}
+	cellHeight := (height - 1) / weeks
+	if cellHeight < 2 {
+		cellHeight = 2
+	}
+
+	weekdays := m.view.WeekdayLabels
+	if len(weekdays) != 7 {
+		weekdays = make([]string, 0, 7)
+		for _, day := range days[:min(7, len(days))] {
+			if t, err := time.Parse("2006-01-02", day.DateKey); err == nil {
+				weekdays = append(weekdays, t.Format("Mon"))
+			}
+		}
+	}
+	var headers []string
+	for _, name := range weekdays {
+		headers = append(headers, cell.Render(dayHeaderStyle.Render(truncate(name, colWidth))))
+	}
+	rows := []string{joinColumns(headers)}
+
+	for w := 0; w < weeks; w++ {
+		var columns []string
+		for _, day := range days[w*7 : min((w+1)*7, len(days))] {
+			number := day.Label
+			if t, err := time.Parse("2006-01-02", day.DateKey); err == nil {
+				number = fmt.Sprintf("%d", t.Day())
+			}
+
+			var lines []string
+			switch {
+			case !day.IsCurrentMonth:
+				lines = append(lines, otherMonthStyle.Render(number))
+				for len(lines) < cellHeight {
+					lines = append(lines, "")
+				}
+			case day.IsToday:
+				lines = append(lines, todayHeaderStyle.Render(number))
+			default:
+				lines = append(lines, dayHeaderStyle.Render(number))
+			}
+			if day.IsCurrentMonth {
+				lines = append(lines, m.cellLines(sections[day.DateKey], colWidth, cellHeight-1, false)...)
+			}
+
+			for i := range lines {
+				lines[i] = cell.Render(lines[i])
+			}
+			columns = append(columns, strings.Join(lines, "\n"))
+		}
+		rows = append(rows, joinColumns(columns))
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// cellLines renders a section's items one line each into height lines.
+// When they do not fit, the last line counts the rest, along with the items
+// the backend left out, and the lines shown follow the cursor.
+func (m Model) cellLines(section agendaSection, width, height int, withTime bool) []string {
+	if height < 1 {
+		return nil
+	}
+
+	total := section.end - section.start
+	shown := total
+	if total > height || (section.more > 0 && total >= height) {
+		shown = height - 1
+	}
+
+	first := section.start
+	if m.cursor >= section.start && m.cursor < section.end && m.cursor-section.start >= shown {
+		first = m.cursor - shown + 1
+	}
+
+	lines := make([]string, 0, height)
+	for i := first; i < first+shown; i++ {
+		lines = append(lines, m.cellItem(i, width, withTime))
+	}
+	if hidden := total - shown + section.more; hidden > 0 {
+		lines = append(lines, metaStyle.Render(truncate(fmt.Sprintf("+%d more", hidden), width)))
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	return lines
+}
+
+// cellItem renders an item as a single line: its status, its start time
+// when asked for and its title, cut to width.
+func (m Model) cellItem(index, width int, withTime bool) string {
+	item := m.items[index]
+
+	text := getItemTitle(item)
+	if withTime && item.StartAt != nil && len(*item.StartAt) >= 16 {
+		text = (*item.StartAt)[11:16] + " " + text
+	}
+	text = truncate(text, width-2)
+
+	switch {
+	case index == m.cursor:
+		return selectedCellStyle.Render(getPlainStatusIcon(item.Status) + " " + text)
+	case item.Status == dto.AgendaItemStatusCompleted:
+		return getStatusIcon(item.Status) + " " + completedTitleStyle.Render(text)
+	default:
+		return getStatusIcon(item.Status) + " " + text
+	}
+}
+
+func getPlainStatusIcon(status string) string {
+	switch status {
+	case dto.AgendaItemStatusCompleted:
+		return "✓"
+	case dto.AgendaItemStatusSkipped:
+		return "✗"
+	case dto.AgendaItemStatusUnfinished:
+		return "◑"
+	default:
+		return "○"
+	}
+}
+
+// gridColumnWidth splits width into seven day columns with a space between.
+func gridColumnWidth(width int) int {
+	return (width - 6) / 7
+}
+
+func joinColumns(columns []string) string {
+	spaced := make([]string, 0, 2*len(columns))
+	for i, column := range columns {
+		if i > 0 {
+			spaced = append(spaced, " ")
+		}
+		spaced = append(spaced, column)
+	}
+	return lipgloss.JoinHorizontal(lipgloss.Top, spaced...)
+}
+
+// truncate cuts s to width cells, ending with an ellipsis when cut.
+func truncate(s string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+	if lipgloss.Width(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	for len(runes) > 0 && lipgloss.Width(string(runes))+1 > width {
+		runes = runes[:len(runes)-1]
+	}
+	return string(runes) + "…"
+}
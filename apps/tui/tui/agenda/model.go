@@ -1,7 +1,6 @@
 package agenda
 
 import (
-	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -13,11 +12,24 @@ import (
 	"cadence/internal/application/dto"
 	"cadence/internal/daemon"
 	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/tracing"
 )
 
 type agendaLoadedMsg struct {
-	items []dto.AgendaItemEnrichedDto
-	err   error
+	view *dto.AgendaViewDto
+	err  error
+}
+
+// agendaSection is a titled run of items in the flattened item list, e.g.
+// one day of a week view. start and end index into Model.items, and dateKey
+// is set for the sections of a week or month day.
+type agendaSection struct {
+	title   string
+	dateKey string
+	isToday bool
+	start   int
+	end     int
+	more    int
 }
 
 type Model struct {
@@ -25,7 +37,9 @@ type Model struct {
 	config       *config.Config
 	anchorDate   time.Time
 	mode         string
+	view         *dto.AgendaViewDto
 	items        []dto.AgendaItemEnrichedDto
+	sections     []agendaSection
 	cursor       int
 	width        int
 	height       int
@@ -38,7 +52,7 @@ func NewModel(daemonClient *daemon.Client, cfg *config.Config) Model {
 		daemonClient: daemonClient,
 		config:       cfg,
 		anchorDate:   time.Now(),
-		mode:         "day",
+		mode:         dto.AgendaViewModeDay,
 		loading:      true,
 	}
 }
@@ -99,35 +113,77 @@ func (m Model) loadAgenda() tea.Cmd {
 		dateStr := m.anchorDate.Format("2006-01-02")
 		tz := resolveTimezone()
 
-		resp, err := m.daemonClient.SendRequest("get_agenda_view", map[string]interface{}{
-			"mode":        m.mode,
-			"anchor_date": dateStr,
-			"timezone":    tz,
-		})
+		view, err := m.daemonClient.GetAgendaView(tracing.NewActionContext(), m.mode, dateStr, tz)
 		if err != nil {
 			return agendaLoadedMsg{err: err}
 		}
 
-		data, err := json.Marshal(resp.Data)
-		if err != nil {
-			return agendaLoadedMsg{err: fmt.Errorf("failed to marshal agenda data: %w", err)}
+		return agendaLoadedMsg{view: view}
+	}
+}
+
+// flattenView orders the items of an agenda view for display and groups them
+// into sections, so the cursor can move across days in week and month mode.
+func flattenView(view *dto.AgendaViewDto) ([]dto.AgendaItemEnrichedDto, []agendaSection) {
+	if view == nil {
+		return nil, nil
+	}
+
+	var items []dto.AgendaItemEnrichedDto
+	var sections []agendaSection
+
+	addSection := func(title, dateKey string, isToday bool, more int, sectionItems []dto.AgendaItemEnrichedDto) {
+		if len(sectionItems) == 0 && more == 0 {
+			return
 		}
+		start := len(items)
+		items = append(items, sectionItems...)
+		sections = append(sections, agendaSection{
+			title:   title,
+			dateKey: dateKey,
+			isToday: isToday,
+			start:   start,
+			end:     len(items),
+			more:    more,
+		})
+	}
 
-		var items []dto.AgendaItemEnrichedDto
-		if err := json.Unmarshal(data, &items); err != nil {
-			var singleItem dto.AgendaItemEnrichedDto
-			if err2 := json.Unmarshal(data, &singleItem); err2 == nil {
-				items = []dto.AgendaItemEnrichedDto{singleItem}
-			} else {
-				var wrapper struct {
-					Items []dto.AgendaItemEnrichedDto `json:"items"`
-				}
-				if err3 := json.Unmarshal(data, &wrapper); err3 == nil {
-					items = wrapper.Items
+	switch view.Mode {
+	case dto.AgendaViewModeWeek:
+		for _, day := range view.Days {
+			dayItems := append([]dto.AgendaItemEnrichedDto{}, day.AllDayItems...)
+			for _, timed := range day.TimedItems {
+				dayItems = append(dayItems, timed.Item)
+			}
+			addSection(day.Label, day.DateKey, day.IsToday, 0, dayItems)
+		}
+	case dto.AgendaViewModeMonth:
+		for _, day := range view.Days {
+			if !day.IsCurrentMonth {
+				continue
+			}
+			addSection(day.Label, day.DateKey, day.IsToday, day.OverflowCount, day.Items)
+		}
+	default:
+		var timed []dto.AgendaItemEnrichedDto
+		for _, slot := range view.Hours {
+			timed = append(timed, slot.Items...)
+		}
+		addSection("", "", false, 0, timed)
+		addSection("All day", "", false, 0, view.AllDayItems)
+
+		if special := view.SpecialItems; special != nil {
+			var routine []dto.AgendaItemEnrichedDto
+			for _, item := range []*dto.AgendaItemEnrichedDto{special.Wakeup, special.Step, special.Sleep} {
+				if item != nil {
+					routine = append(routine, *item)
 				}
 			}
+			addSection("Routine", "", false, 0, routine)
 		}
-
-		return agendaLoadedMsg{items: items}
 	}
+
+	addSection("Unfinished", "", false, 0, view.UnfinishedItems)
+
+	return items, sections
 }
@@ -1,7 +1,6 @@
 package agenda
 
 import (
-	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -10,6 +9,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"cadence/internal/application/dto"
+	"cadence/internal/infrastructure/tracing"
 )
 
 type agendaItemCompletedMsg struct {
@@ -28,7 +28,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.err = msg.err
 			return m, nil
 		}
-		m.items = msg.items
+		m.view = msg.view
+		m.items, m.sections = flattenView(msg.view)
 		m.err = nil
 		if m.cursor >= len(m.items) {
 			m.cursor = max(0, len(m.items)-1)
@@ -66,11 +67,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.cursor > 0 {
 				m.cursor--
 			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("h", "left"))):
+			if m.mode != dto.AgendaViewModeDay {
+				m.cursor = m.dayStart(-1)
+			}
+		case key.Matches(msg, key.NewBinding(key.WithKeys("l", "right"))):
+			if m.mode != dto.AgendaViewModeDay {
+				m.cursor = m.dayStart(1)
+			}
 		case key.Matches(msg, key.NewBinding(key.WithKeys("n"))):
 			switch m.mode {
-			case "week":
+			case dto.AgendaViewModeWeek:
 				m.anchorDate = m.anchorDate.Add(7 * 24 * time.Hour)
-			case "month":
+			case dto.AgendaViewModeMonth:
 				m.anchorDate = m.anchorDate.AddDate(0, 1, 0)
 			default:
 				m.anchorDate = m.anchorDate.Add(24 * time.Hour)
@@ -80,9 +89,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, m.loadAgenda()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("p"))):
 			switch m.mode {
-			case "week":
+			case dto.AgendaViewModeWeek:
 				m.anchorDate = m.anchorDate.Add(-7 * 24 * time.Hour)
-			case "month":
+			case dto.AgendaViewModeMonth:
 				m.anchorDate = m.anchorDate.AddDate(0, -1, 0)
 			default:
 				m.anchorDate = m.anchorDate.Add(-24 * time.Hour)
@@ -101,12 +110,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, key.NewBinding(key.WithKeys("c"))):
 			return m, m.completeItem()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("d"))):
-			m.mode = "day"
+			m.mode = dto.AgendaViewModeDay
 			m.loading = true
 			m.cursor = 0
 			return m, m.loadAgenda()
 		case key.Matches(msg, key.NewBinding(key.WithKeys("w"))):
-			m.mode = "week"
+			m.mode = dto.AgendaViewModeWeek
+			m.loading = true
+			m.cursor = 0
+			return m, m.loadAgenda()
+		case key.Matches(msg, key.NewBinding(key.WithKeys("m"))):
+			m.mode = dto.AgendaViewModeMonth
 			m.loading = true
 			m.cursor = 0
 			return m, m.loadAgenda()
@@ -143,7 +157,7 @@ func (m Model) completeItem() tea.Cmd {
 
 func (m Model) addNewTask() tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 
 		// Get the active board to find a column for the new task
 		activeBoardID, err := m.daemonClient.GetActiveBoard(ctx)
@@ -155,7 +169,7 @@ func (m Model) addNewTask() tea.Cmd {
 		}
 
 		// Get the board details to find the first column
-		boardResp, err := m.daemonClient.SendRequest("get_board", map[string]interface{}{
+		boardResp, err := m.daemonClient.SendRequestContext(ctx, "get_board", map[string]interface{}{
 			"board_id": activeBoardID,
 		})
 		if err != nil {
@@ -179,7 +193,7 @@ func (m Model) addNewTask() tea.Cmd {
 		columnID := board.Columns[0].ID
 
 		// Create a new task in the first column
-		taskResp, err := m.daemonClient.SendRequest("add_task", map[string]interface{}{
+		taskResp, err := m.daemonClient.SendRequestContext(ctx, "add_task", map[string]interface{}{
 			"title":    "New Task",
 			"columnId": columnID,
 		})
@@ -200,7 +214,7 @@ func (m Model) addNewTask() tea.Cmd {
 		// Create an agenda item for this task on the current anchor date
 		// The backend accepts a date string as agendaId and will auto-create the agenda
 		dateStr := m.anchorDate.Format("2006-01-02")
-		_, err = m.daemonClient.SendRequest("create_agenda_item", map[string]interface{}{
+		_, err = m.daemonClient.SendRequestContext(ctx, "create_agenda_item", map[string]interface{}{
 			"agenda_id": dateStr,
 			"task_id":   task.ID,
 		})
@@ -212,6 +226,27 @@ func (m Model) addNewTask() tea.Cmd {
 	}
 }
 
+// dayStart returns the first item of the day with items before (step -1)
+// or after (step 1) the cursor's, or the cursor when there is none.
+func (m Model) dayStart(step int) int {
+	current := -1
+	for i, section := range m.sections {
+		if m.cursor >= section.start && m.cursor < section.end {
+			current = i
+			break
+		}
+	}
+	if current < 0 {
+		return m.cursor
+	}
+	for i := current + step; i >= 0 && i < len(m.sections); i += step {
+		if section := m.sections[i]; section.dateKey != "" && section.end > section.start {
+			return section.start
+		}
+	}
+	return m.cursor
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -51,6 +51,11 @@ var (
 	statusSkippedStyle = lipgloss.NewStyle().
 				Foreground(lipgloss.Color("#FF6B6B"))
 
+	sectionStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888888")).
+			Bold(true).
+			Underline(true)
+
 	emptyStyle = lipgloss.NewStyle().
 			Foreground(lipgloss.Color("#666666")).
 			Italic(true)
@@ -70,7 +75,11 @@ func (m Model) View() string {
 	}
 
 	modeLabel := strings.ToUpper(m.mode)
-	header := headerStyle.Render(fmt.Sprintf("Agenda — %s", m.anchorDate.Format("Monday, January 2, 2006"))) +
+	label := m.anchorDate.Format("Monday, January 2, 2006")
+	if m.view != nil && m.view.Mode != dto.AgendaViewModeDay && m.view.Label != "" {
+		label = m.view.Label
+	}
+	header := headerStyle.Render(fmt.Sprintf("Agenda — %s", label)) +
 		"  " + modeStyle.Render(fmt.Sprintf("[%s]", modeLabel))
 
 	contentHeight := m.height - 6
@@ -79,13 +88,19 @@ func (m Model) View() string {
 	}
 
 	var content string
-	if len(m.items) == 0 {
-		content = emptyStyle.Render("No agenda items for this date.")
+	if m.view != nil && m.view.Mode != dto.AgendaViewModeDay {
+		content = m.renderGrid(contentHeight)
+	} else if len(m.items) == 0 {
+		content = emptyStyle.Render(fmt.Sprintf("No agenda items for this %s.", m.mode))
 	} else {
 		content = m.renderItems(contentHeight)
 	}
 
-	help := helpStyle.Render("j/k: navigate  n/p: next/prev  t: today  r: refresh  c: complete  a: add task  d: day  w: week")
+	navigation := "j/k: navigate"
+	if m.mode != dto.AgendaViewModeDay {
+		navigation += "  h/l: day"
+	}
+	help := helpStyle.Render(navigation + "  n/p: next/prev  t: today  r: refresh  c: complete  a: add task  d: day  w: week  m: month")
 
 	return agendaStyle.Width(m.width - 4).Render(
 		lipgloss.JoinVertical(lipgloss.Left, header, "", content, "", help),
@@ -93,44 +108,92 @@ func (m Model) View() string {
 }
 
 func (m Model) renderItems(maxHeight int) string {
-	itemHeight := 3
-	maxVisible := maxHeight / itemHeight
-	if maxVisible < 1 {
-		maxVisible = 1
-	}
-
-	startIdx := 0
-	if m.cursor >= maxVisible {
-		startIdx = m.cursor - maxVisible + 1
-	}
-	endIdx := startIdx + maxVisible
-	if endIdx > len(m.items) {
-		endIdx = len(m.items)
-	}
-
 	contentWidth := m.width - 8
 	if contentWidth < 40 {
 		contentWidth = 40
 	}
 
-	var rows []string
+	// Render every section into lines, remembering which item each line
+	// belongs to (-1 for section headers) so we can scroll by line.
+	var lines []string
+	var lineItems []int
+	for _, section := range m.sections {
+		if section.title != "" {
+			title := section.title
+			if section.isToday {
+				title += " (today)"
+			}
+			lines = append(lines, sectionStyle.Render(title))
+			lineItems = append(lineItems, -1)
+		}
+		for i := section.start; i < section.end; i++ {
+			for _, line := range strings.Split(m.renderItem(m.items[i], i == m.cursor, contentWidth), "\n") {
+				lines = append(lines, line)
+				lineItems = append(lineItems, i)
+			}
+		}
+		if section.more > 0 {
+			lines = append(lines, metaStyle.Render(fmt.Sprintf("         +%d more", section.more)))
+			lineItems = append(lineItems, -1)
+		}
+	}
+
+	cursorFirst, cursorLast := -1, 0
+	for i, idx := range lineItems {
+		if idx == m.cursor {
+			if cursorFirst == -1 {
+				cursorFirst = i
+			}
+			cursorLast = i
+		}
+	}
+	if cursorFirst == -1 {
+		cursorFirst = 0
+	}
+	// Keep the header of the cursor's section in view where possible.
+	if cursorFirst > 0 && lineItems[cursorFirst-1] == -1 {
+		cursorFirst--
+	}
 
-	for i := startIdx; i < endIdx; i++ {
-		item := m.items[i]
-		isSelected := i == m.cursor
-		rows = append(rows, m.renderItem(item, isSelected, contentWidth))
+	start := 0
+	if cursorLast >= maxHeight {
+		start = cursorLast - maxHeight + 1
+	}
+	if start > cursorFirst {
+		start = cursorFirst
 	}
+	end := start + maxHeight
+	if end > len(lines) {
+		end = len(lines)
+	}
+
+	above := countItems(lineItems[:start])
+	below := countItems(lineItems[end:])
 
-	if startIdx > 0 {
-		rows = append([]string{metaStyle.Render(fmt.Sprintf("  ▲ %d more above", startIdx))}, rows...)
+	rows := lines[start:end]
+	if above > 0 {
+		rows = append([]string{metaStyle.Render(fmt.Sprintf("  ▲ %d more above", above))}, rows...)
 	}
-	if endIdx < len(m.items) {
-		rows = append(rows, metaStyle.Render(fmt.Sprintf("  ▼ %d more below", len(m.items)-endIdx)))
+	if below > 0 {
+		rows = append(rows, metaStyle.Render(fmt.Sprintf("  ▼ %d more below", below)))
 	}
 
 	return strings.Join(rows, "\n")
 }
 
+// countItems returns the number of distinct items referenced by lineItems.
+func countItems(lineItems []int) int {
+	count := 0
+	prev := -1
+	for _, idx := range lineItems {
+		if idx != -1 && idx != prev {
+			count++
+		}
+		prev = idx
+	}
+	return count
+}
+
 func (m Model) renderItem(item dto.AgendaItemEnrichedDto, isSelected bool, width int) string {
 	timeStr := "      "
 	if item.StartAt != nil {
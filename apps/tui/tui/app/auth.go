@@ -0,0 +1,159 @@
+package app
+
+import (
+	"os"
+	"os/exec"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"cadence/internal/daemon"
+	"cadence/internal/infrastructure/auth"
+	"cadence/internal/infrastructure/tracing"
+)
+
+type authRequiredMsg struct {
+	reason string
+}
+
+type loginFinishedMsg struct {
+	err error
+}
+
+type tokenReloadedMsg struct {
+	err error
+}
+
+var (
+	authModalStyle = lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(lipgloss.Color("#FF6B6B")).
+			Padding(1, 3)
+
+	authTitleStyle = lipgloss.NewStyle().
+			Bold(true).
+			Foreground(lipgloss.Color("#FF6B6B"))
+
+	authHintStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color("#888888"))
+)
+
+// checkAuth asks the daemon whether the stored token is still valid, so an
+// expired session is caught at startup and not on the first failed action.
+func (m AppModel) checkAuth() tea.Cmd {
+	return func() tea.Msg {
+		whoami, err := m.daemonClient.WhoAmI(tracing.NewActionContext())
+		if err != nil || whoami.Authenticated {
+			return nil
+		}
+		return authRequiredMsg{reason: whoami.Reason}
+	}
+}
+
+// runLogin suspends the TUI and runs `cadence login` in the terminal.
+func (m AppModel) runLogin() tea.Cmd {
+	exe, err := os.Executable()
+	if err != nil {
+		return func() tea.Msg { return loginFinishedMsg{err: err} }
+	}
+
+	args := []string{"login"}
+	if !auth.HasLocalBrowser() {
+		args = append(args, "--no-browser")
+	}
+
+	return tea.ExecProcess(exec.Command(exe, args...), func(err error) tea.Msg {
+		return loginFinishedMsg{err: err}
+	})
+}
+
+func (m AppModel) reloadToken() tea.Cmd {
+	return func() tea.Msg {
+		return tokenReloadedMsg{err: m.daemonClient.ReloadToken(tracing.NewActionContext())}
+	}
+}
+
+func (m AppModel) updateAuth(msg tea.Msg) (AppModel, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case authRequiredMsg:
+		m.authRequired = true
+		m.authReason = msg.reason
+		m.authError = ""
+		return m, nil, true
+
+	case loginFinishedMsg:
+		if msg.err != nil {
+			m.authError = "Login failed: " + msg.err.Error()
+			return m, nil, true
+		}
+		return m, m.reloadToken(), true
+
+	case tokenReloadedMsg:
+		if msg.err != nil {
+			m.authError = "Daemon could not reload the token: " + msg.err.Error()
+			return m, nil, true
+		}
+		m.authRequired = false
+		m.authReason = ""
+		m.authError = ""
+		return m, tea.Batch(
+			m.kanbanModel.Init(),
+			m.notesModel.Init(),
+			m.agendaModel.Init(),
+		), true
+
+	case tea.KeyMsg:
+		if !m.authRequired {
+			return m, nil, false
+		}
+		switch msg.String() {
+		case "l", "enter":
+			return m, m.runLogin(), true
+		case "esc":
+			m.authRequired = false
+			return m, nil, true
+		case "ctrl+c":
+			return m, tea.Quit, true
+		}
+		return m, nil, true
+	}
+
+	return m, nil, false
+}
+
+func isAuthRequired(n *daemon.Notification) bool {
+	return n != nil && n.Type == daemon.NotificationAuthRequired
+}
+
+func isAccountSwitched(n *daemon.Notification) bool {
+	return n != nil && n.Type == daemon.NotificationAccountSwitched
+}
+
+func notificationReason(n *daemon.Notification) string {
+	if data, ok := n.Data.(map[string]interface{}); ok {
+		if reason, ok := data["reason"].(string); ok {
+			return reason
+		}
+	}
+	return ""
+}
+
+func (m AppModel) authModalView() string {
+	reason := m.authReason
+	if reason == "" {
+		reason = "Your session is no longer valid."
+	}
+
+	lines := []string{
+		authTitleStyle.Render("Sign-in required"),
+		"",
+		reason,
+	}
+	if m.authError != "" {
+		lines = append(lines, "", m.authError)
+	}
+	lines = append(lines, "", authHintStyle.Render("l/enter: log in • esc: dismiss"))
+
+	modal := authModalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	return lipgloss.Place(m.width, m.height-4, lipgloss.Center, lipgloss.Center, modal)
+}
@@ -0,0 +1,128 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"cadence/internal/daemon"
+	"cadence/internal/infrastructure/tracing"
+)
+
+type focusStatusMsg struct {
+	status *daemon.FocusStatus
+}
+
+type focusTickMsg time.Time
+
+// The countdown is rendered inside the status bar, so it repeats the bar's
+// background.
+var focusWorkStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("#FF6B6B")).
+	Background(lipgloss.Color("#1A1A1A"))
+
+var focusBreakStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("#4ECDC4")).
+	Background(lipgloss.Color("#1A1A1A"))
+
+// checkFocus picks up a focus session started before the TUI opened;
+// later changes arrive as focus_phase notifications.
+func (m AppModel) checkFocus() tea.Cmd {
+	return func() tea.Msg {
+		status, err := m.daemonClient.GetFocusStatus(tracing.NewActionContext())
+		if err != nil || status == nil {
+			return nil
+		}
+		return focusStatusMsg{status: status}
+	}
+}
+
+func focusTick() tea.Cmd {
+	return tea.Tick(time.Second, func(t time.Time) tea.Msg {
+		return focusTickMsg(t)
+	})
+}
+
+func isFocusPhase(n *daemon.Notification) bool {
+	return n != nil && n.Type == daemon.NotificationFocusPhase
+}
+
+// notificationFocusStatus decodes the status a focus_phase notification
+// carries.
+func notificationFocusStatus(n *daemon.Notification) *daemon.FocusStatus {
+	data, err := json.Marshal(n.Data)
+	if err != nil {
+		return nil
+	}
+	var status daemon.FocusStatus
+	if err := json.Unmarshal(data, &status); err != nil {
+		return nil
+	}
+	return &status
+}
+
+// setFocus shows a session in the status bar, starting the once-a-second
+// countdown if it is not already running.
+func (m AppModel) setFocus(status *daemon.FocusStatus) (AppModel, tea.Cmd) {
+	if status == nil || status.Finished {
+		m.focus = nil
+		return m, nil
+	}
+	m.focus = status
+	if m.focusTicking {
+		return m, nil
+	}
+	m.focusTicking = true
+	return m, focusTick()
+}
+
+func (m AppModel) updateFocus(msg tea.Msg) (AppModel, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case focusStatusMsg:
+		updated, cmd := m.setFocus(msg.status)
+		return updated, cmd, true
+
+	case focusTickMsg:
+		if m.focus == nil {
+			m.focusTicking = false
+			return m, nil, true
+		}
+		return m, focusTick(), true
+	}
+
+	return m, nil, false
+}
+
+// focusStatusView renders the countdown shown in the status bar, such as
+// "● Work 12:34 · 2/4".
+func (m AppModel) focusStatusView() string {
+	if m.focus == nil {
+		return ""
+	}
+
+	remaining := time.Until(m.focus.PhaseEnd)
+	if remaining < 0 {
+		remaining = 0
+	}
+	secs := int(remaining.Round(time.Second) / time.Second)
+
+	label, style := "Work", focusWorkStyle
+	switch m.focus.Phase {
+	case daemon.FocusPhaseShortBreak:
+		label, style = "Break", focusBreakStyle
+	case daemon.FocusPhaseLongBreak:
+		label, style = "Long break", focusBreakStyle
+	}
+
+	cycle := fmt.Sprintf("%d", m.focus.Cycle)
+	if m.focus.Cycles > 0 {
+		cycle = fmt.Sprintf("%d/%d", m.focus.Cycle, m.focus.Cycles)
+	}
+
+	return style.Render(fmt.Sprintf("● %s %02d:%02d", label, secs/60, secs%60)) + " · " + cycle
+}
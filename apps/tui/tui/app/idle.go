@@ -0,0 +1,135 @@
+package app
+
+import (
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"cadence/internal/daemon"
+	"cadence/internal/infrastructure/tracing"
+)
+
+type idlePeriodsMsg struct {
+	periods []daemon.IdlePeriod
+}
+
+type idleResolvedMsg struct {
+	id  string
+	err error
+}
+
+var idleModalStyle = lipgloss.NewStyle().
+	Border(lipgloss.RoundedBorder()).
+	BorderForeground(lipgloss.Color("#FFE66D")).
+	Padding(1, 3)
+
+var idleTitleStyle = lipgloss.NewStyle().
+	Bold(true).
+	Foreground(lipgloss.Color("#FFE66D"))
+
+// checkIdlePeriods asks the daemon for time cut off by idle detection, so
+// the user can decide to keep it when the TUI opens.
+func (m AppModel) checkIdlePeriods() tea.Cmd {
+	if !m.config.TimeTracking.PromptIdle {
+		return nil
+	}
+	return func() tea.Msg {
+		periods, err := m.daemonClient.GetIdlePeriods(tracing.NewActionContext())
+		if err != nil || len(periods) == 0 {
+			return nil
+		}
+		return idlePeriodsMsg{periods: periods}
+	}
+}
+
+func (m AppModel) resolveIdlePeriod(id string, keep bool) tea.Cmd {
+	return func() tea.Msg {
+		err := m.daemonClient.ResolveIdlePeriod(tracing.NewActionContext(), id, keep)
+		return idleResolvedMsg{id: id, err: err}
+	}
+}
+
+func (m AppModel) updateIdle(msg tea.Msg) (AppModel, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case idlePeriodsMsg:
+		m.idlePeriods = msg.periods
+		m.idleError = ""
+		return m, nil, true
+
+	case idleResolvedMsg:
+		if msg.err != nil {
+			m.idleError = msg.err.Error()
+			return m, nil, true
+		}
+		m.idleError = ""
+		if len(m.idlePeriods) > 0 && m.idlePeriods[0].ID == msg.id {
+			m.idlePeriods = m.idlePeriods[1:]
+		}
+		return m, nil, true
+
+	case tea.KeyMsg:
+		if len(m.idlePeriods) == 0 || m.authRequired {
+			return m, nil, false
+		}
+		period := m.idlePeriods[0]
+		switch msg.String() {
+		case "k", "enter":
+			return m, m.resolveIdlePeriod(period.ID, true), true
+		case "d":
+			return m, m.resolveIdlePeriod(period.ID, false), true
+		case "esc":
+			// Ask again next time the TUI opens.
+			m.idlePeriods = nil
+			return m, nil, true
+		case "ctrl+c":
+			return m, tea.Quit, true
+		}
+		return m, nil, true
+	}
+
+	return m, nil, false
+}
+
+func (m AppModel) idleModalView() string {
+	period := m.idlePeriods[0]
+
+	target := period.ProjectName
+	if target == "" {
+		target = "your project"
+	}
+	if period.TaskID != "" {
+		target += " (" + period.TaskID + ")"
+	}
+
+	lines := []string{
+		idleTitleStyle.Render("Keep idle time?"),
+		"",
+		fmt.Sprintf("You were idle from %s to %s (%s)",
+			formatIdleTime(period.Start, period.End), period.End.Local().Format("15:04"),
+			period.End.Sub(period.Start).Round(time.Minute)),
+		"while tracking " + target + ".",
+	}
+	if m.idleError != "" {
+		lines = append(lines, "", m.idleError)
+	}
+	if remaining := len(m.idlePeriods) - 1; remaining > 0 {
+		lines = append(lines, "", authHintStyle.Render(fmt.Sprintf("%d more after this", remaining)))
+	}
+	lines = append(lines, "", authHintStyle.Render("k/enter: keep • d: discard • esc: decide later"))
+
+	modal := idleModalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	return lipgloss.Place(m.width, m.height-4, lipgloss.Center, lipgloss.Center, modal)
+}
+
+// formatIdleTime includes the date when the idle period spans midnight or
+// is not from today.
+func formatIdleTime(start, end time.Time) string {
+	start, end = start.Local(), end.Local()
+	if start.YearDay() != end.YearDay() || start.Year() != end.Year() ||
+		start.YearDay() != time.Now().YearDay() {
+		return start.Format("Mon 15:04")
+	}
+	return start.Format("15:04")
+}
@@ -28,6 +28,19 @@ type AppModel struct {
 	config       *config.Config
 	width        int
 	height       int
+
+	authRequired bool
+	authReason   string
+	authError    string
+
+	idlePeriods []daemon.IdlePeriod
+	idleError   string
+
+	projectPrompts     []daemon.ProjectPrompt
+	projectPromptError string
+
+	focus        *daemon.FocusStatus
+	focusTicking bool
 }
 
 func NewAppModel(cfg *config.Config, daemonClient *daemon.Client, initialTab int) AppModel {
@@ -49,5 +62,9 @@ func (m AppModel) Init() tea.Cmd {
 		m.kanbanModel.Init(),
 		m.notesModel.Init(),
 		m.agendaModel.Init(),
+		m.checkAuth(),
+		m.checkIdlePeriods(),
+		m.checkProjectPrompts(),
+		m.checkFocus(),
 	)
 }
@@ -0,0 +1,143 @@
+package app
+
+import (
+	"encoding/json"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"cadence/internal/daemon"
+	"cadence/internal/infrastructure/tracing"
+)
+
+type projectPromptsMsg struct {
+	prompts []daemon.ProjectPrompt
+}
+
+type projectPromptResolvedMsg struct {
+	id  string
+	err error
+}
+
+// checkProjectPrompts asks the daemon for projects and boards it did not
+// create while the TUI was closed, with session_tracking.create: ask.
+func (m AppModel) checkProjectPrompts() tea.Cmd {
+	if m.config.SessionTracking.Create != "ask" {
+		return nil
+	}
+	return func() tea.Msg {
+		prompts, err := m.daemonClient.GetProjectPrompts(tracing.NewActionContext())
+		if err != nil || len(prompts) == 0 {
+			return nil
+		}
+		return projectPromptsMsg{prompts: prompts}
+	}
+}
+
+func (m AppModel) resolveProjectPrompt(id string, create bool) tea.Cmd {
+	return func() tea.Msg {
+		err := m.daemonClient.ResolveProjectPrompt(tracing.NewActionContext(), id, create)
+		return projectPromptResolvedMsg{id: id, err: err}
+	}
+}
+
+// addProjectPrompt queues a prompt the daemon announced, once.
+func (m AppModel) addProjectPrompt(prompt daemon.ProjectPrompt) AppModel {
+	for _, p := range m.projectPrompts {
+		if p.ID == prompt.ID {
+			return m
+		}
+	}
+	m.projectPrompts = append(m.projectPrompts, prompt)
+	return m
+}
+
+func (m AppModel) updateProjectPrompt(msg tea.Msg) (AppModel, tea.Cmd, bool) {
+	switch msg := msg.(type) {
+	case projectPromptsMsg:
+		for _, prompt := range msg.prompts {
+			m = m.addProjectPrompt(prompt)
+		}
+		m.projectPromptError = ""
+		return m, nil, true
+
+	case projectPromptResolvedMsg:
+		if msg.err != nil {
+			m.projectPromptError = msg.err.Error()
+			return m, nil, true
+		}
+		m.projectPromptError = ""
+		if len(m.projectPrompts) > 0 && m.projectPrompts[0].ID == msg.id {
+			m.projectPrompts = m.projectPrompts[1:]
+		}
+		return m, nil, true
+
+	case tea.KeyMsg:
+		if len(m.projectPrompts) == 0 || m.authRequired || len(m.idlePeriods) > 0 {
+			return m, nil, false
+		}
+		prompt := m.projectPrompts[0]
+		switch msg.String() {
+		case "c", "enter":
+			return m, m.resolveProjectPrompt(prompt.ID, true), true
+		case "n":
+			return m, m.resolveProjectPrompt(prompt.ID, false), true
+		case "esc":
+			// Ask again next time the TUI opens.
+			m.projectPrompts = nil
+			return m, nil, true
+		case "ctrl+c":
+			return m, tea.Quit, true
+		}
+		return m, nil, true
+	}
+
+	return m, nil, false
+}
+
+func isProjectPrompt(n *daemon.Notification) bool {
+	return n != nil && n.Type == daemon.NotificationProjectPrompt
+}
+
+// notificationProjectPrompt decodes the prompt a project_prompt
+// notification carries.
+func notificationProjectPrompt(n *daemon.Notification) (daemon.ProjectPrompt, bool) {
+	var prompt daemon.ProjectPrompt
+	data, err := json.Marshal(n.Data)
+	if err != nil {
+		return prompt, false
+	}
+	if err := json.Unmarshal(data, &prompt); err != nil {
+		return prompt, false
+	}
+	return prompt, prompt.ID != ""
+}
+
+func (m AppModel) projectPromptModalView() string {
+	prompt := m.projectPrompts[0]
+
+	title := "Create project?"
+	question := fmt.Sprintf("Create project %q with board %q", prompt.Project, prompt.Board)
+	if prompt.ProjectExists {
+		title = "Create board?"
+		question = fmt.Sprintf("Create board %q in project %q", prompt.Board, prompt.Project)
+	}
+
+	lines := []string{
+		idleTitleStyle.Render(title),
+		"",
+		question,
+		"for " + prompt.WorkingDir + "?",
+	}
+	if m.projectPromptError != "" {
+		lines = append(lines, "", m.projectPromptError)
+	}
+	if remaining := len(m.projectPrompts) - 1; remaining > 0 {
+		lines = append(lines, "", authHintStyle.Render(fmt.Sprintf("%d more after this", remaining)))
+	}
+	lines = append(lines, "", authHintStyle.Render("c/enter: create • n: don't create • esc: decide later"))
+
+	modal := idleModalStyle.Render(lipgloss.JoinVertical(lipgloss.Left, lines...))
+	return lipgloss.Place(m.width, m.height-4, lipgloss.Center, lipgloss.Center, modal)
+}
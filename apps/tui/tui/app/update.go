@@ -28,7 +28,46 @@ var tabKeys = tabKeyMap{
 }
 
 func (m AppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if updated, cmd, handled := m.updateAuth(msg); handled {
+		return updated, cmd
+	}
+	if updated, cmd, handled := m.updateIdle(msg); handled {
+		return updated, cmd
+	}
+	if updated, cmd, handled := m.updateProjectPrompt(msg); handled {
+		return updated, cmd
+	}
+	if updated, cmd, handled := m.updateFocus(msg); handled {
+		return updated, cmd
+	}
+
 	switch msg := msg.(type) {
+	case kanban.NotificationMsg:
+		// The board subscription belongs to the kanban model, so it gets
+		// every notification even when another tab is active.
+		if n := msg.Notification(); isAuthRequired(n) {
+			m.authRequired = true
+			m.authReason = notificationReason(n)
+			m.authError = ""
+		}
+		updated, cmd := m.kanbanModel.Update(msg)
+		m.kanbanModel = updated.(kanban.Model)
+		if isAccountSwitched(msg.Notification()) {
+			m.authRequired = false
+			cmd = tea.Batch(cmd, m.notesModel.Init(), m.agendaModel.Init())
+		}
+		if n := msg.Notification(); isProjectPrompt(n) {
+			if prompt, ok := notificationProjectPrompt(n); ok {
+				m = m.addProjectPrompt(prompt)
+			}
+		}
+		if n := msg.Notification(); isFocusPhase(n) {
+			var focusCmd tea.Cmd
+			m, focusCmd = m.setFocus(notificationFocusStatus(n))
+			cmd = tea.Batch(cmd, focusCmd)
+		}
+		return m, cmd
+
 	case tea.WindowSizeMsg:
 		m.width = msg.Width
 		m.height = msg.Height
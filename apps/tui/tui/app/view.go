@@ -30,6 +30,18 @@ func (m AppModel) View() string {
 		m.statusBar.SetRight(right)
 	}
 
+	if focus := m.focusStatusView(); focus != "" {
+		m.statusBar.SetRight(focus + "  " + m.statusBar.Right())
+	}
+
+	if m.authRequired {
+		content = m.authModalView()
+	} else if len(m.idlePeriods) > 0 {
+		content = m.idleModalView()
+	} else if len(m.projectPrompts) > 0 {
+		content = m.projectPromptModalView()
+	}
+
 	statusBarView := m.statusBar.View(m.width)
 
 	return lipgloss.JoinVertical(lipgloss.Left, tabBarView, content, statusBarView)
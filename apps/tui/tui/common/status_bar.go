@@ -28,6 +28,10 @@ func (s *StatusBar) SetRight(msg string) {
 	s.right = msg
 }
 
+func (s StatusBar) Right() string {
+	return s.right
+}
+
 func (s StatusBar) View(width int) string {
 	left := statusBarStyle.Render(s.left)
 	right := statusBarStyle.Render(s.right)
@@ -1,10 +1,10 @@
 package kanban
 
 import (
-	"context"
 	"os"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"cadence/internal/infrastructure/tracing"
 	"cadence/pkg/editor"
 )
 
@@ -20,7 +20,7 @@ func checkBoardChange(m Model) tea.Cmd {
 			return nil
 		}
 
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 		activeBoardID, err := client.GetActiveBoard(ctx)
 		if err != nil || activeBoardID == "" {
 			return nil
@@ -56,7 +56,7 @@ func (m *Model) moveTaskRight() tea.Cmd {
 
 	client := m.daemonClient
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 		_, err := client.MoveTask(ctx, taskID, targetColumnID)
 		return taskMovedMsg{err: err}
 	}
@@ -80,7 +80,7 @@ func (m *Model) moveTaskLeft() tea.Cmd {
 
 	client := m.daemonClient
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 		_, err := client.MoveTask(ctx, taskID, targetColumnID)
 		return taskMovedMsg{err: err}
 	}
@@ -100,7 +100,7 @@ func (m *Model) addTask() tea.Cmd {
 func (m Model) createTask(fields *editor.TaskFields, columnID string) tea.Cmd {
 	client := m.daemonClient
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 		_, err := client.CreateTask(ctx, fields.Title, fields.Description, fields.Priority, columnID)
 		return taskAddedMsg{err: err}
 	}
@@ -152,7 +152,7 @@ func (m Model) deleteTask() tea.Cmd {
 	taskID := task.ID
 	client := m.daemonClient
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 		err := client.DeleteTask(ctx, taskID)
 		return taskDeletedMsg{err: err}
 	}
@@ -160,7 +160,7 @@ func (m Model) deleteTask() tea.Cmd {
 
 func (m Model) reloadBoard() tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 		board, err := fetchBoard(ctx, m.daemonClient, m.boardID)
 		if err != nil {
 			return nil
@@ -11,6 +11,7 @@ import (
 	"cadence/internal/application/dto"
 	"cadence/internal/daemon"
 	"cadence/internal/infrastructure/config"
+	"cadence/internal/infrastructure/tracing"
 )
 
 const tasksPerPage = 10
@@ -45,6 +46,10 @@ type NotificationMsg struct {
 	notification *daemon.Notification
 }
 
+func (msg NotificationMsg) Notification() *daemon.Notification {
+	return msg.notification
+}
+
 type boardLoadedMsg struct {
 	board *dto.BoardDetailDto
 	err   error
@@ -107,7 +112,7 @@ func (m Model) Init() tea.Cmd {
 
 func (m Model) loadActiveBoard() tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 		activeBoardID, err := m.daemonClient.GetActiveBoard(ctx)
 		if err != nil {
 			return boardLoadedMsg{err: fmt.Errorf("failed to get active board: %w", err)}
@@ -144,7 +149,7 @@ func (m Model) fetchAllColumnTasks() tea.Cmd {
 	pages := m.columnPages
 
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 		type result struct {
 			columnID string
 			tasks    []dto.TaskDto
@@ -199,7 +204,7 @@ func (m Model) fetchAllColumnTasks() tea.Cmd {
 
 func fetchColumnTasks(client *daemon.Client, columnID string, page int) tea.Cmd {
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 		resp, err := client.ListTasks(ctx, columnID, page, tasksPerPage)
 		if err != nil {
 			return columnTasksLoadedMsg{columnID: columnID, err: err}
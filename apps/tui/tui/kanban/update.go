@@ -1,13 +1,14 @@
 package kanban
 
 import (
-	"context"
 	"sort"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"cadence/internal/application/dto"
+	"cadence/internal/daemon"
+	"cadence/internal/infrastructure/tracing"
 	"cadence/pkg/editor"
 )
 
@@ -48,10 +49,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case NotificationMsg:
+		if msg.notification.Type == daemon.NotificationAccountSwitched {
+			// The daemon drops subscriptions on an account switch; load the
+			// new account's active board and subscribe to it afresh.
+			_ = m.daemonClient.Unsubscribe()
+			m.subscribed = false
+			return m, m.loadActiveBoard()
+		}
 		if msg.notification.Type == "board_updated" ||
 			msg.notification.Type == "task_moved" ||
 			msg.notification.Type == "task_created" ||
-			msg.notification.Type == "task_updated" {
+			msg.notification.Type == "task_updated" ||
+			msg.notification.Type == "task_deleted" {
 			return m, m.reloadBoard()
 		}
 		return m, m.waitForNotification()
@@ -263,7 +272,7 @@ func (m *Model) loadMoreTasks(colIndex int) tea.Cmd {
 	copy(existingTasks, col.Tasks)
 
 	return func() tea.Msg {
-		ctx := context.Background()
+		ctx := tracing.NewActionContext()
 		resp, err := client.ListTasks(ctx, columnID, nextPage, tasksPerPage)
 		if err != nil {
 			return columnTasksLoadedMsg{columnID: columnID, err: err}
@@ -0,0 +1,106 @@
+'use client';
+
+import { useEffect, useState } from 'react';
+import { useRouter } from 'next/navigation';
+import { authClient } from '@/lib/auth-client';
+
+type DeviceState = 'loading' | 'ready' | 'approved' | 'denied';
+
+// Approves the code `cadence login --device` prints, signing the terminal in
+// as the current user.
+export default function DevicePage() {
+  const router = useRouter();
+  const [userCode, setUserCode] = useState('');
+  const [state, setState] = useState<DeviceState>('loading');
+  const [error, setError] = useState('');
+
+  useEffect(() => {
+    const code = new URLSearchParams(window.location.search).get('user_code') ?? '';
+    setUserCode(code);
+
+    authClient.getSession().then(({ data }) => {
+      if (!data?.session) {
+        const back = code ? `/device?user_code=${encodeURIComponent(code)}` : '/device';
+        router.replace(`/sign-in?callbackURL=${encodeURIComponent(back)}`);
+        return;
+      }
+      setState('ready');
+    });
+  }, [router]);
+
+  const answer = async (approve: boolean) => {
+    setError('');
+    const code = userCode.trim().replace(/-/g, '').toUpperCase();
+
+    const check = await authClient.device({ query: { user_code: code } });
+    if (check.error) {
+      setError('Unknown or expired code');
+      return;
+    }
+
+    const result = approve
+      ? await authClient.device.approve({ userCode: code })
+      : await authClient.device.deny({ userCode: code });
+    if (result.error) {
+      setError(result.error.message ?? 'Could not answer the request');
+      return;
+    }
+    setState(approve ? 'approved' : 'denied');
+  };
+
+  if (state === 'loading') {
+    return (
+      <div className="min-h-screen flex items-center justify-center">
+        <div className="animate-pulse font-mono text-muted-foreground">Loading...</div>
+      </div>
+    );
+  }
+
+  return (
+    <div className="min-h-screen flex items-center justify-center">
+      <div className="w-full max-w-sm space-y-8 px-6">
+        <div className="text-center space-y-2">
+          <h1 className="font-mono font-bold text-3xl text-primary tracking-tight">
+            cadence
+          </h1>
+          <p className="text-muted-foreground text-sm">
+            {state === 'approved'
+              ? 'Device signed in. You can return to your terminal.'
+              : state === 'denied'
+                ? 'Sign-in request denied.'
+                : 'Enter the code shown in your terminal'}
+          </p>
+        </div>
+
+        {state === 'ready' && (
+          <div className="space-y-4">
+            <input
+              value={userCode}
+              onChange={(e) => setUserCode(e.target.value)}
+              placeholder="ABCD-EFGH"
+              autoFocus
+              className="w-full px-4 py-3 rounded-lg bg-card border border-border font-mono text-center text-lg tracking-widest uppercase"
+            />
+            {error && <p className="text-sm text-destructive text-center">{error}</p>}
+            <div className="flex gap-3">
+              <button
+                onClick={() => answer(false)}
+                disabled={!userCode.trim()}
+                className="flex-1 px-4 py-3 rounded-lg bg-card border border-border text-sm font-medium hover:bg-accent transition-colors disabled:opacity-50"
+              >
+                Deny
+              </button>
+              <button
+                onClick={() => answer(true)}
+                disabled={!userCode.trim()}
+                className="flex-1 px-4 py-3 rounded-lg bg-primary text-primary-foreground text-sm font-medium hover:opacity-90 transition-opacity disabled:opacity-50"
+              >
+                Approve
+              </button>
+            </div>
+          </div>
+        )}
+      </div>
+    </div>
+  );
+}
@@ -4,9 +4,12 @@ import { authClient } from '@/lib/auth-client';
 
 export default function SignInPage() {
   const handleGoogleSignIn = () => {
+    // Pages such as /device send the user back once signed in; only paths
+    // on this site are followed.
+    const next = new URLSearchParams(window.location.search).get('callbackURL');
     authClient.signIn.social({
       provider: 'google',
-      callbackURL: '/agenda',
+      callbackURL: next?.startsWith('/') && !next.startsWith('//') ? next : '/agenda',
     });
   };
 